@@ -0,0 +1,181 @@
+// Package cache keeps fetched transcripts on disk so repeated runs skip the network.
+//
+// Each entry is a JSON file named after the video and a hash of the language selection
+// it was fetched with, so different selections of the same video never collide.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// Entry is a cached transcript together with the caption track it came from
+type Entry struct {
+	VideoID   string                       `json:"videoId"`
+	Key       string                       `json:"key"`
+	Track     transcript.Transcript        `json:"track"`
+	Entries   []transcript.TranscriptEntry `json:"entries"`
+	FetchedAt time.Time                    `json:"fetchedAt"`
+}
+
+// Info describes a cache file without its entries
+type Info struct {
+	Path      string    `json:"path"`
+	VideoID   string    `json:"videoId"`
+	Language  string    `json:"language"`
+	Entries   int       `json:"entries"`
+	Size      int64     `json:"size"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// Cache is a directory of cached transcripts
+type Cache struct {
+	dir string
+	now func() time.Time
+}
+
+// DefaultDir returns the per-user cache directory, e.g. ~/.cache/yt-words on Linux
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "yt-words"), nil
+}
+
+// Open opens the cache rooted at dir, creating the directory if needed
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %v", err)
+	}
+	return &Cache{dir: dir, now: time.Now}, nil
+}
+
+// Dir returns the directory the cache was opened with
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// path returns the file caching the transcript of videoID for key
+func (c *Cache) path(videoID, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, videoID+"-"+hex.EncodeToString(sum[:4])+".json")
+}
+
+// Get returns the cached transcript of videoID for key. A nil Cache always misses.
+func (c *Cache) Get(videoID, key string) (Entry, bool) {
+	if c == nil {
+		return Entry{}, false
+	}
+	b, err := os.ReadFile(c.path(videoID, key))
+	if err != nil {
+		return Entry{}, false
+	}
+	var e Entry
+	// A corrupt or foreign file is treated as a miss and overwritten by the next Put
+	if err := json.Unmarshal(b, &e); err != nil || e.VideoID != videoID || e.Key != key {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Put stores an entry, replacing any previous one for the same video and key. A nil Cache
+// discards it.
+func (c *Cache) Put(e Entry) error {
+	if c == nil {
+		return nil
+	}
+	if e.VideoID == "" || strings.ContainsAny(e.VideoID, `/\`) {
+		return fmt.Errorf("invalid video ID for cache: %q", e.VideoID)
+	}
+	if e.FetchedAt.IsZero() {
+		e.FetchedAt = c.now()
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, "."+e.VideoID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(e.VideoID, e.Key))
+}
+
+// List describes every cached transcript, oldest first
+func (c *Cache) List() ([]Info, error) {
+	matches, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]Info, 0, len(matches))
+	for _, path := range matches {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var e Entry
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("error decoding %s: %v", path, err)
+		}
+		infos = append(infos, Info{
+			Path:      path,
+			VideoID:   e.VideoID,
+			Language:  e.Track.LanguageCode,
+			Entries:   len(e.Entries),
+			Size:      int64(len(b)),
+			FetchedAt: e.FetchedAt,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].FetchedAt.Before(infos[j].FetchedAt) })
+	return infos, nil
+}
+
+// Clear removes every cached transcript and returns how many were removed
+func (c *Cache) Clear() (int, error) {
+	return c.remove(func(time.Time) bool { return true })
+}
+
+// Prune removes transcripts fetched more than age ago and returns how many were removed
+func (c *Cache) Prune(age time.Duration) (int, error) {
+	cutoff := c.now().Add(-age)
+	return c.remove(func(fetched time.Time) bool { return fetched.Before(cutoff) })
+}
+
+func (c *Cache) remove(match func(fetched time.Time) bool) (int, error) {
+	infos, err := c.List()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, info := range infos {
+		if !match(info.FetchedAt) {
+			continue
+		}
+		if err := os.Remove(info.Path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestCache(t *testing.T) {
+	c, err := Open(filepath.Join(t.TempDir(), "nested"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	if _, ok := c.Get("abcdefghijk", "en"); ok {
+		t.Error("Get() hit on empty cache")
+	}
+
+	entry := Entry{
+		VideoID: "abcdefghijk",
+		Key:     "en",
+		Track:   transcript.Transcript{LanguageCode: "en"},
+		Entries: []transcript.TranscriptEntry{{Text: "hello", Start: 1, Duration: 2}},
+	}
+	if err := c.Put(entry); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	old := Entry{VideoID: "abcdefghijk", Key: "de", FetchedAt: now.Add(-48 * time.Hour)}
+	if err := c.Put(old); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, ok := c.Get("abcdefghijk", "en")
+	if !ok || got.Track.LanguageCode != "en" || len(got.Entries) != 1 || !got.FetchedAt.Equal(now) {
+		t.Errorf("Get() = %+v, %v; want cached entry fetched now", got, ok)
+	}
+
+	infos, err := c.List()
+	if err != nil || len(infos) != 2 || !infos[0].FetchedAt.Equal(old.FetchedAt) || infos[1].Entries != 1 {
+		t.Errorf("List() = %+v, %v; want old entry first", infos, err)
+	}
+
+	if n, err := c.Prune(24 * time.Hour); err != nil || n != 1 {
+		t.Errorf("Prune() = %d, %v; want 1", n, err)
+	}
+	if _, ok := c.Get("abcdefghijk", "de"); ok {
+		t.Error("Get() hit on pruned entry")
+	}
+	if n, err := c.Clear(); err != nil || n != 1 {
+		t.Errorf("Clear() = %d, %v; want 1", n, err)
+	}
+	if files, _ := os.ReadDir(c.Dir()); len(files) != 0 {
+		t.Errorf("cache directory has %d files after Clear; want 0", len(files))
+	}
+}
+
+func TestCache_Nil(t *testing.T) {
+	var c *Cache
+	if err := c.Put(Entry{VideoID: "abcdefghijk"}); err != nil {
+		t.Errorf("nil Put() error = %v", err)
+	}
+	if _, ok := c.Get("abcdefghijk", ""); ok {
+		t.Error("nil Get() hit")
+	}
+}
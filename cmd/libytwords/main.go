@@ -0,0 +1,62 @@
+// Command libytwords builds a C shared library exposing the transcript client.
+//
+//	go build -buildmode=c-shared -o libytwords.so ./cmd/libytwords
+//
+// Every exported function returns a newly allocated C string holding a JSON object
+// with either a "result" or an "error" key. Callers must release it with FreeString.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"unsafe"
+
+	"github.com/mjlefevre/yt-words-go/mobile"
+)
+
+var client = mobile.NewClient("")
+
+type response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+//export GetTranscriptJSON
+func GetTranscriptJSON(videoID *C.char, lang *C.char) *C.char {
+	return respond(client.GetTranscriptJSON(C.GoString(videoID), C.GoString(lang)))
+}
+
+//export ListLanguagesJSON
+func ListLanguagesJSON(videoID *C.char) *C.char {
+	return respond(client.ListLanguagesJSON(C.GoString(videoID)))
+}
+
+//export ExtractVideoID
+func ExtractVideoID(input *C.char) *C.char {
+	// The C API keeps returning an empty string for input without a video ID
+	videoID, _ := mobile.ExtractVideoID(C.GoString(input))
+	return C.CString(videoID)
+}
+
+//export FreeString
+func FreeString(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func respond(result string, err error) *C.char {
+	var resp response
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Result = json.RawMessage(result)
+	}
+
+	b, _ := json.Marshal(resp)
+	return C.CString(string(b))
+}
+
+func main() {}
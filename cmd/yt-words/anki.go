@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runAnki writes Anki flashcards pairing each sentence of a video with its translation
+func runAnki(args []string) {
+	fs := flag.NewFlagSet("anki", flag.ExitOnError)
+	output := fs.String("output", "", "write to this file instead of stdout")
+	selFlags := addSelectionFlags(fs)
+	trFlags := addTranslateFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 || *trFlags.with == "" {
+		cliLog.usagef("Usage: %s anki <YouTube URL or Video ID> --translate-with service --translate-to code [--output cards.tsv] [--lang code | --langs a,b]", getBinaryName())
+	}
+	trFlags.setup()
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	r := fetchOne(newClient(netFlags.options()...), netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+	original := transcript.Clean(r.entries)
+	translated, err := transcript.TranslateEntries(trFlags.translator, original, r.track.LanguageCode, *trFlags.to)
+	if err != nil {
+		cliLog.exitf(exitFailure, "Error translating transcript: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := transcript.WriteAnkiTSV(&buf, videoID, original, translated); err != nil {
+		cliLog.fatalf("Error writing flashcards: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := writeFile(*output, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing flashcards: %v", err)
+	}
+	cliLog.infof("Wrote %s", *output)
+}
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/cache"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// batchResult is the outcome of fetching one video of a batch
+type batchResult struct {
+	videoID string
+	track   transcript.Transcript
+	entries []transcript.TranscriptEntry
+	err     error
+	// elapsed is how long fetching took
+	elapsed time.Duration
+
+	// metadata is read from the watch page by fetchOne, or fetched on demand by the output
+	// writer for cached transcripts
+	metadata *transcript.VideoMetadata
+	// chapters are those of the watch page; nil for cached transcripts and with a backend
+	chapters []transcript.Chapter
+}
+
+// runBatch fetches the transcripts of many videos read from a file or stdin
+func runBatch(args []string) {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	input := fs.String("input", "", "file with one URL or video ID per line (- for stdin, the default without arguments)")
+	scan := fs.Bool("scan", false, "fetch every video linked anywhere in the input, such as a note or an HTML page")
+	b := addBatchRunFlags(fs)
+	positional := b.parse(fs, args)
+
+	var videoIDs []string
+	if *scan {
+		videoIDs = scanVideoIDs(*input, positional)
+	} else {
+		videoIDs = readVideoIDs(*input, positional)
+	}
+	if len(videoIDs) == 0 {
+		cliLog.usagef("Usage: %s batch [--input ids.txt|- [--scan]] [--concurrency 3] [--rate n] [--fail-fast | --max-failures n] [--lang code | --langs a,b] [--format f | --template t] [--output template] [--out-dir dir [--resume]]", getBinaryName())
+	}
+	b.run(videoIDs)
+}
+
+// batchRun holds the flags of commands that fetch and write the transcripts of many videos
+type batchRun struct {
+	bFlags     *batchFlags
+	selFlags   *selectionFlags
+	outFlags   *outputFlags
+	trFlags    *translateFlags
+	outDir     *string
+	resume     *bool
+	manifest   *string
+	retryQueue *string
+	summary    *string
+	aiBatch    *openAIBatchFlags
+	netFlags   *networkFlags
+	logFlags   *logFlags
+
+	client   *transcript.Client
+	settings map[string]string
+}
+
+func addBatchRunFlags(fs *flag.FlagSet) *batchRun {
+	return &batchRun{
+		bFlags:     addBatchFlags(fs),
+		selFlags:   addSelectionFlags(fs),
+		outFlags:   addOutputFlags(fs),
+		trFlags:    addTranslateFlags(fs),
+		outDir:     fs.String("out-dir", "", "write one file per video into this directory, plus a manifest.json of the run"),
+		resume:     fs.Bool("resume", false, "skip videos the manifest.json in --out-dir lists as done, continuing an interrupted run"),
+		manifest:   fs.String("manifest", "", "write a JSON manifest of the run (inputs, settings, per-video status, files and durations) to this file"),
+		retryQueue: fs.String("retry-queue", "", "record failed videos, with attempt counts and failure classes, in this JSON file for "+getBinaryName()+" retry"),
+		summary:    fs.String("summary-json", "", "also write the end-of-run summary (outcomes, words, bytes, elapsed time, average latency) as JSON to this file"),
+		aiBatch:    addOpenAIBatchFlags(fs),
+		netFlags:   addNetworkFlags(fs),
+		logFlags:   addLogFlags(fs),
+	}
+}
+
+// parse parses args and applies the logging and translation flags, returning the positional arguments
+func (b *batchRun) parse(fs *flag.FlagSet, args []string) []string {
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	b.logFlags.apply()
+	b.trFlags.setup()
+	b.settings = flagSettings(fs)
+	return positional
+}
+
+// newClient returns the client shared by the whole run, creating it on first use
+func (b *batchRun) newClient() *transcript.Client {
+	if b.client == nil {
+		b.client = newClient(append(b.netFlags.options(), b.bFlags.options()...)...)
+	}
+	return b.client
+}
+
+// run fetches and writes the transcripts of videoIDs, exiting non-zero if any failed.
+// SIGINT and SIGTERM stop the run between videos, so no output file is left half-written;
+// a second signal exits immediately.
+func (b *batchRun) run(videoIDs []string) {
+	client := b.newClient()
+	out := b.outFlags.writer(client)
+	var m *manifest
+	if *b.outDir != "" {
+		if err := os.MkdirAll(*b.outDir, 0o755); err != nil {
+			cliLog.fatalf("Error creating output directory: %v", err)
+		}
+		if out.pathTemplate == "" {
+			out.pathTemplate = "{id}.{ext}"
+		}
+		out.pathTemplate = filepath.Join(*b.outDir, out.pathTemplate)
+		m = newManifest(videoIDs, b.settings)
+		if *b.resume {
+			videoIDs = m.resume(filepath.Join(*b.outDir, manifestName), videoIDs)
+		}
+	} else if *b.resume {
+		cliLog.usagef("--resume requires --out-dir")
+	} else if *b.manifest != "" {
+		m = newManifest(videoIDs, b.settings)
+	}
+	if out.pathTemplate != "" && len(videoIDs) > 1 && !out.perVideo() {
+		cliLog.usagef("--output must contain {id} or {title} when fetching several videos")
+	}
+
+	var queue *retryQueue
+	if *b.retryQueue != "" {
+		var err error
+		if queue, err = loadRetryQueue(*b.retryQueue); err != nil {
+			cliLog.fatalf("Error reading retry queue: %v", err)
+		}
+	}
+
+	batchFile := b.aiBatch.open()
+
+	bar := newProgress(len(videoIDs), *b.logFlags.quiet)
+	out.logf = bar.wrap(cliLog.infof)
+
+	var failures []error
+	summary := newBatchSummary()
+	sel := b.selFlags.selection()
+	tc := b.netFlags.cache()
+	fetch := func(videoID string) batchResult {
+		start := time.Now()
+		r := b.trFlags.apply(fetchOne(client, tc, videoID, sel))
+		r.elapsed = time.Since(start)
+		return r
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// Restore the default handlers so a second ^C is not swallowed while fetches drain
+		stop()
+	}()
+
+	handled := fetchBatch(ctx, videoIDs, *b.bFlags.concurrency, fetch, func(r batchResult) bool {
+		bar.clear()
+		if r.err != nil {
+			failures = append(failures, r.err)
+			bar.suspend(func() { cliLog.failure(r.videoID, r.err, "Error fetching transcript for %s: %v", r.videoID, r.err) })
+			m.add(r, "", r.err)
+			queue.record(r.videoID, r.err)
+			summary.add(r, r.err)
+			bar.advance(r.videoID, r.err)
+			return b.bFlags.tolerates(len(failures))
+		}
+		var path string
+		var err error
+		if batchFile != nil {
+			path, err = batchFile.write(r)
+		} else {
+			path, err = out.write(r)
+		}
+		if err != nil {
+			failures = append(failures, err)
+			bar.suspend(func() { cliLog.failure(r.videoID, err, "Error writing transcript for %s: %v", r.videoID, err) })
+		}
+		if err == nil {
+			cliLog.verbosef("Fetched %s transcript for %s (%d entries)", r.track.LanguageCode, r.videoID, len(r.entries))
+			queue.remove(r.videoID)
+		} else {
+			queue.record(r.videoID, err)
+		}
+		m.add(r, path, err)
+		summary.add(r, err)
+		bar.advance(r.videoID, err)
+		return b.bFlags.tolerates(len(failures))
+	})
+	bar.finish()
+	batchFile.close()
+	interrupted := ctx.Err() != nil && handled < len(videoIDs)
+
+	if m != nil {
+		if interrupted {
+			m.Pending = videoIDs[handled:]
+		}
+		var paths []string
+		if *b.outDir != "" {
+			paths = append(paths, filepath.Join(*b.outDir, manifestName))
+		}
+		if *b.manifest != "" {
+			paths = append(paths, *b.manifest)
+		}
+		for _, path := range paths {
+			if err := m.save(path); err != nil {
+				cliLog.fatalf("Error writing manifest: %v", err)
+			}
+			cliLog.infof("Wrote %s: %d succeeded, %d failed", path, m.Succeeded, m.Failed)
+		}
+	}
+
+	summary.finish()
+	cliLog.infof("%s", summary)
+	if *b.summary != "" {
+		if err := summary.save(*b.summary); err != nil {
+			cliLog.fatalf("Error writing summary: %v", err)
+		}
+	}
+
+	if queue != nil {
+		if err := queue.save(*b.retryQueue); err != nil {
+			cliLog.fatalf("Error writing retry queue: %v", err)
+		}
+		if len(failures) > 0 {
+			cliLog.infof("Recorded %d failed videos in %s; fetch them again with: %s retry --retry-queue %s", len(failures), *b.retryQueue, getBinaryName(), *b.retryQueue)
+		}
+	}
+
+	if interrupted {
+		b.interrupted(videoIDs[handled:], len(videoIDs))
+	}
+	if handled < len(videoIDs) {
+		cliLog.exitf(batchExitCode(failures), "Aborted after %d failed videos; %d of %d videos were not fetched", len(failures), len(videoIDs)-handled, len(videoIDs))
+	}
+	if len(failures) > 0 {
+		cliLog.exitf(batchExitCode(failures), "%d of %d videos failed", len(failures), len(videoIDs))
+	}
+}
+
+// interrupted reports an interrupted run with a hint on how to resume it, then exits
+func (b *batchRun) interrupted(pending []string, total int) {
+	if *b.outDir != "" {
+		cliLog.exitf(exitInterrupted, "Interrupted: %d of %d videos were not fetched. Re-run the same command with --resume to continue.", len(pending), total)
+	}
+
+	f, err := os.CreateTemp("", "yt-words-pending-*.txt")
+	if err == nil {
+		_, err = f.WriteString(strings.Join(pending, "\n") + "\n")
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		cliLog.exitf(exitInterrupted, "Interrupted: %d of %d videos were not fetched", len(pending), total)
+	}
+	cliLog.exitf(exitInterrupted, "Interrupted: %d of %d videos were not fetched. Their IDs are in %s; fetch them with: %s batch --input %s",
+		len(pending), total, f.Name(), getBinaryName(), f.Name())
+}
+
+// readVideoIDs collects video IDs from positional arguments and the --input file.
+// Without either, IDs are read from stdin.
+func readVideoIDs(input string, positional []string) []string {
+	if input == "" && len(positional) == 0 {
+		input = "-"
+	}
+	lines := positional
+	if input != "" {
+		fromFile, err := readInputLines(input)
+		if err != nil {
+			cliLog.fatalf("Error reading %s: %v", input, err)
+		}
+		lines = append(lines, fromFile...)
+	}
+
+	var videoIDs []string
+	for _, line := range lines {
+		videoID, err := transcript.ExtractVideoID(line)
+		if err != nil {
+			cliLog.usagef("%v", err)
+		}
+		videoIDs = append(videoIDs, videoID)
+	}
+	return videoIDs
+}
+
+// scanVideoIDs collects the videos linked in the text of the positional arguments and the
+// --input file, which defaults to stdin like in readVideoIDs
+func scanVideoIDs(input string, positional []string) []string {
+	if input == "" && len(positional) == 0 {
+		input = "-"
+	}
+	text := strings.Join(positional, "\n")
+	if input != "" {
+		var b []byte
+		var err error
+		if input == "-" {
+			b, err = io.ReadAll(os.Stdin)
+		} else {
+			b, err = os.ReadFile(input)
+		}
+		if err != nil {
+			cliLog.fatalf("Error reading %s: %v", input, err)
+		}
+		text += "\n" + string(b)
+	}
+
+	videoIDs := transcript.ExtractAllVideoIDs(text)
+	cliLog.verbosef("Found %d videos in the input", len(videoIDs))
+	return videoIDs
+}
+
+// fetchBatch runs fetch on videoIDs with up to concurrency calls in flight and calls fn
+// with each result in input order. No further fetches are started once ctx is done or fn
+// returns false; fetchBatch then waits for those in flight, discarding their results, and
+// returns the number of results passed to fn.
+func fetchBatch(ctx context.Context, videoIDs []string, concurrency int, fetch func(videoID string) batchResult, fn func(batchResult) bool) int {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]chan batchResult, len(videoIDs))
+	for i := range results {
+		results[i] = make(chan batchResult, 1)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				results[i] <- fetch(videoIDs[i])
+			}
+		}()
+	}
+	go func() {
+		defer close(work)
+		for i := range videoIDs {
+			select {
+			case work <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	handled := 0
+loop:
+	for _, c := range results {
+		select {
+		case r := <-c:
+			handled++
+			if !fn(r) {
+				break loop
+			}
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	cancel()
+	wg.Wait()
+	return handled
+}
+
+// fetchTrackFromPage selects the track of r's video from its watch page, keeping the metadata
+// and chapters the page holds for the output writer
+func fetchTrackFromPage(client *transcript.Client, r *batchResult, sel transcript.LanguageSelection) (transcript.Transcript, error) {
+	page, err := client.GetWatchPage(r.videoID)
+	if err != nil {
+		return transcript.Transcript{}, err
+	}
+	if md, err := page.Metadata(); err == nil {
+		r.metadata = &md
+		r.chapters = transcript.ParseChapters(md.Description)
+	}
+	return page.FindTranscript(sel)
+}
+
+// fetchOne fetches a video's transcript, reading through tc when it is not nil
+func fetchOne(client *transcript.Client, tc *cache.Cache, videoID string, sel transcript.LanguageSelection) batchResult {
+	key := selectionKey(sel)
+	if e, ok := tc.Get(videoID, key); ok {
+		cliLog.verbosef("Using cached transcript of %s from %s", videoID, e.FetchedAt.Local().Format("2006-01-02 15:04"))
+		return batchResult{videoID: videoID, track: e.Track, entries: e.Entries}
+	}
+
+	r := batchResult{videoID: videoID}
+	if client.HasBackend() {
+		// A backend exists to avoid scraping, so metadata is left to be fetched on demand
+		r.track, r.err = client.FindTranscriptMatching(videoID, sel)
+	} else {
+		r.track, r.err = fetchTrackFromPage(client, &r, sel)
+	}
+	if r.err == nil {
+		r.entries, r.err = client.FetchTranscript(r.track)
+	}
+	if r.err == nil {
+		if err := tc.Put(cache.Entry{VideoID: videoID, Key: key, Track: r.track, Entries: r.entries}); err != nil {
+			cliLog.verbosef("Error caching transcript of %s: %v", videoID, err)
+		}
+	}
+	return r
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestFetchBatch_Order(t *testing.T) {
+	videoIDs := []string{"a", "b", "c", "d", "e"}
+	// Earlier videos take longer, so they finish last
+	fetch := func(videoID string) batchResult {
+		time.Sleep(time.Duration('f'-videoID[0]) * 5 * time.Millisecond)
+		return batchResult{videoID: videoID}
+	}
+
+	var got []string
+	n := fetchBatch(context.Background(), videoIDs, 3, fetch, func(r batchResult) bool {
+		got = append(got, r.videoID)
+		return true
+	})
+	if n != len(videoIDs) || strings.Join(got, ",") != "a,b,c,d,e" {
+		t.Errorf("fetchBatch() handled %d results %v; want 5 in input order", n, got)
+	}
+}
+
+func TestFetchBatch_Stop(t *testing.T) {
+	tests := []struct {
+		name   string
+		cancel bool
+	}{
+		{name: "fn returns false"},
+		{name: "context cancelled", cancel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			videoIDs := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+			var started atomic.Int32
+			fetch := func(videoID string) batchResult {
+				started.Add(1)
+				if videoID != "a" {
+					time.Sleep(20 * time.Millisecond)
+				}
+				return batchResult{videoID: videoID}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			n := fetchBatch(ctx, videoIDs, 2, fetch, func(r batchResult) bool {
+				if tt.cancel {
+					cancel()
+					return true
+				}
+				return false
+			})
+			if n != 1 {
+				t.Errorf("fetchBatch() handled %d results; want 1", n)
+			}
+			// Besides the first video, only the fetches already in flight may have started
+			if s := started.Load(); s > 3 {
+				t.Errorf("started %d fetches; want at most 3", s)
+			}
+		})
+	}
+}
+
+func TestFetchBatch_ConcurrencyBelowOne(t *testing.T) {
+	n := fetchBatch(context.Background(), []string{"a", "b"}, 0, func(videoID string) batchResult {
+		return batchResult{videoID: videoID}
+	}, func(batchResult) bool { return true })
+	if n != 2 {
+		t.Errorf("fetchBatch() with concurrency 0 handled %d results; want 2", n)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+const testWatchPage = `{"videoDetails":{"videoId":"%s","title":"Test video","author":"Tester","lengthSeconds":"3"},"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?v=%s&lang=en","name":{"simpleText":"English"},"languageCode":"en"}]}}}`
+
+// interruptVideos are fetched by the interrupted run of TestBatchRun_Interrupt
+var interruptVideos = []string{"aaaaaaaaaaa", "bbbbbbbbbbb", "ccccccccccc", "ddddddddddd", "eeeeeeeeeee"}
+
+// runInterruptedBatch fetches interruptVideos into dir, sending the process SIGINT while
+// the second video is being fetched; batchRun.run then exits with exitInterrupted
+func runInterruptedBatch(dir string) {
+	cliLog.out = os.Stderr
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	b := addBatchRunFlags(fs)
+	b.parse(fs, []string{"--out-dir", dir, "--concurrency", "1", "--no-cache", "--quiet"})
+	b.client = transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		videoID := r.URL.Query().Get("v")
+		body := fmt.Sprintf(testWatchPage, videoID, videoID)
+		if r.URL.Path == "/api/timedtext" {
+			body = `<transcript><text start="1" dur="2">hi there</text></transcript>`
+		} else if videoID == interruptVideos[1] {
+			syscall.Kill(os.Getpid(), syscall.SIGINT)
+			// Let the signal reach the batch before this fetch completes
+			time.Sleep(200 * time.Millisecond)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+	})))
+	b.run(interruptVideos)
+}
+
+func TestBatchRun_Interrupt(t *testing.T) {
+	if dir := os.Getenv("YTWORDS_TEST_INTERRUPT_DIR"); dir != "" {
+		runInterruptedBatch(dir)
+		return
+	}
+
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestBatchRun_Interrupt$")
+	cmd.Env = append(os.Environ(), "YTWORDS_TEST_INTERRUPT_DIR="+dir)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != exitInterrupted {
+		t.Fatalf("interrupted run exited with %v; want code %d. Stderr:\n%s", err, exitInterrupted, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "--resume") {
+		t.Errorf("stderr %q has no hint to resume", stderr.String())
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		t.Fatalf("interrupted run left no manifest: %v", err)
+	}
+	var m manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if len(m.Pending) == 0 || len(m.Videos)+len(m.Pending) != len(interruptVideos) {
+		t.Errorf("manifest has %d videos and %d pending; want every video in one of them, some pending", len(m.Videos), len(m.Pending))
+	}
+
+	// Only complete transcripts of the videos the manifest lists as done are left behind
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	done := make(map[string]bool)
+	for _, v := range m.Videos {
+		if v.Status == "ok" {
+			done[filepath.Base(v.File)] = true
+		}
+	}
+	for _, f := range files {
+		if f.Name() == manifestName {
+			continue
+		}
+		if !done[f.Name()] {
+			t.Errorf("interrupted run left %s, which the manifest does not list as done", f.Name())
+			continue
+		}
+		content, _ := os.ReadFile(filepath.Join(dir, f.Name()))
+		if string(content) != "hi there\n" && string(content) != "hi there" {
+			t.Errorf("%s holds %q; want the whole transcript", f.Name(), content)
+		}
+	}
+	if len(files) != len(done)+1 {
+		t.Errorf("output directory holds %d files; want %d transcripts and the manifest", len(files), len(done))
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// batchSummary sums up a batch run for capacity planning
+type batchSummary struct {
+	Videos int `json:"videos"`
+	// Outcomes counts the videos by --error-format json code, "ok" for those written
+	Outcomes map[string]int `json:"outcomes"`
+	Words    int            `json:"words"`
+	// Bytes is the size of the caption text fetched, in UTF-8
+	Bytes            int64   `json:"bytes"`
+	ElapsedMs        int64   `json:"elapsedMs"`
+	AverageLatencyMs float64 `json:"averageLatencyMs"`
+
+	start   time.Time
+	latency time.Duration
+}
+
+func newBatchSummary() *batchSummary {
+	return &batchSummary{Outcomes: make(map[string]int), start: time.Now()}
+}
+
+// add counts a handled video; err is the fetch or write error, if any
+func (s *batchSummary) add(r batchResult, err error) {
+	s.Videos++
+	s.latency += r.elapsed
+	outcome := "ok"
+	if err != nil {
+		outcome = errorCodes[exitCode(err)]
+	}
+	s.Outcomes[outcome]++
+	for _, e := range r.entries {
+		s.Words += len(strings.Fields(e.Text))
+		s.Bytes += int64(len(e.Text))
+	}
+}
+
+// finish sets the elapsed time and average latency
+func (s *batchSummary) finish() {
+	s.ElapsedMs = time.Since(s.start).Milliseconds()
+	if s.Videos > 0 {
+		s.AverageLatencyMs = float64(s.latency.Milliseconds()) / float64(s.Videos)
+	}
+}
+
+// String renders the stats as one log line, outcomes ordered by count
+func (s *batchSummary) String() string {
+	outcomes := make([]string, 0, len(s.Outcomes))
+	for name := range s.Outcomes {
+		outcomes = append(outcomes, name)
+	}
+	sort.Slice(outcomes, func(i, j int) bool {
+		a, b := outcomes[i], outcomes[j]
+		if s.Outcomes[a] != s.Outcomes[b] {
+			return s.Outcomes[a] > s.Outcomes[b]
+		}
+		return a < b
+	})
+	for i, name := range outcomes {
+		outcomes[i] = fmt.Sprintf("%d %s", s.Outcomes[name], name)
+	}
+	elapsed := time.Duration(s.ElapsedMs) * time.Millisecond
+	latency := time.Duration(s.AverageLatencyMs * float64(time.Millisecond))
+	return fmt.Sprintf("Summary: %d videos in %s (%s); %d words, %s of captions; average latency %s",
+		s.Videos, elapsed.Round(time.Millisecond), strings.Join(outcomes, ", "), s.Words, byteSize(s.Bytes), latency.Round(time.Millisecond))
+}
+
+// save writes the stats as indented JSON
+func (s *batchSummary) save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, append(b, '\n'))
+}
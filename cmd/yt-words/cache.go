@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/cache"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// cacheDir returns the configured cache directory, or the per-user default
+func cacheDir() (string, error) {
+	if cfg.CacheDir != "" {
+		return cfg.CacheDir, nil
+	}
+	return cache.DefaultDir()
+}
+
+// openCache opens the transcript cache; fetching commands carry on uncached when it fails
+func openCache() *cache.Cache {
+	dir, err := cacheDir()
+	if err == nil {
+		var c *cache.Cache
+		if c, err = cache.Open(dir); err == nil {
+			return c
+		}
+	}
+	cliLog.verbosef("Transcript cache disabled: %v", err)
+	return nil
+}
+
+// selectionKey identifies a language selection in cache file names
+func selectionKey(sel transcript.LanguageSelection) string {
+	return fmt.Sprintf("%s|manual=%t|generated=%t", strings.Join(sel.Languages, ","), sel.PreferManual, sel.GeneratedOnly)
+}
+
+// runCache dispatches the cache subcommands
+func runCache(args []string) {
+	if len(args) < 1 {
+		cacheUsage()
+	}
+
+	fs := flag.NewFlagSet("cache "+args[0], flag.ExitOnError)
+	olderThan := fs.String("older-than", "30d", "prune transcripts fetched longer ago than this, e.g. 72h or 30d")
+	asJSON := fs.Bool("json", false, "print as JSON")
+	fs.Parse(args[1:])
+
+	dir, err := cacheDir()
+	if err != nil {
+		cliLog.fatalf("Error locating cache directory: %v", err)
+	}
+	c, err := cache.Open(dir)
+	if err != nil {
+		cliLog.fatalf("Error opening cache: %v", err)
+	}
+
+	switch args[0] {
+	case "ls":
+		infos, err := c.List()
+		if err != nil {
+			cliLog.fatalf("Error reading cache: %v", err)
+		}
+		if *asJSON {
+			printJSON(infos)
+			return
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VIDEO\tLANG\tENTRIES\tSIZE\tFETCHED")
+		for _, info := range infos {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", info.VideoID, info.Language, info.Entries, byteSize(info.Size),
+				info.FetchedAt.Local().Format("2006-01-02 15:04"))
+		}
+		tw.Flush()
+
+	case "info":
+		infos, err := c.List()
+		if err != nil {
+			cliLog.fatalf("Error reading cache: %v", err)
+		}
+		var size int64
+		for _, info := range infos {
+			size += info.Size
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "Directory\t%s\n", c.Dir())
+		fmt.Fprintf(tw, "Transcripts\t%d\n", len(infos))
+		fmt.Fprintf(tw, "Size\t%s\n", byteSize(size))
+		if len(infos) > 0 {
+			fmt.Fprintf(tw, "Oldest\t%s\n", infos[0].FetchedAt.Local().Format("2006-01-02 15:04"))
+			fmt.Fprintf(tw, "Newest\t%s\n", infos[len(infos)-1].FetchedAt.Local().Format("2006-01-02 15:04"))
+		}
+		tw.Flush()
+
+	case "clear":
+		n, err := c.Clear()
+		if err != nil {
+			cliLog.fatalf("Error clearing cache: %v", err)
+		}
+		cliLog.infof("Removed %d cached transcripts", n)
+
+	case "prune":
+		age, err := parseAge(*olderThan)
+		if err != nil {
+			cliLog.usagef("Invalid --older-than: %v", err)
+		}
+		n, err := c.Prune(age)
+		if err != nil {
+			cliLog.fatalf("Error pruning cache: %v", err)
+		}
+		cliLog.infof("Removed %d cached transcripts older than %s", n, *olderThan)
+
+	default:
+		cacheUsage()
+	}
+}
+
+func cacheUsage() {
+	cliLog.usagef("Usage: %s cache ls|info|clear|prune [--older-than 30d] [--json]", getBinaryName())
+}
+
+// parseAge parses a duration, additionally accepting whole days such as "30d"
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// byteSize renders a size in bytes with a binary unit
+func byteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runChannel fetches the transcripts of a channel's most recent uploads
+func runChannel(args []string) {
+	fs := flag.NewFlagSet("channel", flag.ExitOnError)
+	limit := fs.Int("limit", 100, "fetch at most this many of the most recent uploads (0 means all)")
+	since := fs.String("since", "", "only videos published on or after this date (YYYY-MM-DD)")
+	until := fs.String("until", "", "only videos published on or before this date (YYYY-MM-DD)")
+	b := addBatchRunFlags(fs)
+	positional := b.parse(fs, args)
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s channel <@handle, channel URL or UC... ID> [--limit 100] [--since YYYY-MM-DD] [--until YYYY-MM-DD] [--out-dir dir]", getBinaryName())
+	}
+	notBefore := parseDateFlag("since", *since)
+	notAfter := parseDateFlag("until", *until)
+
+	client := b.newClient()
+	channelID, err := client.ResolveChannelID(positional[0])
+	if err != nil {
+		cliLog.exitf(exitUsage, "Error resolving channel: %v", err)
+	}
+	uploads, err := client.GetPlaylistVideos(transcript.UploadsPlaylistID(channelID))
+	if err != nil {
+		cliLog.failf("", err, "Error listing uploads of %s: %v", channelID, err)
+	}
+
+	dated := datedUploads{client: client}
+	if !notBefore.IsZero() || !notAfter.IsZero() {
+		dated.loadFeed(channelID)
+	}
+
+	var videoIDs []string
+	for _, v := range uploads {
+		if *limit > 0 && len(videoIDs) >= *limit {
+			break
+		}
+		if !notBefore.IsZero() || !notAfter.IsZero() {
+			published, ok := dated.published(v.VideoID)
+			if !ok {
+				cliLog.infof("Skipping %s: publish date unknown", v.VideoID)
+				continue
+			}
+			if published.Before(notBefore) {
+				// Uploads are listed newest first, so every later one is older still
+				break
+			}
+			if !notAfter.IsZero() && published.After(notAfter) {
+				continue
+			}
+		}
+		videoIDs = append(videoIDs, v.VideoID)
+	}
+	if len(videoIDs) == 0 {
+		cliLog.infof("No uploads of %s match", channelID)
+		return
+	}
+	cliLog.verbosef("Fetching %d uploads of %s", len(videoIDs), channelID)
+
+	if *b.outFlags.output == "" && *b.outDir == "" {
+		*b.outFlags.output = "{id}.{ext}"
+	}
+	b.run(videoIDs)
+}
+
+// parseDateFlag parses a YYYY-MM-DD flag value, returning the zero time when it is empty
+func parseDateFlag(name, value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		cliLog.usagef("Invalid --%s date %q: want YYYY-MM-DD", name, value)
+	}
+	return t
+}
+
+// datedUploads looks up publish dates, from the channel feed where possible and from each
+// video's metadata otherwise
+type datedUploads struct {
+	client *transcript.Client
+	feed   map[string]time.Time
+}
+
+func (d *datedUploads) loadFeed(channelID string) {
+	d.feed = make(map[string]time.Time)
+	videos, err := d.client.GetChannelFeed(channelID)
+	if err != nil {
+		cliLog.verbosef("Error fetching feed of %s, reading dates from each video: %v", channelID, err)
+		return
+	}
+	for _, v := range videos {
+		d.feed[v.VideoID] = v.Published
+	}
+}
+
+// published returns the UTC date a video was published on
+func (d *datedUploads) published(videoID string) (time.Time, bool) {
+	if t, ok := d.feed[videoID]; ok {
+		y, m, day := t.UTC().Date()
+		return time.Date(y, m, day, 0, 0, 0, 0, time.UTC), true
+	}
+	md, err := d.client.GetVideoMetadata(videoID)
+	if err != nil || md.PublishDate == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", md.PublishDate)
+	return t, err == nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runClean applies the cleaning pipeline to a transcript file on disk, without any network access
+func runClean(args []string) {
+	fs := flag.NewFlagSet("clean", flag.ExitOnError)
+	format := fs.String("format", "", "output format (default: the input file's format)")
+	output := fs.String("output", "", "write to this file instead of stdout")
+	noStrip := fs.Bool("no-strip", false, "keep annotations such as [Music] and (applause)")
+	noDedup := fs.Bool("no-dedup", false, "keep words repeated across overlapping auto-generated captions")
+	noReflow := fs.Bool("no-reflow", false, "keep the original entry boundaries instead of merging into sentences")
+	pipeline := fs.String("pipeline", "", "cleaning steps applied in order instead of the default strip,dedup,reflow, e.g. strip,reflow")
+	maxChars := fs.Int("max-chars", transcript.DefaultReflowChars, "maximum length of a reflowed entry")
+	logFlags := addLogFlags(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s clean <file.srt|file.vtt|file.json> [--format f] [--output path] [--no-strip] [--no-dedup] [--no-reflow] [--pipeline steps]", getBinaryName())
+	}
+
+	path := positional[0]
+	inFormat, ok := formatForExtension(filepath.Ext(path))
+	if !ok {
+		cliLog.usagef("Cannot tell the format of %s: want a .srt, .vtt or .json file", path)
+	}
+	outFormat := inFormat
+	if *format != "" {
+		f, err := transcript.ParseFormat(*format)
+		if err != nil {
+			cliLog.usagef("Invalid --format: %v", err)
+		}
+		outFormat = f
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		cliLog.fatalf("Error opening transcript: %v", err)
+	}
+	entries, err := transcript.ReadFormat(f, inFormat)
+	f.Close()
+	if err != nil {
+		cliLog.exitf(exitUsage, "Error reading %s: %v", path, err)
+	}
+
+	if *pipeline != "" {
+		entries, err = parsePipeline(*pipeline).Process(entries)
+		if err != nil {
+			cliLog.fatalf("Error cleaning transcript: %v", err)
+		}
+	} else {
+		if !*noStrip {
+			entries = transcript.StripAnnotations(entries)
+		}
+		if !*noDedup {
+			entries = transcript.DedupOverlap(entries)
+		}
+		if !*noReflow {
+			entries = transcript.ReflowSentences(entries, *maxChars)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := transcript.WriteFormat(&buf, outFormat, entries); err != nil {
+		cliLog.fatalf("Error writing transcript: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := writeFile(*output, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing transcript: %v", err)
+	}
+	cliLog.infof("Wrote %s", *output)
+}
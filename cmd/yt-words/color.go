@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"regexp"
+)
+
+// palette applies ANSI styles when enabled and returns text unchanged otherwise
+type palette struct {
+	enabled bool
+}
+
+// newPalette enables colors when stdout is a terminal, unless --no-color or NO_COLOR (https://no-color.org) is set
+func newPalette(noColor bool) palette {
+	_, envNoColor := os.LookupEnv("NO_COLOR")
+	return palette{enabled: !noColor && !envNoColor && isTerminal(os.Stdout)}
+}
+
+func (p palette) style(code, s string) string {
+	if !p.enabled {
+		return s
+	}
+	return "\033[" + code + "m" + s + "\033[0m"
+}
+
+func (p palette) bold(s string) string    { return p.style("1", s) }
+func (p palette) dim(s string) string     { return p.style("2", s) }
+func (p palette) heading(s string) string { return p.style("1;36", s) }
+
+// highlight styles every match of re in s
+func (p palette) highlight(s string, re *regexp.Regexp) string {
+	if !p.enabled || re == nil {
+		return s
+	}
+	return re.ReplaceAllStringFunc(s, func(m string) string { return p.style("1;33", m) })
+}
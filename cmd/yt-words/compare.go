@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runCompare reports how far a video's auto-generated captions differ from the uploader's
+func runCompare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	lang := fs.String("lang", "en", "language whose manual and auto-generated tracks are compared")
+	asJSON := fs.Bool("json", false, "print the comparison as JSON instead of a table")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s compare <YouTube URL or Video ID> [--lang code] [--json]", getBinaryName())
+	}
+
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	c, err := newClient(netFlags.options()...).CompareCaptions(videoID, *lang)
+	if err != nil {
+		cliLog.failf(videoID, err, "Error comparing captions: %v", err)
+	}
+
+	if *asJSON {
+		printJSON(struct {
+			VideoID  string `json:"videoId"`
+			Language string `json:"language"`
+			transcript.Comparison
+		}{videoID, *lang, c})
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Video\t%s (%s)\n", videoID, *lang)
+	fmt.Fprintf(tw, "Manual words\t%d\n", c.ReferenceWords)
+	fmt.Fprintf(tw, "Word error rate\t%.1f%%\n", c.WordErrorRate*100)
+	fmt.Fprintf(tw, "Substitutions\t%d\n", c.Substitutions)
+	fmt.Fprintf(tw, "Deletions\t%d\n", c.Deletions)
+	fmt.Fprintf(tw, "Insertions\t%d\n", c.Insertions)
+	tw.Flush()
+
+	if len(c.MostChanged) > 0 {
+		fmt.Println("\nMost changed:")
+	}
+	for _, d := range c.MostChanged {
+		fmt.Printf("%s  %d errors\n  manual:    %s\n  generated: %s\n", transcript.FormatClock(d.Start), d.Errors, d.Manual, d.Generated)
+	}
+}
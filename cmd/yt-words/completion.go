@@ -0,0 +1,179 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// completionCommand describes a subcommand for shell completion scripts
+type completionCommand struct {
+	Name        string
+	Flags       []string
+	Subcommands []string
+}
+
+var (
+	selectionFlagNames = []string{"lang", "langs", "prefer-manual", "generated-only"}
+	networkFlagNames   = []string{"proxy", "proxy-file", "hl", "header", "budget", "backend", "cookies", "timeout", "retries", "retry-delay", "max-retry-after", "no-cache"}
+	logFlagNames       = []string{"quiet", "v", "vv", "log-format", "error-format"}
+	outputFlagNames    = []string{"format", "output", "template", "fields", "no-timestamps", "no-color", "pipeline", "sponsorblock", "translate-with", "translate-to"}
+)
+
+// completionCommands lists every subcommand and its flags; keep it in sync with main and the run functions
+var completionCommands = []completionCommand{
+	{Name: "get", Flags: joinFlags([]string{"wait", "wait-interval"}, selectionFlagNames, outputFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "batch", Flags: joinFlags([]string{"input", "scan", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown", "out-dir", "resume", "manifest", "retry-queue", "summary-json", "openai-batch", "model", "system-prompt", "chunk-chars"}, selectionFlagNames, outputFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "playlist", Flags: joinFlags([]string{"limit", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown", "out-dir", "resume", "manifest", "retry-queue", "summary-json", "openai-batch", "model", "system-prompt", "chunk-chars"}, selectionFlagNames, outputFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "channel", Flags: joinFlags([]string{"limit", "since", "until", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown", "out-dir", "resume", "manifest", "retry-queue", "summary-json", "openai-batch", "model", "system-prompt", "chunk-chars"}, selectionFlagNames, outputFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "langs", Flags: joinFlags([]string{"json"}, networkFlagNames, logFlagNames)},
+	{Name: "stats", Flags: joinFlags([]string{"json", "pace", "window", "gaps"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "link", Flags: joinFlags([]string{"json"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "wordfreq", Flags: joinFlags([]string{"format", "output", "min-count", "limit"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "highlights", Flags: joinFlags([]string{"top", "lang", "json"}, networkFlagNames, logFlagNames)},
+	{Name: "anki", Flags: joinFlags([]string{"output", "translate-with", "translate-to"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "mux", Flags: joinFlags([]string{"output", "burn", "run"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "markers", Flags: joinFlags([]string{"match", "regexp", "format", "fps", "output"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "epub", Flags: joinFlags([]string{"output", "title", "limit", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "merge", Flags: joinFlags([]string{"input", "output", "global-time", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "index", Flags: joinFlags([]string{"store", "db"}, logFlagNames)},
+	{Name: "query", Flags: joinFlags([]string{"store", "db", "channel", "limit", "json"}, logFlagNames)},
+	{Name: "duplicates", Flags: joinFlags([]string{"store", "threshold", "json"}, logFlagNames)},
+	{Name: "notes", Flags: joinFlags([]string{"output", "toc"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "compare", Flags: joinFlags([]string{"lang", "json"}, networkFlagNames, logFlagNames)},
+	{Name: "grep", Flags: joinFlags([]string{"input", "regexp", "case-sensitive", "store", "json", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown", "no-color"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "export", Flags: joinFlags([]string{"formats", "out-dir", "name", "chunks", "documents", "chunk-tokens"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "clean", Flags: joinFlags([]string{"format", "output", "no-strip", "no-dedup", "no-reflow", "pipeline", "max-chars"}, logFlagNames)},
+	{Name: "summarize", Flags: joinFlags([]string{"api-base", "model", "chunk-chars"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "jobs", Flags: joinFlags([]string{"db", "lang", "input", "playlist", "channel", "limit", "workers"}, networkFlagNames, logFlagNames), Subcommands: []string{"submit", "status", "list", "results", "work"}},
+	{Name: "serve", Flags: joinFlags([]string{"addr", "graphql", "cache-ttl", "cache-size", "rate", "burst", "api-keys-file", "jobs-db", "workers", "shutdown-timeout", "drain-delay"}, networkFlagNames, logFlagNames)},
+	{Name: "serve-grpc", Flags: joinFlags([]string{"addr"}, networkFlagNames, logFlagNames)},
+	{Name: "live", Flags: joinFlags([]string{"lang", "json"}, networkFlagNames, logFlagNames)},
+	{Name: "watch", Flags: joinFlags([]string{"channel", "store", "interval", "webhook", "lang", "since", "once"}, networkFlagNames, logFlagNames)},
+	{Name: "sync", Flags: joinFlags([]string{"channel", "store", "limit", "recheck", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown"}, selectionFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "retry", Flags: joinFlags([]string{"all", "max-attempts", "list", "concurrency", "rate", "fail-fast", "max-failures", "breaker", "breaker-cooldown", "out-dir", "resume", "manifest", "retry-queue", "summary-json", "openai-batch", "model", "system-prompt", "chunk-chars"}, selectionFlagNames, outputFlagNames, networkFlagNames, logFlagNames)},
+	{Name: "cache", Flags: []string{"older-than", "json"}, Subcommands: []string{"ls", "info", "clear", "prune"}},
+	{Name: "completion", Subcommands: []string{"bash", "zsh", "fish"}},
+	{Name: "version"},
+	{Name: "help"},
+}
+
+func joinFlags(groups ...[]string) []string {
+	var flags []string
+	for _, g := range groups {
+		flags = append(flags, g...)
+	}
+	return flags
+}
+
+// completionData is passed to the completion script templates
+type completionData struct {
+	Binary   string
+	Commands []completionCommand
+	Formats  string
+}
+
+// runCompletion prints a completion script for the requested shell
+func runCompletion(args []string) {
+	if len(args) != 1 {
+		cliLog.usagef("Usage: %s completion bash|zsh|fish", getBinaryName())
+	}
+
+	var tmpl string
+	switch args[0] {
+	case "bash":
+		tmpl = bashCompletion
+	case "zsh":
+		tmpl = "autoload -U +X bashcompinit && bashcompinit\n" + bashCompletion
+	case "fish":
+		tmpl = fishCompletion
+	default:
+		cliLog.usagef("Unsupported shell %q: want bash, zsh or fish", args[0])
+	}
+
+	formats := make([]string, 0, len(transcript.Formats))
+	for _, f := range transcript.Formats {
+		formats = append(formats, string(f))
+	}
+
+	t := template.Must(template.New(args[0]).Funcs(template.FuncMap{
+		"join":      strings.Join,
+		"dashed":    dashedFlags,
+		"funcName":  func(s string) string { return strings.ReplaceAll(s, "-", "_") },
+		"shortFlag": func(f string) bool { return len(f) <= 2 },
+	}).Parse(tmpl))
+	data := completionData{Binary: getBinaryName(), Commands: completionCommands, Formats: strings.Join(formats, " ")}
+	if err := t.Execute(os.Stdout, data); err != nil {
+		cliLog.fatalf("Error generating completion script: %v", err)
+	}
+}
+
+// dashedFlags renders flag names as --name, or -v for single-letter style flags
+func dashedFlags(flags []string) string {
+	dashed := make([]string, 0, len(flags))
+	for _, f := range flags {
+		if len(f) <= 2 {
+			dashed = append(dashed, "-"+f)
+		} else {
+			dashed = append(dashed, "--"+f)
+		}
+	}
+	return strings.Join(dashed, " ")
+}
+
+const bashCompletion = `# bash completion for {{.Binary}}
+_{{funcName .Binary}}() {
+	local cur prev
+	cur="${COMP_WORDS[COMP_CWORD]}"
+	prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+	case "$prev" in
+	--format|-format)
+		COMPREPLY=($(compgen -W "{{.Formats}}" -- "$cur"))
+		return
+		;;
+	--log-format|-log-format|--error-format|-error-format)
+		COMPREPLY=($(compgen -W "text json" -- "$cur"))
+		return
+		;;
+	--input|-input|--proxy-file|-proxy-file|--output|-output|--out-dir|-out-dir|--store|-store|--db|-db|--jobs-db|-jobs-db)
+		COMPREPLY=($(compgen -f -- "$cur"))
+		return
+		;;
+	esac
+
+	if [ "$COMP_CWORD" -eq 1 ]; then
+		COMPREPLY=($(compgen -W "{{range .Commands}}{{.Name}} {{end}}" -- "$cur"))
+		return
+	fi
+
+	local words=""
+	case "${COMP_WORDS[1]}" in
+{{- range .Commands}}
+	{{.Name}})
+		words="{{join .Subcommands " "}} {{dashed .Flags}}"
+		;;
+{{- end}}
+	esac
+	COMPREPLY=($(compgen -W "$words" -- "$cur"))
+}
+complete -o default -F _{{funcName .Binary}} {{.Binary}}
+`
+
+const fishCompletion = `# fish completion for {{.Binary}}
+set -l commands{{range .Commands}} {{.Name}}{{end}}
+complete -c {{.Binary}} -n "not __fish_seen_subcommand_from $commands" -a "$commands"
+{{- range $cmd := .Commands}}
+{{- range .Subcommands}}
+complete -c {{$.Binary}} -f -n "__fish_seen_subcommand_from {{$cmd.Name}}" -a {{.}}
+{{- end}}
+{{- range .Flags}}
+complete -c {{$.Binary}} -n "__fish_seen_subcommand_from {{$cmd.Name}}" {{if shortFlag .}}-o{{else}}-l{{end}} {{.}}
+{{- end}}
+{{- end}}
+complete -c {{.Binary}} -l format -x -a "{{.Formats}}"
+complete -c {{.Binary}} -l log-format -x -a "text json"
+complete -c {{.Binary}} -l error-format -x -a "text json"
+`
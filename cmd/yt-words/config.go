@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+	"gopkg.in/yaml.v3"
+)
+
+// config holds the defaults read from the user's config file and YTWORDS_* environment
+// variables, in increasing order of precedence; command-line flags override both
+type config struct {
+	Languages []string `yaml:"languages"`
+	Format    string   `yaml:"format"`
+	Proxy     string   `yaml:"proxy"`
+	// CacheDir is where fetched transcripts are cached (default: the user cache directory)
+	CacheDir    string `yaml:"cache_dir"`
+	Concurrency int    `yaml:"concurrency"`
+	UserAgent   string `yaml:"user_agent"`
+	// Headers are sent with every request, before any --header flags
+	Headers map[string]string `yaml:"headers"`
+}
+
+// cfg is loaded once at startup by main
+var cfg config
+
+// configPath returns $XDG_CONFIG_HOME/yt-words/config.yaml, falling back to ~/.config
+func configPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "yt-words", "config.yaml")
+}
+
+// loadConfig reads the config file at path; a missing file yields an empty config
+func loadConfig(path string) (config, error) {
+	var c config
+	if path == "" {
+		return c, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return c, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	return c, nil
+}
+
+// applyEnv overrides config values with YTWORDS_* environment variables
+func (c *config) applyEnv() error {
+	if v := os.Getenv("YTWORDS_LANGS"); v != "" {
+		c.Languages = transcript.ParseLanguageList(v)
+	}
+	if v := os.Getenv("YTWORDS_FORMAT"); v != "" {
+		c.Format = v
+	}
+	if v := os.Getenv(transcript.EnvProxy); v != "" {
+		c.Proxy = v
+	}
+	if v := os.Getenv("YTWORDS_CACHE_DIR"); v != "" {
+		c.CacheDir = v
+	}
+	if v := os.Getenv("YTWORDS_CONCURRENCY"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid YTWORDS_CONCURRENCY %q: %v", v, err)
+		}
+		c.Concurrency = n
+	}
+	if v := os.Getenv(transcript.EnvUserAgent); v != "" {
+		c.UserAgent = v
+	}
+	return nil
+}
+
+// applyDefaults presets the flags that have a config counterpart, so that
+// values given on the command line still take precedence once flags are parsed
+func (c config) applyDefaults(flags *flag.FlagSet) {
+	defaults := map[string]string{
+		"langs":  strings.Join(c.Languages, ","),
+		"format": c.Format,
+		"proxy":  c.Proxy,
+	}
+	if c.Concurrency > 0 {
+		defaults["concurrency"] = strconv.Itoa(c.Concurrency)
+	}
+
+	for name, value := range defaults {
+		f := flags.Lookup(name)
+		if f == nil || value == "" {
+			continue
+		}
+		if err := f.Value.Set(value); err != nil {
+			cliLog.usagef("Invalid %s in config file: %v", name, err)
+		}
+		f.DefValue = value
+	}
+}
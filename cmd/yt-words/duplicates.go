@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runDuplicates lists the near-identical transcripts of a store, such as reuploads and mirrors
+func runDuplicates(args []string) {
+	fs := flag.NewFlagSet("duplicates", flag.ExitOnError)
+	storeDir := fs.String("store", "transcripts", "store directory the transcripts were saved to, e.g. by watch")
+	threshold := fs.Float64("threshold", transcript.DefaultDuplicateThreshold, "estimated share of shared word sequences from which transcripts count as duplicates")
+	asJSON := fs.Bool("json", false, "print the pairs as JSON")
+	logFlags := addLogFlags(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+	if len(positional) != 0 {
+		cliLog.usagef("Usage: %s duplicates [--store dir] [--threshold 0.8] [--json]", getBinaryName())
+	}
+	if *threshold <= 0 || *threshold > 1 {
+		cliLog.usagef("--threshold must be above 0 and at most 1")
+	}
+
+	st, err := store.Open(*storeDir)
+	if err != nil {
+		cliLog.fatalf("Error opening store: %v", err)
+	}
+	ids, err := st.List()
+	if err != nil {
+		cliLog.fatalf("Error listing store: %v", err)
+	}
+	signatures := make(map[string]transcript.Signature, len(ids))
+	titles := make(map[string]string, len(ids))
+	for _, id := range ids {
+		rec, err := st.Get(id)
+		if err != nil {
+			cliLog.fatalf("Error reading store: %v", err)
+		}
+		sig := transcript.NewSignature(rec.Entries)
+		// Transcripts without words say nothing about being copies of each other
+		if len(sig) == 0 {
+			cliLog.verbosef("Skipping %s: empty transcript", id)
+			continue
+		}
+		signatures[id] = sig
+		titles[id] = rec.Title
+	}
+
+	dups := transcript.FindDuplicates(signatures, *threshold)
+	if *asJSON {
+		printJSON(dups)
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Similarity\tVideo\tDuplicate\n")
+	for _, d := range dups {
+		fmt.Fprintf(tw, "%.0f%%\t%s %s\t%s %s\n", d.Similarity*100, d.A, titles[d.A], d.B, titles[d.B])
+	}
+	tw.Flush()
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runEPUB writes the transcripts of a playlist as one EPUB, a chapter per video
+func runEPUB(args []string) {
+	fs := flag.NewFlagSet("epub", flag.ExitOnError)
+	output := fs.String("output", "", "file to write (default: the playlist ID with .epub)")
+	title := fs.String("title", "", "title of the book (default: the playlist ID)")
+	limit := fs.Int("limit", 0, "include at most this many videos from the start of the playlist (0 means all)")
+	bFlags := addBatchFlags(fs)
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s epub <playlist URL or ID> [--output book.epub] [--title text] [--limit n] [--concurrency 3] [--lang code | --langs a,b]", getBinaryName())
+	}
+	playlistID := transcript.ExtractPlaylistID(positional[0])
+	if playlistID == "" {
+		cliLog.usagef("Invalid YouTube playlist URL or ID: %s", positional[0])
+	}
+	if *output == "" {
+		*output = playlistID + ".epub"
+	}
+	if *title == "" {
+		*title = "Playlist " + playlistID
+	}
+
+	client := newClient(append(netFlags.options(), bFlags.options()...)...)
+	videos, err := client.GetPlaylistVideos(playlistID)
+	if err != nil {
+		cliLog.failf("", err, "Error listing playlist: %v", err)
+	}
+	if *limit > 0 && len(videos) > *limit {
+		videos = videos[:*limit]
+	}
+	videoIDs := make([]string, len(videos))
+	for i, v := range videos {
+		videoIDs[i] = v.VideoID
+	}
+
+	book := transcript.Book{Title: *title, Identifier: "https://www.youtube.com/playlist?list=" + playlistID}
+	var failures []error
+	sel := selFlags.selection()
+	tc := netFlags.cache()
+	fetch := func(videoID string) batchResult {
+		r := fetchOne(client, tc, videoID, sel)
+		if r.err == nil && r.metadata == nil {
+			var md transcript.VideoMetadata
+			md, r.err = client.GetVideoMetadata(videoID)
+			r.metadata = &md
+		}
+		return r
+	}
+	fetchBatch(context.Background(), videoIDs, *bFlags.concurrency, fetch, func(r batchResult) bool {
+		if r.err != nil {
+			failures = append(failures, r.err)
+			cliLog.failure(r.videoID, r.err, "Skipping %s: %v", r.videoID, r.err)
+			return bFlags.tolerates(len(failures))
+		}
+		if len(book.Chapters) == 0 {
+			book.Author = r.metadata.Author
+			book.Language = r.track.LanguageCode
+		}
+		book.Chapters = append(book.Chapters, transcript.BookChapter{Metadata: *r.metadata, Entries: r.entries})
+		return true
+	})
+	if len(book.Chapters) == 0 {
+		cliLog.exitf(batchExitCode(failures), "None of the %d videos of %s has a transcript", len(videoIDs), playlistID)
+	}
+
+	var buf bytes.Buffer
+	if err := transcript.WriteEPUB(&buf, book); err != nil {
+		cliLog.fatalf("Error writing EPUB: %v", err)
+	}
+	if err := writeFile(*output, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing EPUB: %v", err)
+	}
+	cliLog.infof("Wrote %s with %d of %d videos", *output, len(book.Chapters), len(videoIDs))
+	if len(failures) > 0 && !bFlags.tolerates(len(failures)) {
+		cliLog.exitf(batchExitCode(failures), "Stopped after %d failed videos", len(failures))
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// Exit codes, so scripts can branch on the class of failure
+const (
+	exitOK           = 0
+	exitFailure      = 1   // any error not listed below
+	exitUsage        = 2   // invalid flags, arguments or video IDs
+	exitUnavailable  = 3   // the video does not exist or cannot be watched
+	exitNoTranscript = 4   // no caption track matches the requested languages
+	exitDisabled     = 5   // the uploader disabled captions
+	exitRateLimited  = 6   // YouTube answered with HTTP 429
+	exitMembersOnly  = 7   // the video is for channel members and no member cookies were given
+	exitNotYet       = 8   // the video is an upcoming live stream or premiere
+	exitEmpty        = 9   // the caption track holds no cues
+	exitRegion       = 10  // the video is blocked in the country requests come from
+	exitInterrupted  = 130 // a batch or --wait stopped early on SIGINT or SIGTERM, as shells report ^C
+)
+
+// exitCode classifies a library error
+func exitCode(err error) int {
+	var (
+		unavailable    transcript.ErrVideoUnavailable
+		unavailablePtr *transcript.ErrVideoUnavailable
+		noTranscript   transcript.ErrNoTranscriptFound
+		disabled       transcript.ErrTranscriptsDisabled
+		limited        transcript.ErrTooManyRequests
+		invalidID      transcript.ErrInvalidVideoID
+		membersOnly    transcript.ErrMembersOnly
+		notYet         transcript.ErrNotYetAvailable
+		empty          transcript.ErrEmptyTranscript
+		region         transcript.ErrRegionBlocked
+		circuitOpen    transcript.ErrCircuitOpen
+		overBudget     transcript.ErrBudgetExceeded
+	)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &invalidID):
+		return exitUsage
+	case errors.As(err, &unavailablePtr), errors.As(err, &unavailable):
+		return exitUnavailable
+	case errors.As(err, &noTranscript):
+		return exitNoTranscript
+	case errors.As(err, &disabled):
+		return exitDisabled
+	case errors.As(err, &limited), errors.As(err, &circuitOpen), errors.As(err, &overBudget):
+		return exitRateLimited
+	case errors.As(err, &membersOnly):
+		return exitMembersOnly
+	case errors.As(err, &notYet):
+		return exitNotYet
+	case errors.As(err, &empty):
+		return exitEmpty
+	case errors.As(err, &region):
+		return exitRegion
+	default:
+		return exitFailure
+	}
+}
+
+// batchExitCode returns the shared exit code of a batch's failures, or exitFailure when they differ
+func batchExitCode(errs []error) int {
+	code := exitOK
+	for _, err := range errs {
+		c := exitCode(err)
+		if code != exitOK && c != code {
+			return exitFailure
+		}
+		code = c
+	}
+	return code
+}
+
+// errorCodes names each exit code in --error-format json output
+var errorCodes = map[int]string{
+	exitFailure:      "error",
+	exitUsage:        "invalid_input",
+	exitUnavailable:  "video_unavailable",
+	exitNoTranscript: "no_transcript",
+	exitDisabled:     "transcripts_disabled",
+	exitRateLimited:  "rate_limited",
+	exitMembersOnly:  "members_only",
+	exitNotYet:       "not_yet_available",
+	exitEmpty:        "empty_transcript",
+	exitRegion:       "region_blocked",
+	exitInterrupted:  "interrupted",
+}
+
+// cliError is the --error-format json representation of a failure
+type cliError struct {
+	Code      string `json:"code"`
+	VideoID   string `json:"videoId,omitempty"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+	// PlayabilityStatus and PlayabilityReason are YouTube's own verdict on the video, if it gave one
+	PlayabilityStatus string `json:"playabilityStatus,omitempty"`
+	PlayabilityReason string `json:"playabilityReason,omitempty"`
+}
+
+// isRetryable reports whether trying again later may succeed
+func isRetryable(err error) bool {
+	var (
+		limited     transcript.ErrTooManyRequests
+		circuitOpen transcript.ErrCircuitOpen
+		overBudget  transcript.ErrBudgetExceeded
+		notYet      transcript.ErrNotYetAvailable
+		netErr      net.Error
+	)
+	return errors.As(err, &limited) || errors.As(err, &circuitOpen) || errors.As(err, &overBudget) || errors.As(err, &notYet) || errors.As(err, &netErr)
+}
+
+// report logs a failure, as a cliError object when --error-format json is set
+func (l *cliLogger) report(e cliError) {
+	if !l.errorJSON {
+		l.errorf("%s", e.Message)
+		return
+	}
+
+	b, _ := json.Marshal(e)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.out, "%s\n", b)
+}
+
+// failure reports a failed video without exiting
+func (l *cliLogger) failure(videoID string, err error, format string, v ...interface{}) {
+	e := cliError{Code: errorCodes[exitCode(err)], VideoID: videoID, Message: fmt.Sprintf(format, v...), Retryable: isRetryable(err)}
+	var pe transcript.PlayabilityError
+	if errors.As(err, &pe) {
+		e.PlayabilityStatus, e.PlayabilityReason = pe.PlayabilityStatus(), pe.PlayabilityReason()
+	}
+	l.report(e)
+}
+
+// exitf logs an error and exits with the given code
+func (l *cliLogger) exitf(code int, format string, v ...interface{}) {
+	l.report(cliError{Code: errorCodes[code], Message: fmt.Sprintf(format, v...), Retryable: code == exitRateLimited})
+	os.Exit(code)
+}
+
+// usagef reports invalid input and exits with exitUsage
+func (l *cliLogger) usagef(format string, v ...interface{}) {
+	l.exitf(exitUsage, format, v...)
+}
+
+// failf reports the failure of videoID and exits with the code matching the class of err
+func (l *cliLogger) failf(videoID string, err error, format string, v ...interface{}) {
+	l.failure(videoID, err, format, v...)
+	os.Exit(exitCode(err))
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestBatchExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []error
+		want int
+	}{
+		{name: "no failures", want: exitOK},
+		{name: "one class", errs: []error{transcript.ErrNoTranscriptFound{}, transcript.ErrNoTranscriptFound{VideoID: "x"}}, want: exitNoTranscript},
+		{name: "rate limits", errs: []error{transcript.ErrTooManyRequests{}, transcript.ErrCircuitOpen{}}, want: exitRateLimited},
+		{name: "mixed classes", errs: []error{transcript.ErrNoTranscriptFound{}, transcript.ErrTranscriptsDisabled{}}, want: exitFailure},
+		{name: "wrapped", errs: []error{fmt.Errorf("fetching: %w", transcript.ErrEmptyTranscript{})}, want: exitEmpty},
+		{name: "unclassified", errs: []error{errors.New("boom")}, want: exitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchExitCode(tt.errs); got != tt.want {
+				t.Errorf("batchExitCode(%v) = %d; want %d", tt.errs, got, tt.want)
+			}
+		})
+	}
+}
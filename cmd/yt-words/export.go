@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"path/filepath"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runExport fetches a transcript once and writes it in several formats
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	formatList := fs.String("formats", "srt,vtt,json,md", "comma-separated formats to write")
+	outDir := fs.String("out-dir", ".", "directory the files are written to")
+	name := fs.String("name", "{id}", "file name without extension; placeholders: {id} {title} {lang} {date} {channel}")
+	chunks := fs.Bool("chunks", false, "also write NAME.chunks.jsonl, chunk records for embedding with video_id, title and channel")
+	documents := fs.Bool("documents", false, "also write NAME.documents.jsonl, chunks as LangChain/LlamaIndex documents with page_content and metadata")
+	chunkTokens := fs.Int("chunk-tokens", transcript.DefaultEmbeddingTokens, "maximum estimated tokens per record of --chunks and --documents")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s export <YouTube URL or Video ID> [--formats srt,vtt,json,md] [--out-dir dir] [--name template] [--chunks] [--documents] [--chunk-tokens 512]", getBinaryName())
+	}
+
+	var formats []transcript.Format
+	for _, f := range strings.Split(*formatList, ",") {
+		if strings.TrimSpace(f) == "" {
+			continue
+		}
+		format, err := transcript.ParseFormat(f)
+		if err != nil {
+			cliLog.usagef("Invalid --formats: %v", err)
+		}
+		formats = append(formats, format)
+	}
+	if len(formats) == 0 && !*chunks && !*documents {
+		cliLog.usagef("--formats needs at least one format")
+	}
+
+	input := positional[0]
+	videoID, err := transcript.ExtractVideoID(input)
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	client := newClient(netFlags.options()...)
+	r := fetchOne(client, netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+
+	// Resolve the name once so metadata placeholders cost a single request for all formats
+	base := &outputWriter{client: client, pathTemplate: *name}
+	stem, err := base.path(&r)
+	if err != nil {
+		cliLog.fatalf("Error naming output files: %v", err)
+	}
+
+	for _, format := range formats {
+		w := &outputWriter{client: client, format: format, pathTemplate: filepath.Join(*outDir, stem+".{ext}"), logf: cliLog.infof}
+		if _, err := w.write(r); err != nil {
+			cliLog.fatalf("Error writing %s: %v", format, err)
+		}
+	}
+
+	if !*chunks && !*documents {
+		return
+	}
+	md, err := base.metadata(&r)
+	if err != nil {
+		cliLog.failf(videoID, err, "Error writing chunks: %v", err)
+	}
+	records := transcript.EmbeddingRecords(md, r.entries, *chunkTokens)
+	if *chunks {
+		writeLines(filepath.Join(*outDir, stem+".chunks.jsonl"), func(buf *bytes.Buffer) error {
+			return transcript.WriteEmbeddingJSONL(buf, records)
+		})
+	}
+	if *documents {
+		writeLines(filepath.Join(*outDir, stem+".documents.jsonl"), func(buf *bytes.Buffer) error {
+			return transcript.WriteLoaderJSONL(buf, transcript.LoaderDocuments(records))
+		})
+	}
+}
+
+// writeLines writes the JSON Lines produced by encode to path
+func writeLines(path string, encode func(*bytes.Buffer) error) {
+	var buf bytes.Buffer
+	if err := encode(&buf); err != nil {
+		cliLog.fatalf("Error writing %s: %v", path, err)
+	}
+	if err := writeFile(path, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing %s: %v", path, err)
+	}
+	cliLog.infof("Wrote %s", path)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runGet fetches and prints the transcript of a single video
+func runGet(args []string) {
+	fs := flag.NewFlagSet("get", flag.ExitOnError)
+	selFlags := addSelectionFlags(fs)
+	outFlags := addOutputFlags(fs)
+	trFlags := addTranslateFlags(fs)
+	wait := fs.Bool("wait", false, "for upcoming streams, premieres and videos without captions yet, poll until the transcript exists")
+	waitInterval := fs.Duration("wait-interval", 5*time.Minute, "time between --wait polls")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+	trFlags.setup()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s [get] <YouTube URL or Video ID> [--lang code | --langs a,b] [--prefer-manual] [--generated-only] [--format f | --template t] [--output path] [--translate-with service --translate-to code] [--wait [--wait-interval 5m]]", getBinaryName())
+	}
+
+	input := positional[0]
+	videoID, err := transcript.ExtractVideoID(input)
+	if err != nil && transcript.ExtractPlaylistID(input) != "" {
+		runPlaylist(args)
+		return
+	}
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	client := newClient(netFlags.options()...)
+	r := fetchOne(client, netFlags.cache(), videoID, selFlags.selection())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	for *wait && isPending(r.err) {
+		delay := *waitInterval
+		var notYet transcript.ErrNotYetAvailable
+		if errors.As(r.err, &notYet) && !notYet.ScheduledStart.IsZero() {
+			if untilStart := time.Until(notYet.ScheduledStart); untilStart > 0 && untilStart < delay {
+				delay = untilStart
+			}
+		}
+		cliLog.infof("%v; checking again in %s", r.err, delay.Round(time.Second))
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			cliLog.exitf(exitInterrupted, "Interrupted while waiting for the transcript of %s", videoID)
+		}
+		r = fetchOne(client, netFlags.cache(), videoID, selFlags.selection())
+	}
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+
+	r = trFlags.apply(r)
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error translating transcript: %v", r.err)
+	}
+	if _, err := outFlags.writer(client).write(r); err != nil {
+		cliLog.fatalf("Error writing transcript: %v", err)
+	}
+}
+
+// isPending reports whether a transcript may exist later: the video has not started yet, or
+// YouTube has not published any captions yet. A video whose tracks exist but none in the
+// requested languages is not pending, as waiting would not change that.
+func isPending(err error) bool {
+	var (
+		notYet       transcript.ErrNotYetAvailable
+		noTranscript transcript.ErrNoTranscriptFound
+	)
+	if errors.As(err, &noTranscript) {
+		return noTranscript.Language == ""
+	}
+	return errors.As(err, &notYet)
+}
+
+// parseInterspersed parses flags appearing before or after positional arguments,
+// so both "yt-words --lang de VIDEO" and "yt-words VIDEO --lang de" work
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...)
+		}
+		if len(rest) == 0 {
+			return positional
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
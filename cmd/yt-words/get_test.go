@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		args       []string
+		positional []string
+		lang       string
+	}{
+		{args: []string{"VIDEO"}, positional: []string{"VIDEO"}},
+		{args: []string{"--lang", "de", "VIDEO"}, positional: []string{"VIDEO"}, lang: "de"},
+		{args: []string{"VIDEO", "--lang", "de"}, positional: []string{"VIDEO"}, lang: "de"},
+		{args: []string{"A", "--lang", "de", "B"}, positional: []string{"A", "B"}, lang: "de"},
+		// Everything after -- is positional, even if it looks like a flag
+		{args: []string{"A", "--", "--lang", "-x"}, positional: []string{"A", "--lang", "-x"}},
+		{args: []string{"--lang", "de", "--", "-dashed1234"}, positional: []string{"-dashed1234"}, lang: "de"},
+		{args: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			lang := fs.String("lang", "", "")
+			positional := parseInterspersed(fs, tt.args)
+			if !reflect.DeepEqual(positional, tt.positional) || *lang != tt.lang {
+				t.Errorf("parseInterspersed(%q) = %q, lang %q; want %q, lang %q", tt.args, positional, *lang, tt.positional, tt.lang)
+			}
+		})
+	}
+}
+
+func TestIsPending(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{transcript.ErrNotYetAvailable{VideoID: "abcdefghijk"}, true},
+		{transcript.ErrNoTranscriptFound{VideoID: "abcdefghijk"}, true},
+		// Tracks exist, just none in the requested language: waiting would never end
+		{transcript.ErrNoTranscriptFound{VideoID: "abcdefghijk", Language: "xx"}, false},
+		{transcript.ErrTranscriptsDisabled{VideoID: "abcdefghijk"}, false},
+		{errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		if got := isPending(tt.err); got != tt.want {
+			t.Errorf("isPending(%v) = %v; want %v", tt.err, got, tt.want)
+		}
+	}
+}
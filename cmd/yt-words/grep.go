@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// grepMatch is the --json representation of a matching entry
+type grepMatch struct {
+	VideoID string  `json:"videoId"`
+	Start   float64 `json:"start"`
+	Link    string  `json:"link"`
+	Text    string  `json:"text"`
+}
+
+// runGrep searches the transcripts of many videos for a phrase or regular expression
+func runGrep(args []string) {
+	fs := flag.NewFlagSet("grep", flag.ExitOnError)
+	input := fs.String("input", "", "file with one URL or video ID per line (- for stdin, the default without video arguments)")
+	isRegexp := fs.Bool("regexp", false, "treat the pattern as a regular expression")
+	caseSensitive := fs.Bool("case-sensitive", false, "match case exactly")
+	storeDir := fs.String("store", "", "read transcripts from this store directory when present instead of fetching them")
+	asJSON := fs.Bool("json", false, "print one JSON object per match")
+	bFlags := addBatchFlags(fs)
+	selFlags := addSelectionFlags(fs)
+	noColor := fs.Bool("no-color", false, "disable colored terminal output (also disabled by NO_COLOR)")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) == 0 {
+		cliLog.usagef("Usage: %s grep <phrase> [videos...] [--input ids.txt|-] [--regexp] [--case-sensitive] [--store dir] [--json]", getBinaryName())
+	}
+
+	pattern := positional[0]
+	if !*isRegexp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !*caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		cliLog.usagef("Invalid pattern: %v", err)
+	}
+
+	var st *store.Store
+	if *storeDir != "" {
+		if st, err = store.Open(*storeDir); err != nil {
+			cliLog.fatalf("Error opening store: %v", err)
+		}
+	}
+
+	videoIDs := readVideoIDs(*input, positional[1:])
+	client := newClient(append(netFlags.options(), bFlags.options()...)...)
+	sel := selFlags.selection()
+	tc := netFlags.cache()
+	fetch := func(videoID string) batchResult {
+		if st != nil {
+			if rec, err := st.Get(videoID); err == nil {
+				return batchResult{videoID: videoID, entries: rec.Entries}
+			}
+		}
+		return fetchOne(client, tc, videoID, sel)
+	}
+
+	colors := newPalette(*noColor)
+	var failures []error
+	matches := 0
+	handled := fetchBatch(context.Background(), videoIDs, *bFlags.concurrency, fetch, func(r batchResult) bool {
+		if r.err != nil {
+			failures = append(failures, r.err)
+			cliLog.failure(r.videoID, r.err, "Error fetching transcript for %s: %v", r.videoID, r.err)
+			return bFlags.tolerates(len(failures))
+		}
+		for _, e := range r.entries {
+			if !re.MatchString(e.Text) {
+				continue
+			}
+			matches++
+			link := transcript.DeepLink(r.videoID, e.Start)
+			if *asJSON {
+				printJSONLine(grepMatch{VideoID: r.videoID, Start: e.Start, Link: link, Text: e.Text})
+				continue
+			}
+			fmt.Printf("%s %s %s %s\n", colors.bold(r.videoID), colors.dim(transcript.FormatClock(e.Start)), colors.dim(link), colors.highlight(e.Text, re))
+		}
+		return true
+	})
+
+	if handled < len(videoIDs) {
+		cliLog.exitf(batchExitCode(failures), "Aborted after %d failed videos; %d of %d videos were not searched", len(failures), len(videoIDs)-handled, len(videoIDs))
+	}
+	if len(failures) > 0 {
+		cliLog.exitf(batchExitCode(failures), "%d of %d videos failed", len(failures), len(videoIDs))
+	}
+	// Like grep, exit with 1 when nothing matched
+	if matches == 0 {
+		os.Exit(exitFailure)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runHighlights prints what is said during the most replayed moments of a video
+func runHighlights(args []string) {
+	fs := flag.NewFlagSet("highlights", flag.ExitOnError)
+	top := fs.Int("top", 5, "number of most replayed segments to consider")
+	lang := fs.String("lang", "", "language code of the transcript (default: English, else the first track)")
+	asJSON := fs.Bool("json", false, "print the highlights as JSON")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s highlights <YouTube URL or Video ID> [--top 5] [--lang code] [--json]", getBinaryName())
+	}
+	if *top < 1 {
+		cliLog.usagef("--top must be at least 1")
+	}
+
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+	highlights, err := newClient(netFlags.options()...).GetHighlights(videoID, *lang, *top)
+	if err != nil {
+		cliLog.failf(videoID, err, "Error fetching highlights: %v", err)
+	}
+
+	if *asJSON {
+		printJSON(highlights)
+		return
+	}
+	if len(highlights) == 0 {
+		cliLog.infof("%s has no most replayed graph yet", videoID)
+		return
+	}
+	for _, h := range highlights {
+		fmt.Printf("%s-%s %s\n  %s\n", transcript.FormatClock(h.Start), transcript.FormatClock(h.End), transcript.DeepLink(videoID, h.Start), h.Text)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/index"
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// indexFlags registers the flags locating the store and its search index
+type indexFlags struct {
+	store *string
+	db    *string
+}
+
+func addIndexFlags(fs *flag.FlagSet) *indexFlags {
+	return &indexFlags{
+		store: fs.String("store", "transcripts", "store directory the transcripts were saved to, e.g. by watch"),
+		db:    fs.String("db", "", "index database (default: index.db in the store directory)"),
+	}
+}
+
+// open opens the index database
+func (f *indexFlags) open() index.Index {
+	path := *f.db
+	if path == "" {
+		path = filepath.Join(*f.store, "index.db")
+	}
+	idx, err := index.Open(path)
+	if err != nil {
+		cliLog.fatalf("Error opening index: %v", err)
+	}
+	return idx
+}
+
+// runIndex adds every transcript of a store to its search index
+func runIndex(args []string) {
+	fs := flag.NewFlagSet("index", flag.ExitOnError)
+	iFlags := addIndexFlags(fs)
+	logFlags := addLogFlags(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+	if len(positional) != 0 {
+		cliLog.usagef("Usage: %s index [--store dir] [--db path]", getBinaryName())
+	}
+
+	st, err := store.Open(*iFlags.store)
+	if err != nil {
+		cliLog.fatalf("Error opening store: %v", err)
+	}
+	idx := iFlags.open()
+	defer idx.Close()
+	n, err := index.Ingest(idx, st)
+	if err != nil {
+		cliLog.fatalf("Error indexing %s after %d transcripts: %v", *iFlags.store, n, err)
+	}
+	cliLog.infof("Indexed %d transcripts", n)
+}
+
+// runQuery searches the index of a store, best matches first
+func runQuery(args []string) {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	limit := fs.Int("limit", 20, "maximum number of passages to print (0 means all)")
+	channel := fs.String("channel", "", "only search the videos of this channel, by ID or name")
+	asJSON := fs.Bool("json", false, "print one JSON object per passage")
+	iFlags := addIndexFlags(fs)
+	logFlags := addLogFlags(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+	if len(positional) == 0 {
+		cliLog.usagef("Usage: %s query <words or \"a phrase\"> [--channel name] [--store dir] [--db path] [--limit 20] [--json]", getBinaryName())
+	}
+
+	idx := iFlags.open()
+	defer idx.Close()
+	results, err := idx.Rank(index.Query{Text: strings.Join(positional, " "), Channel: *channel, Limit: *limit})
+	if err != nil {
+		cliLog.exitf(exitUsage, "%v", err)
+	}
+	for _, r := range results {
+		if *asJSON {
+			printJSONLine(r)
+			continue
+		}
+		fmt.Printf("%s %s (%s)\n  %s %s\n", r.Link, r.Title, r.Author, transcript.FormatClock(r.Start), r.Snippet)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/mjlefevre/yt-words-go/jobs"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+const defaultJobsDB = "yt-words-jobs.db"
+
+// runJobs dispatches the jobs subcommands
+func runJobs(args []string) {
+	if len(args) < 1 {
+		jobsUsage()
+		os.Exit(exitUsage)
+	}
+
+	fs := flag.NewFlagSet("jobs "+args[0], flag.ExitOnError)
+	dbPath := fs.String("db", defaultJobsDB, "path of the job database")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	// parse reads the subcommand flags, which may follow the positional arguments
+	parse := func() []string {
+		positional := parseInterspersed(fs, args[1:])
+		logFlags.apply()
+		return positional
+	}
+
+	switch args[0] {
+	case "submit":
+		lang := fs.String("lang", "", "language code to fetch (default: English, then first available)")
+		input := fs.String("input", "", "file with one URL or video ID per line (- for stdin)")
+		playlist := fs.String("playlist", "", "also submit the videos of this playlist URL or ID")
+		channel := fs.String("channel", "", "also submit the uploads of this channel (@handle, URL or UC... ID)")
+		limit := fs.Int("limit", 0, "submit at most this many videos of the playlist or channel (0 means all)")
+		inputs := parse()
+		if *input != "" {
+			lines, err := readInputLines(*input)
+			if err != nil {
+				cliLog.usagef("Error reading %s: %v", *input, err)
+			}
+			inputs = append(inputs, lines...)
+		}
+
+		var videoIDs []string
+		for _, in := range inputs {
+			videoID, err := transcript.ExtractVideoID(in)
+			if err != nil {
+				cliLog.usagef("%v", err)
+			}
+			videoIDs = append(videoIDs, videoID)
+		}
+		if *playlist != "" || *channel != "" {
+			videoIDs = append(videoIDs, listJobVideos(newClient(netFlags.options()...), *playlist, *channel, *limit)...)
+		}
+		if len(videoIDs) == 0 {
+			cliLog.usagef("Nothing to submit: pass video IDs, --input, --playlist or --channel")
+		}
+
+		job, err := openQueue(*dbPath).Enqueue(videoIDs, *lang)
+		if err != nil {
+			cliLog.fatalf("Error submitting job: %v", err)
+		}
+		fmt.Println(job.ID)
+
+	case "status":
+		positional := parse()
+		if len(positional) != 1 {
+			cliLog.usagef("Usage: %s jobs status [-db path] <job ID>", getBinaryName())
+		}
+		job, err := openQueue(*dbPath).Get(positional[0])
+		if err != nil {
+			cliLog.fatalf("Error reading job: %v", err)
+		}
+		printJSON(job)
+
+	case "list":
+		limit := fs.Int("limit", 20, "maximum number of jobs to list")
+		parse()
+		list, err := openQueue(*dbPath).List(*limit)
+		if err != nil {
+			cliLog.fatalf("Error listing jobs: %v", err)
+		}
+		for _, job := range list {
+			fmt.Printf("%s\t%s\t%d/%d\t%d failed\t%s\n", job.ID, job.Status, job.Completed, job.Total, job.Failed,
+				job.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+
+	case "results":
+		positional := parse()
+		if len(positional) != 1 {
+			cliLog.usagef("Usage: %s jobs results [-db path] <job ID>", getBinaryName())
+		}
+		items, err := openQueue(*dbPath).Items(positional[0])
+		if err != nil {
+			cliLog.fatalf("Error reading job results: %v", err)
+		}
+		printJSON(items)
+
+	case "work":
+		workers := fs.Int("workers", 4, "number of videos fetched in parallel")
+		parse()
+		client := newClient(netFlags.options()...)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		cliLog.infof("Processing jobs from %s with %d workers", *dbPath, *workers)
+		openQueue(*dbPath).Run(ctx, client, *workers)
+
+	default:
+		jobsUsage()
+		os.Exit(exitUsage)
+	}
+}
+
+// listJobVideos enumerates the videos of a playlist and the uploads of a channel, at most
+// limit of each when limit is positive
+func listJobVideos(client *transcript.Client, playlist, channel string, limit int) []string {
+	var playlistIDs []string
+	if playlist != "" {
+		playlistID := transcript.ExtractPlaylistID(playlist)
+		if playlistID == "" {
+			cliLog.usagef("Invalid YouTube playlist URL or ID: %s", playlist)
+		}
+		playlistIDs = append(playlistIDs, playlistID)
+	}
+	if channel != "" {
+		channelID, err := client.ResolveChannelID(channel)
+		if err != nil {
+			cliLog.exitf(exitUsage, "Error resolving channel: %v", err)
+		}
+		playlistIDs = append(playlistIDs, transcript.UploadsPlaylistID(channelID))
+	}
+
+	var videoIDs []string
+	for _, playlistID := range playlistIDs {
+		videos, err := client.GetPlaylistVideos(playlistID)
+		if err != nil {
+			cliLog.failf("", err, "Error listing playlist %s: %v", playlistID, err)
+		}
+		if limit > 0 && len(videos) > limit {
+			videos = videos[:limit]
+		}
+		for _, v := range videos {
+			videoIDs = append(videoIDs, v.VideoID)
+		}
+		cliLog.verbosef("Submitting %d videos of %s", len(videos), playlistID)
+	}
+	return videoIDs
+}
+
+func jobsUsage() {
+	fmt.Printf("Usage: %s jobs submit [-db path] [-lang code] [-input file] [-playlist URL] [-channel @handle] [-limit n] [URL or ID...]\n", getBinaryName())
+	fmt.Printf("       %s jobs status|results [-db path] <job ID>\n", getBinaryName())
+	fmt.Printf("       %s jobs list [-db path] [-limit n]\n", getBinaryName())
+	fmt.Printf("       %s jobs work [-db path] [-workers n]\n", getBinaryName())
+}
+
+func openQueue(path string) *jobs.Queue {
+	q, err := jobs.Open(path, jobs.WithLogger(errorLogger{cliLog}))
+	if err != nil {
+		cliLog.fatalf("Error opening job database %s: %v", path, err)
+	}
+	return q
+}
+
+// readInputLines reads non-empty, non-comment lines from path, or from stdin when path is "-"
+func readInputLines(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func printJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		cliLog.fatalf("Error encoding JSON: %v", err)
+	}
+}
+
+// printJSONLine writes v as a single line of JSON, for streaming output such as JSON Lines
+func printJSONLine(v interface{}) {
+	if err := json.NewEncoder(os.Stdout).Encode(v); err != nil {
+		cliLog.fatalf("Error encoding JSON: %v", err)
+	}
+}
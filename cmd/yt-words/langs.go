@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// languageTrack is the JSON shape of an available caption track
+type languageTrack struct {
+	LanguageCode   string `json:"languageCode"`
+	Language       string `json:"language"`
+	IsGenerated    bool   `json:"isGenerated"`
+	IsTranslatable bool   `json:"isTranslatable"`
+}
+
+// runLangs lists the caption tracks available for a video
+func runLangs(args []string) {
+	fs := flag.NewFlagSet("langs", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print tracks as JSON instead of a table")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s langs <YouTube URL or Video ID> [--json]", getBinaryName())
+	}
+
+	input := positional[0]
+	videoID, err := transcript.ExtractVideoID(input)
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	transcripts, err := newClient(netFlags.options()...).ListAvailableTranscripts(videoID)
+	if err != nil {
+		cliLog.failf(videoID, err, "Error listing languages: %v", err)
+	}
+
+	if *asJSON {
+		tracks := make([]languageTrack, 0, len(transcripts))
+		for _, t := range transcripts {
+			tracks = append(tracks, languageTrack{
+				LanguageCode:   t.LanguageCode,
+				Language:       t.Language,
+				IsGenerated:    t.IsGenerated,
+				IsTranslatable: t.IsTranslatable,
+			})
+		}
+		printJSON(tracks)
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CODE\tNAME\tTYPE\tTRANSLATABLE")
+	for _, t := range transcripts {
+		kind := "manual"
+		if t.IsGenerated {
+			kind = "generated"
+		}
+		translatable := "no"
+		if t.IsTranslatable {
+			translatable = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", t.LanguageCode, t.Language, kind, translatable)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runLink prints a link to the moment of a video where a phrase is said
+func runLink(args []string) {
+	fs := flag.NewFlagSet("link", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the match as JSON")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) < 2 {
+		cliLog.usagef("Usage: %s link <YouTube URL or Video ID> <phrase> [--json] [--lang code | --langs a,b]", getBinaryName())
+	}
+
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+	phrase := strings.Join(positional[1:], " ")
+
+	r := fetchOne(newClient(netFlags.options()...), netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+
+	m, ok := transcript.LocateQuote(r.entries, phrase)
+	if !ok {
+		cliLog.exitf(exitFailure, "%q is not said in %s", phrase, videoID)
+	}
+	link := transcript.DeepLink(videoID, m.Start)
+	if *asJSON {
+		printJSON(struct {
+			VideoID string `json:"videoId"`
+			Link    string `json:"link"`
+			transcript.QuoteMatch
+		}{videoID, link, m})
+		return
+	}
+	cliLog.infof("%s %s (%.0f%% match)", transcript.FormatClock(m.Start), m.Text, m.Score*100)
+	fmt.Println(link)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// liveEntry is the --json representation of a live caption
+type liveEntry struct {
+	Start float64 `json:"start"`
+	Link  string  `json:"link"`
+	Text  string  `json:"text"`
+}
+
+// runLive prints the captions of an ongoing live stream as YouTube publishes them
+func runLive(args []string) {
+	fs := flag.NewFlagSet("live", flag.ExitOnError)
+	lang := fs.String("lang", "", "language code to follow (default: English, then first available)")
+	asJSON := fs.Bool("json", false, "print one JSON object per caption")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s live <YouTube URL or Video ID> [--lang code] [--json]", getBinaryName())
+	}
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	live, err := newClient(netFlags.options()...).FollowLiveTranscript(ctx, videoID, *lang)
+	if err != nil {
+		cliLog.failf(videoID, err, "Error following live captions: %v", err)
+	}
+	cliLog.infof("Following live captions of %s; press Ctrl-C to stop", videoID)
+	for e := range live.Entries {
+		if *asJSON {
+			printJSONLine(liveEntry{Start: e.Start, Link: transcript.DeepLink(videoID, e.Start), Text: e.Text})
+			continue
+		}
+		fmt.Printf("[%s] %s\n", transcript.FormatClock(e.Start), e.Text)
+	}
+	if err := live.Err(); err != nil {
+		cliLog.failf(videoID, err, "Error following live captions: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// Log levels, from least to most verbose
+const (
+	levelError = iota
+	levelInfo
+	levelVerbose
+	levelDebug
+)
+
+var levelNames = map[int]string{
+	levelError:   "error",
+	levelInfo:    "info",
+	levelVerbose: "verbose",
+	levelDebug:   "debug",
+}
+
+// cliLog is the logger used by the fetching commands; its level and format are set by logFlags
+var cliLog = &cliLogger{out: os.Stderr, level: levelInfo}
+
+// cliLogger writes leveled log lines as text or as one JSON object per line
+type cliLogger struct {
+	mu    sync.Mutex
+	out   io.Writer
+	level int
+	json  bool
+	// errorJSON reports failures as cliError objects regardless of the log format
+	errorJSON bool
+}
+
+func (l *cliLogger) logf(level int, format string, v ...interface{}) {
+	if level > l.level {
+		return
+	}
+	msg := fmt.Sprintf(format, v...)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.json {
+		fmt.Fprintf(l.out, "%s %s\n", time.Now().Format("2006/01/02 15:04:05"), msg)
+		return
+	}
+	b, _ := json.Marshal(struct {
+		Time  string `json:"time"`
+		Level string `json:"level"`
+		Msg   string `json:"msg"`
+	}{time.Now().Format(time.RFC3339), levelNames[level], msg})
+	fmt.Fprintf(l.out, "%s\n", b)
+}
+
+func (l *cliLogger) errorf(format string, v ...interface{})   { l.logf(levelError, format, v...) }
+func (l *cliLogger) infof(format string, v ...interface{})    { l.logf(levelInfo, format, v...) }
+func (l *cliLogger) verbosef(format string, v ...interface{}) { l.logf(levelVerbose, format, v...) }
+
+// fatalf logs an error and exits with exitFailure
+func (l *cliLogger) fatalf(format string, v ...interface{}) {
+	l.exitf(exitFailure, format, v...)
+}
+
+// libraryLogger adapts cliLogger to transcript.Logger at debug level
+type libraryLogger struct{ l *cliLogger }
+
+func (a libraryLogger) Printf(format string, v ...interface{}) { a.l.logf(levelDebug, format, v...) }
+
+// errorLogger adapts cliLogger to transcript.Logger at error level
+type errorLogger struct{ l *cliLogger }
+
+func (a errorLogger) Printf(format string, v ...interface{}) { a.l.logf(levelError, format, v...) }
+
+// logFlags registers --quiet, -v, -vv and --log-format
+type logFlags struct {
+	quiet       *bool
+	verbose     *bool
+	debug       *bool
+	logFormat   *string
+	errorFormat *string
+}
+
+func addLogFlags(fs *flag.FlagSet) *logFlags {
+	return &logFlags{
+		quiet:       fs.Bool("quiet", false, "only log errors and hide progress output"),
+		verbose:     fs.Bool("v", false, "verbose logging"),
+		debug:       fs.Bool("vv", false, "debug logging, including every HTTP request made by the library"),
+		logFormat:   fs.String("log-format", "text", "log format: text or json"),
+		errorFormat: fs.String("error-format", "text", "failure report format on stderr: text or json {code, videoId, message, retryable}"),
+	}
+}
+
+// apply configures cliLog from the parsed flags. The error format is set first, so an
+// invalid --log-format is already reported in it; an invalid --error-format is reported as text.
+func (f *logFlags) apply() {
+	switch *f.errorFormat {
+	case "text":
+	case "json":
+		cliLog.errorJSON = true
+	default:
+		cliLog.usagef("Invalid --error-format %q: want text or json", *f.errorFormat)
+	}
+
+	switch *f.logFormat {
+	case "text":
+	case "json":
+		cliLog.json = true
+	default:
+		cliLog.usagef("Invalid --log-format %q: want text or json", *f.logFormat)
+	}
+
+	switch {
+	case *f.debug:
+		cliLog.level = levelDebug
+	case *f.verbose:
+		cliLog.level = levelVerbose
+	case *f.quiet:
+		cliLog.level = levelError
+	}
+}
+
+// newClient creates a library client whose diagnostics go to cliLog, followed by options
+func newClient(extra ...transcript.ClientOption) *transcript.Client {
+	var options []transcript.ClientOption
+	if cliLog.level >= levelDebug {
+		options = append(options, transcript.WithLogger(libraryLogger{cliLog}))
+	}
+	if cfg.UserAgent != "" {
+		options = append(options, transcript.WithUserAgent(cfg.UserAgent))
+	}
+	if len(cfg.Headers) > 0 {
+		options = append(options, transcript.WithHeaders(cfg.Headers))
+	}
+	options = append(options, extra...)
+	client, err := transcript.NewClientE(options...)
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+	return client
+}
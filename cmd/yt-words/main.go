@@ -2,31 +2,178 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"os"
-
-	"github.com/mjlefevre/yt-words-go/transcript"
 )
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Printf("Usage: %s <YouTube URL or Video ID>\n", getBinaryName())
+		usage()
 		os.Exit(1)
 	}
 
-	input := os.Args[1]
-	videoID := transcript.ExtractVideoID(input)
-	if videoID == "" {
-		log.Fatalf("Invalid YouTube URL or Video ID: %s", input)
+	// Neither needs the config, so a broken one cannot keep them from working
+	switch os.Args[1] {
+	case "version", "--version", "-version":
+		fmt.Println(versionString())
+		return
+	case "help", "--help", "-help", "-h":
+		usage()
+		return
 	}
 
-	client := transcript.NewClient()
-	transcriptText, err := client.GetTranscriptString(videoID)
+	var err error
+	if cfg, err = loadConfig(configPath()); err == nil {
+		err = cfg.applyEnv()
+	}
 	if err != nil {
-		log.Fatalf("Error fetching transcript: %v", err)
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	switch os.Args[1] {
+	case "get":
+		runGet(os.Args[2:])
+		return
+	case "batch":
+		runBatch(os.Args[2:])
+		return
+	case "playlist":
+		runPlaylist(os.Args[2:])
+		return
+	case "channel":
+		runChannel(os.Args[2:])
+		return
+	case "langs":
+		runLangs(os.Args[2:])
+		return
+	case "cache":
+		runCache(os.Args[2:])
+		return
+	case "completion":
+		runCompletion(os.Args[2:])
+		return
+	case "stats":
+		runStats(os.Args[2:])
+		return
+	case "compare":
+		runCompare(os.Args[2:])
+		return
+	case "wordfreq":
+		runWordFreq(os.Args[2:])
+		return
+	case "highlights":
+		runHighlights(os.Args[2:])
+		return
+	case "notes":
+		runNotes(os.Args[2:])
+		return
+	case "anki":
+		runAnki(os.Args[2:])
+		return
+	case "mux":
+		runMux(os.Args[2:])
+		return
+	case "markers":
+		runMarkers(os.Args[2:])
+		return
+	case "epub":
+		runEPUB(os.Args[2:])
+		return
+	case "merge":
+		runMerge(os.Args[2:])
+		return
+	case "index":
+		runIndex(os.Args[2:])
+		return
+	case "query":
+		runQuery(os.Args[2:])
+		return
+	case "duplicates":
+		runDuplicates(os.Args[2:])
+		return
+	case "sync":
+		runSync(os.Args[2:])
+		return
+	case "retry":
+		runRetry(os.Args[2:])
+		return
+	case "link":
+		runLink(os.Args[2:])
+		return
+	case "grep":
+		runGrep(os.Args[2:])
+		return
+	case "export":
+		runExport(os.Args[2:])
+		return
+	case "clean":
+		runClean(os.Args[2:])
+		return
+	case "summarize":
+		runSummarize(os.Args[2:])
+		return
+	case "jobs":
+		runJobs(os.Args[2:])
+		return
+	case "serve":
+		runServe(os.Args[2:])
+		return
+	case "serve-grpc":
+		runServeGRPC(os.Args[2:])
+		return
+	case "watch":
+		runWatch(os.Args[2:])
+		return
+	case "live":
+		runLive(os.Args[2:])
+		return
 	}
 
-	fmt.Printf("Transcript for video %s:\n%s\n", videoID, transcriptText)
+	runGet(os.Args[1:])
+}
+
+func usage() {
+	fmt.Printf("Usage: %s [get] <YouTube URL or Video ID> [--lang code | --langs a,b] [--prefer-manual] [--generated-only] [--format f | --template t] [--output path] [--pipeline strip,dedup,reflow] [--sponsorblock strip|label]\n", getBinaryName())
+	fmt.Printf("       %s batch [--input ids.txt|-] [--concurrency 3] [--rate n] [--fail-fast | --max-failures n] [--lang code | --langs a,b] [--format f | --template t] [--output template] [--out-dir dir [--resume]] [--manifest run.json] [--retry-queue file] [--summary-json file] [--openai-batch file --model name [--system-prompt text]]\n", getBinaryName())
+	fmt.Printf("       %s playlist <playlist URL or ID> [--limit n] plus the batch flags; get also accepts playlist URLs\n", getBinaryName())
+	fmt.Printf("       %s channel <@handle or channel URL> [--limit 100] [--since YYYY-MM-DD] [--until YYYY-MM-DD] plus the batch flags\n", getBinaryName())
+	fmt.Printf("       %s langs <YouTube URL or Video ID> [--json]\n", getBinaryName())
+	fmt.Printf("       %s stats <YouTube URL or Video ID> [--json] [--pace [--window 1m] | --gaps 10s]\n", getBinaryName())
+	fmt.Printf("       %s wordfreq <YouTube URL or Video ID> [--format csv|json] [--min-count n] [--limit n] [--output path]\n", getBinaryName())
+	fmt.Printf("       %s compare <YouTube URL or Video ID> [--lang en] [--json]\n", getBinaryName())
+	fmt.Printf("       %s grep <phrase> [videos...] [--input ids.txt|-] [--regexp] [--store dir] [--json]\n", getBinaryName())
+	fmt.Printf("       %s link <YouTube URL or Video ID> <phrase> [--json]\n", getBinaryName())
+	fmt.Printf("       %s highlights <YouTube URL or Video ID> [--top 5] [--lang code] [--json]\n", getBinaryName())
+	fmt.Printf("       %s notes <YouTube URL or Video ID> [--output path] [--toc]\n", getBinaryName())
+	fmt.Printf("       %s anki <YouTube URL or Video ID> --translate-with service --translate-to code [--output cards.tsv]\n", getBinaryName())
+	fmt.Printf("       %s mux <YouTube URL or Video ID> <local video file> [--burn] [--output path] [--run]\n", getBinaryName())
+	fmt.Printf("       %s markers <YouTube URL or Video ID> [--match phrase [--regexp]] [--format edl|csv] [--fps 30] [--output path]\n", getBinaryName())
+	fmt.Printf("       %s epub <playlist URL or ID> [--output book.epub] [--title text] [--limit n] [--concurrency 3]\n", getBinaryName())
+	fmt.Printf("       %s merge [videos...] [--input ids.txt|-] [--global-time] [--output path]\n", getBinaryName())
+	fmt.Printf("       %s index [--store dir] [--db path]\n", getBinaryName())
+	fmt.Printf("       %s query <words or \"a phrase\"> [--channel name] [--store dir] [--db path] [--limit 20] [--json]\n", getBinaryName())
+	fmt.Printf("       %s duplicates [--store dir] [--threshold 0.8] [--json]\n", getBinaryName())
+	fmt.Printf("       %s export <YouTube URL or Video ID> [--formats srt,vtt,json,md] [--out-dir dir] [--name template] [--chunks] [--documents]\n", getBinaryName())
+	fmt.Printf("       %s clean <file.srt|file.vtt|file.json> [--format f] [--output path] [--no-strip] [--no-dedup] [--no-reflow] [--pipeline steps]\n", getBinaryName())
+	fmt.Printf("       %s summarize <YouTube URL or Video ID> --model name [--api-base URL] [--chunk-chars n]\n", getBinaryName())
+	fmt.Printf("       %s jobs submit|status|list|results|work [flags]\n", getBinaryName())
+	fmt.Printf("       %s serve [-addr :8080] [-graphql] [-rate 1 -burst 10 [-api-keys-file path]] [-jobs-db path]\n", getBinaryName())
+	fmt.Printf("       %s serve-grpc [-addr :50051]\n", getBinaryName())
+	fmt.Printf("       %s watch --channel @handle [--store dir] [--interval 15m] [--webhook URL]\n", getBinaryName())
+	fmt.Printf("       %s sync --channel @handle [--store dir] [--limit n] [--recheck] [--concurrency 3]\n", getBinaryName())
+	fmt.Printf("       %s retry [--retry-queue retry-queue.json] [--all] [--max-attempts 5] [--list] plus the batch flags\n", getBinaryName())
+	fmt.Printf("       %s live <YouTube URL or Video ID> [--lang code] [--json]\n", getBinaryName())
+	fmt.Printf("       %s cache ls|info|clear|prune [--older-than 30d]\n", getBinaryName())
+	fmt.Printf("       %s completion bash|zsh|fish\n", getBinaryName())
+	fmt.Printf("       %s --version\n", getBinaryName())
+	fmt.Printf("       %s help\n", getBinaryName())
+	fmt.Printf("\nget, batch, playlist, channel, export, langs, stats, wordfreq, compare, grep, link, highlights, notes, anki, mux, markers, epub, merge, sync, retry, summarize, live, jobs, serve, serve-grpc and watch also accept network flags (--proxy, --proxy-file, --hl, --header, --budget, --backend, --cookies, --timeout, --retries, --retry-delay, --max-retry-after, --no-cache)\n")
+	fmt.Printf("and logging flags (--quiet, -v, -vv, --log-format text|json, --error-format text|json)\n")
+	fmt.Printf("Defaults for languages, format, proxy, cache_dir, concurrency and user_agent are read from %s\n", configPath())
+	fmt.Printf("and can be overridden with YTWORDS_LANGS, YTWORDS_FORMAT, YTWORDS_PROXY, YTWORDS_CACHE_DIR, YTWORDS_CONCURRENCY and YTWORDS_USER_AGENT\n")
+	fmt.Printf("get, batch and anki --translate-with deepl|google|libretranslate read DEEPL_API_KEY, GOOGLE_TRANSLATE_API_KEY or LIBRETRANSLATE_URL/LIBRETRANSLATE_API_KEY\n")
+	fmt.Printf("summarize reads OPENAI_BASE_URL, OPENAI_API_KEY and OPENAI_MODEL and works with any OpenAI-compatible endpoint\n")
+	fmt.Printf("Exit codes: 1 error, 2 invalid input, 3 video unavailable, 4 no transcript, 5 transcripts disabled, 6 rate limited, 7 members only, 8 not yet available, 9 empty transcript, 10 region blocked\n")
 }
 
 func getBinaryName() string {
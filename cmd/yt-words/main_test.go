@@ -0,0 +1,13 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Helpers under test log progress through cliLog
+	cliLog.out = io.Discard
+	os.Exit(m.Run())
+}
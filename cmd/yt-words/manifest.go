@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// manifestName is the file written next to the transcripts of an --out-dir run
+const manifestName = "manifest.json"
+
+// manifest records the outcome of every video of a batch run
+type manifest struct {
+	// Version describes the binary that made the run, as printed by --version
+	Version    string    `json:"version"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Succeeded  int       `json:"succeeded"`
+	Failed     int       `json:"failed"`
+	// Empty counts the failed videos whose caption track has no cues
+	Empty int `json:"empty,omitempty"`
+	// Inputs are the videos the run was asked for, in order
+	Inputs []string `json:"inputs"`
+	// Settings are the flags given on the command line, secrets masked
+	Settings map[string]string `json:"settings,omitempty"`
+	Videos   []manifestEntry   `json:"videos"`
+	// Pending lists the videos an interrupted run did not get to, in input order
+	Pending []string `json:"pending,omitempty"`
+}
+
+type manifestEntry struct {
+	VideoID     string `json:"videoId"`
+	Status      string `json:"status"`
+	Language    string `json:"language,omitempty"`
+	IsGenerated bool   `json:"isGenerated,omitempty"`
+	File        string `json:"file,omitempty"`
+	// DurationMs is how long fetching the transcript took
+	DurationMs int64 `json:"durationMs,omitempty"`
+	// Fingerprint is the transcript.Fingerprint of the fetched entries
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func newManifest(inputs []string, settings map[string]string) *manifest {
+	return &manifest{Version: versionString(), StartedAt: time.Now(), Inputs: inputs, Settings: settings, Videos: []manifestEntry{}}
+}
+
+// secretFlags are the flags whose values a manifest does not record, as they may hold credentials
+var secretFlags = map[string]bool{"header": true, "proxy": true, "cookies": true}
+
+// flagSettings returns the flags set on the command line, masking secretFlags
+func flagSettings(fs *flag.FlagSet) map[string]string {
+	settings := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		settings[f.Name] = f.Value.String()
+		if secretFlags[f.Name] {
+			settings[f.Name] = "(set)"
+		}
+	})
+	return settings
+}
+
+// add records a result; it is a no-op on a nil manifest so callers need not check
+func (m *manifest) add(r batchResult, file string, err error) {
+	if m == nil {
+		return
+	}
+
+	entry := manifestEntry{VideoID: r.videoID, Status: "ok", File: file, DurationMs: r.elapsed.Milliseconds()}
+	if r.err == nil {
+		entry.Language = r.track.LanguageCode
+		entry.IsGenerated = r.track.IsGenerated
+		entry.Fingerprint = transcript.Fingerprint(r.entries)
+	}
+	if err != nil {
+		entry.Status, entry.Error = "failed", err.Error()
+		m.Failed++
+		if errors.As(err, new(transcript.ErrEmptyTranscript)) {
+			entry.Status = "empty"
+			m.Empty++
+		}
+	} else {
+		m.Succeeded++
+	}
+	m.Videos = append(m.Videos, entry)
+}
+
+// save writes the manifest as indented JSON
+func (m *manifest) save(path string) error {
+	m.FinishedAt = time.Now()
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, append(b, '\n'))
+}
+
+// resume carries over the finished videos of the manifest at path and returns the videoIDs
+// still to fetch. Failed videos are fetched again; a missing manifest resumes nothing.
+func (m *manifest) resume(path string, videoIDs []string) []string {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		cliLog.infof("No %s to resume from; fetching all videos", path)
+		return videoIDs
+	}
+	if err != nil {
+		cliLog.fatalf("Error reading manifest: %v", err)
+	}
+	var prev manifest
+	if err := json.Unmarshal(b, &prev); err != nil {
+		cliLog.fatalf("Error reading manifest %s: %v", path, err)
+	}
+
+	done := make(map[string]bool)
+	for _, v := range prev.Videos {
+		if v.Status == "ok" {
+			done[v.VideoID] = true
+			m.Videos = append(m.Videos, v)
+			m.Succeeded++
+		}
+	}
+	m.StartedAt = prev.StartedAt
+
+	var remaining []string
+	for _, id := range videoIDs {
+		if !done[id] {
+			remaining = append(remaining, id)
+		}
+	}
+	cliLog.infof("Resuming: %d of %d videos already done", len(videoIDs)-len(remaining), len(videoIDs))
+	return remaining
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestManifest_Add(t *testing.T) {
+	m := newManifest([]string{"a", "b", "c"}, nil)
+	m.add(batchResult{videoID: "a", track: transcript.Transcript{LanguageCode: "en"}, entries: []transcript.TranscriptEntry{{Text: "hi"}}}, "a.txt", nil)
+	empty := transcript.ErrEmptyTranscript{VideoID: "b"}
+	m.add(batchResult{videoID: "b", err: empty}, "", empty)
+	m.add(batchResult{videoID: "c", err: errors.New("boom")}, "", errors.New("boom"))
+
+	if m.Succeeded != 1 || m.Failed != 2 || m.Empty != 1 {
+		t.Errorf("counts = %d succeeded, %d failed, %d empty; want 1, 2, 1", m.Succeeded, m.Failed, m.Empty)
+	}
+	var statuses []string
+	for _, v := range m.Videos {
+		statuses = append(statuses, v.Status)
+	}
+	if !reflect.DeepEqual(statuses, []string{"ok", "empty", "failed"}) {
+		t.Errorf("statuses = %v; want [ok empty failed]", statuses)
+	}
+	if m.Videos[0].Language != "en" || m.Videos[0].Fingerprint == "" {
+		t.Errorf("Videos[0] = %+v; want the language and fingerprint of the track", m.Videos[0])
+	}
+
+	// A nil manifest ignores results
+	var none *manifest
+	none.add(batchResult{videoID: "a"}, "", nil)
+}
+
+func TestManifest_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), manifestName)
+	prev := newManifest([]string{"a", "b", "c"}, nil)
+	prev.add(batchResult{videoID: "a"}, "a.txt", nil)
+	prev.add(batchResult{videoID: "b", err: errors.New("boom")}, "", errors.New("boom"))
+	if err := prev.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		remaining []string
+		succeeded int
+	}{
+		{name: "existing manifest", path: path, remaining: []string{"b", "c"}, succeeded: 1},
+		{name: "missing manifest", path: filepath.Join(t.TempDir(), manifestName), remaining: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newManifest([]string{"a", "b", "c"}, nil)
+			remaining := m.resume(tt.path, []string{"a", "b", "c"})
+			if !reflect.DeepEqual(remaining, tt.remaining) {
+				t.Errorf("resume() = %v; want %v", remaining, tt.remaining)
+			}
+			if m.Succeeded != tt.succeeded || len(m.Videos) != tt.succeeded {
+				t.Errorf("resumed %d succeeded, %d videos; want %d", m.Succeeded, len(m.Videos), tt.succeeded)
+			}
+		})
+	}
+}
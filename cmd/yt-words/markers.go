@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"regexp"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runMarkers writes the chapters of a video, or the moments a phrase is said, as markers for
+// video editors
+func runMarkers(args []string) {
+	fs := flag.NewFlagSet("markers", flag.ExitOnError)
+	match := fs.String("match", "", "mark the captions containing this phrase instead of the chapters")
+	isRegexp := fs.Bool("regexp", false, "treat --match as a regular expression")
+	format := fs.String("format", "edl", "marker format: edl (CMX3600 with Resolve markers) or csv")
+	fps := fs.Int("fps", transcript.DefaultMarkerFPS, "frame rate of the timecodes")
+	output := fs.String("output", "", "write to this file instead of stdout")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s markers <YouTube URL or Video ID> [--match phrase [--regexp]] [--format edl|csv] [--fps 30] [--output path] [--lang code | --langs a,b]", getBinaryName())
+	}
+	if *format != "edl" && *format != "csv" {
+		cliLog.usagef("Invalid --format: %s (want edl or csv)", *format)
+	}
+	if *fps < 1 {
+		cliLog.usagef("--fps must be at least 1")
+	}
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	client := newClient(netFlags.options()...)
+	md, err := client.GetVideoMetadata(videoID)
+	if err != nil {
+		cliLog.failf(videoID, err, "Error fetching metadata: %v", err)
+	}
+
+	var markers []transcript.Marker
+	if *match != "" {
+		pattern := *match
+		if !*isRegexp {
+			pattern = "(?i)" + regexp.QuoteMeta(pattern)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			cliLog.usagef("Invalid --match: %v", err)
+		}
+		r := fetchOne(client, netFlags.cache(), videoID, selFlags.selection())
+		if r.err != nil {
+			cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+		}
+		markers = transcript.MatchMarkers(r.entries, re)
+	} else {
+		chapters := transcript.ParseChapters(md.Description)
+		if len(chapters) == 0 {
+			cliLog.exitf(exitFailure, "%s has no chapters; pass --match to mark spoken moments instead", videoID)
+		}
+		markers = transcript.ChapterMarkers(chapters, float64(md.LengthSeconds))
+	}
+
+	var buf bytes.Buffer
+	if *format == "csv" {
+		err = transcript.WriteMarkerCSV(&buf, markers, *fps)
+	} else {
+		err = transcript.WriteEDL(&buf, md.Title, markers, *fps)
+	}
+	if err != nil {
+		cliLog.fatalf("Error writing markers: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := writeFile(*output, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing markers: %v", err)
+	}
+	cliLog.infof("Wrote %d markers to %s", len(markers), *output)
+}
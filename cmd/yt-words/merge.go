@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runMerge writes the transcripts of many videos as one Markdown document with a header per video
+func runMerge(args []string) {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	input := fs.String("input", "", "file with one URL or video ID per line (- for stdin, the default without video arguments)")
+	output := fs.String("output", "", "write to this file instead of stdout")
+	globalTime := fs.Bool("global-time", false, "also show each entry's position in the whole series, videos placed end to end")
+	bFlags := addBatchFlags(fs)
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	videoIDs := readVideoIDs(*input, positional)
+	if len(videoIDs) == 0 {
+		cliLog.usagef("Usage: %s merge [videos...] [--input ids.txt|-] [--global-time] [--output path] [--concurrency 3] [--lang code | --langs a,b]", getBinaryName())
+	}
+
+	client := newClient(append(netFlags.options(), bFlags.options()...)...)
+	sel := selFlags.selection()
+	tc := netFlags.cache()
+	fetch := func(videoID string) batchResult {
+		r := fetchOne(client, tc, videoID, sel)
+		if r.err == nil && r.metadata == nil {
+			var md transcript.VideoMetadata
+			md, r.err = client.GetVideoMetadata(videoID)
+			r.metadata = &md
+		}
+		return r
+	}
+
+	// A series missing a video would silently shift every later global timestamp
+	var videos []transcript.MergedVideo
+	var failures []error
+	fetchBatch(context.Background(), videoIDs, *bFlags.concurrency, fetch, func(r batchResult) bool {
+		if r.err != nil {
+			failures = append(failures, r.err)
+			cliLog.failure(r.videoID, r.err, "Error fetching transcript for %s: %v", r.videoID, r.err)
+			return bFlags.tolerates(len(failures))
+		}
+		videos = append(videos, transcript.MergedVideo{Metadata: *r.metadata, Entries: r.entries})
+		return true
+	})
+	if len(failures) > 0 {
+		cliLog.exitf(batchExitCode(failures), "%d of %d videos failed; nothing was written", len(failures), len(videoIDs))
+	}
+
+	var buf bytes.Buffer
+	if err := transcript.WriteMergedMarkdown(&buf, videos, *globalTime); err != nil {
+		cliLog.fatalf("Error writing document: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := writeFile(*output, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing document: %v", err)
+	}
+	cliLog.infof("Wrote %s with %d videos", *output, len(videos))
+}
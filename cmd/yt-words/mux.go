@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runMux writes a video's subtitles to a temporary SRT file and prints the ffmpeg command
+// adding them to a local copy of the video, or runs it
+func runMux(args []string) {
+	fs := flag.NewFlagSet("mux", flag.ExitOnError)
+	output := fs.String("output", "", "file ffmpeg writes (default: the input file name with .subs before the extension)")
+	burn := fs.Bool("burn", false, "render the subtitles into the picture instead of adding a subtitle stream")
+	run := fs.Bool("run", false, "run ffmpeg instead of printing the command")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 2 {
+		cliLog.usagef("Usage: %s mux <YouTube URL or Video ID> <local video file> [--burn] [--output path] [--run] [--lang code | --langs a,b]", getBinaryName())
+	}
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+	input := positional[1]
+	if _, err := os.Stat(input); err != nil {
+		cliLog.usagef("Invalid video file: %v", err)
+	}
+	out := *output
+	if out == "" {
+		ext := filepath.Ext(input)
+		out = strings.TrimSuffix(input, ext) + ".subs" + ext
+	}
+
+	r := fetchOne(newClient(netFlags.options()...), netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+
+	var buf bytes.Buffer
+	if err := transcript.WriteFormat(&buf, transcript.FormatSRT, r.entries); err != nil {
+		cliLog.fatalf("Error writing subtitles: %v", err)
+	}
+	f, err := os.CreateTemp("", "yt-words-"+videoID+"-*.srt")
+	if err == nil {
+		_, err = f.Write(buf.Bytes())
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		cliLog.fatalf("Error writing subtitles: %v", err)
+	}
+	cliLog.infof("Wrote %s", f.Name())
+
+	ffmpegArgs := muxArgs(input, f.Name(), out, r.track.LanguageCode, *burn)
+	if !*run {
+		quoted := make([]string, len(ffmpegArgs))
+		for i, a := range ffmpegArgs {
+			quoted[i] = shellQuote(a)
+		}
+		fmt.Println("ffmpeg " + strings.Join(quoted, " "))
+		return
+	}
+
+	cmd := exec.Command("ffmpeg", ffmpegArgs...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+	// The subtitles are in out now, or ffmpeg failed; either way the SRT is of no further use
+	os.Remove(f.Name())
+	if err != nil {
+		cliLog.exitf(exitFailure, "Error running ffmpeg: %v", err)
+	}
+	cliLog.infof("Wrote %s", out)
+}
+
+// muxArgs returns the ffmpeg arguments adding the subtitles in srt to input, as a stream in
+// the codec output's container supports or, with burn, drawn into the picture
+func muxArgs(input, srt, output, lang string, burn bool) []string {
+	input, output = ffmpegPath(input), ffmpegPath(output)
+	if burn {
+		return []string{"-i", input, "-vf", "subtitles=" + filterQuote(srt), "-c:a", "copy", output}
+	}
+	codec := "srt"
+	switch strings.ToLower(filepath.Ext(output)) {
+	case ".mp4", ".m4v", ".mov":
+		codec = "mov_text"
+	case ".webm":
+		codec = "webvtt"
+	}
+	args := []string{"-i", input, "-i", srt, "-map", "0", "-map", "1", "-c", "copy", "-c:s", codec}
+	if lang != "" {
+		args = append(args, "-metadata:s:s:0", "language="+lang)
+	}
+	return append(args, output)
+}
+
+// ffmpegPath keeps ffmpeg from reading a relative path starting with "-" as an option
+func ffmpegPath(path string) string {
+	if strings.HasPrefix(path, "-") {
+		return "./" + path
+	}
+	return path
+}
+
+// filterQuote escapes a path for use as an option value in an ffmpeg filter graph, once for
+// the option and once for the graph
+func filterQuote(path string) string {
+	option := strings.NewReplacer(`\`, `\\`, `'`, `\'`, `:`, `\:`).Replace(path)
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`, `[`, `\[`, `]`, `\]`, `,`, `\,`, `;`, `\;`).Replace(option)
+}
+
+// shellSafe matches arguments a POSIX shell passes through unchanged
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes s for a POSIX shell when it needs it
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeaderFlag_Set(t *testing.T) {
+	tests := []struct {
+		input string
+		name  string
+		value string
+		ok    bool
+	}{
+		{input: "X-Test: yes", name: "X-Test", value: "yes", ok: true},
+		{input: "  Cookie :a=b; c=d:e ", name: "Cookie", value: "a=b; c=d:e", ok: true},
+		{input: "X-Empty:", name: "X-Empty", value: "", ok: true},
+		{input: "no colon"},
+		{input: " : value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			h := make(headerFlag)
+			err := h.Set(tt.input)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Set(%q) error = %v; want ok %v", tt.input, err, tt.ok)
+			}
+			if value, set := h[tt.name]; tt.ok && (!set || value != tt.value) {
+				t.Errorf("Set(%q) gave %v; want %s: %q", tt.input, h, tt.name, tt.value)
+			}
+		})
+	}
+}
+
+func TestBudgetFlag_Set(t *testing.T) {
+	tests := []struct {
+		input string
+		want  budget
+		ok    bool
+	}{
+		{input: "1000/1h", want: budget{max: 1000, per: time.Hour}, ok: true},
+		{input: "5/30s", want: budget{max: 5, per: 30 * time.Second}, ok: true},
+		{input: "1000"},
+		{input: "0/1h"},
+		{input: "-1/1h"},
+		{input: "x/1h"},
+		{input: "10/hour"},
+		{input: "10/0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var b budgetFlag
+			err := b.Set(tt.input)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Set(%q) error = %v; want ok %v", tt.input, err, tt.ok)
+			}
+			if tt.ok && (len(b) != 1 || b[0] != tt.want) {
+				t.Errorf("Set(%q) = %v; want [%v]", tt.input, b, tt.want)
+			}
+		})
+	}
+
+	// Repeated flags combine
+	var b budgetFlag
+	b.Set("10/1m")
+	b.Set("100/1h")
+	if len(b) != 2 || b.String() != "10/1m0s, 100/1h0m0s" {
+		t.Errorf("two budgets = %q; want both", b.String())
+	}
+}
+
+func TestBatchFlags_Tolerates(t *testing.T) {
+	tests := []struct {
+		name        string
+		failFast    bool
+		maxFailures int
+		failures    int
+		want        bool
+	}{
+		{name: "no limit", failures: 100, want: true},
+		{name: "fail fast", failFast: true, failures: 1, want: false},
+		{name: "below max", maxFailures: 3, failures: 2, want: true},
+		{name: "at max", maxFailures: 3, failures: 3, want: false},
+		{name: "fail fast overrides max", failFast: true, maxFailures: 3, failures: 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &batchFlags{failFast: &tt.failFast, maxFailures: &tt.maxFailures}
+			if got := f.tolerates(tt.failures); got != tt.want {
+				t.Errorf("tolerates(%d) = %v; want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runNotes writes Markdown show notes of a video: a section per chapter with its transcript
+func runNotes(args []string) {
+	fs := flag.NewFlagSet("notes", flag.ExitOnError)
+	output := fs.String("output", "", "write to this file instead of stdout")
+	toc := fs.Bool("toc", false, "start with a linked table of contents, from the chapters or else the longest pauses")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s notes <YouTube URL or Video ID> [--output path] [--toc] [--lang code | --langs a,b]", getBinaryName())
+	}
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	client := newClient(netFlags.options()...)
+	r := fetchOne(client, netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+	md, err := client.GetVideoMetadata(videoID)
+	if err != nil {
+		cliLog.failf(videoID, err, "Error fetching metadata: %v", err)
+	}
+
+	var buf bytes.Buffer
+	notes := transcript.ShowNotes{
+		VideoID:  videoID,
+		Title:    md.Title,
+		Chapters: transcript.ParseChapters(md.Description),
+		Entries:  r.entries,
+		TOC:      *toc,
+	}
+	if err := notes.WriteMarkdown(&buf); err != nil {
+		cliLog.fatalf("Error writing notes: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := writeFile(*output, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing notes: %v", err)
+	}
+	cliLog.infof("Wrote %s", *output)
+}
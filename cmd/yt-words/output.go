@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// outputFlags registers the flags controlling how and where transcripts are written
+type outputFlags struct {
+	fs           *flag.FlagSet
+	format       *string
+	output       *string
+	template     *string
+	fields       *string
+	noTimestamps *bool
+	noColor      *bool
+	pipeline     *string
+	sponsorBlock *string
+}
+
+func addOutputFlags(fs *flag.FlagSet) *outputFlags {
+	return &outputFlags{
+		fs:           fs,
+		format:       fs.String("format", "", "output format: text, json, srt, vtt, csv, md, prose or audacity (default: from --output extension, else text)"),
+		output:       fs.String("output", "", "write to this file instead of stdout; placeholders: {id} {title} {lang} {date} {channel} {ext}"),
+		template:     fs.String("template", "", "render with this Go text/template (a file path or the template itself) instead of --format"),
+		fields:       fs.String("fields", "", "comma-separated json/csv columns to keep: text, start, duration, end"),
+		noTimestamps: fs.Bool("no-timestamps", false, "drop timing columns from json/csv output (same as --fields text)"),
+		noColor:      fs.Bool("no-color", false, "disable colored terminal output (also disabled by NO_COLOR)"),
+		sponsorBlock: fs.String("sponsorblock", "", "strip or label the entries of sponsor and self-promotion segments known to SponsorBlock"),
+		pipeline:     fs.String("pipeline", "", "post-processing steps applied in order before writing, e.g. strip,dedup,reflow,mask-profanity"),
+	}
+}
+
+// outputWriter writes fetched transcripts according to the parsed output flags
+type outputWriter struct {
+	client       *transcript.Client
+	format       transcript.Format
+	pathTemplate string
+	tmpl         *template.Template
+	fields       []transcript.Field
+	pipeline     transcript.Pipeline
+	sponsorBlock string
+	colors       palette
+	logf         func(format string, v ...interface{})
+}
+
+func (f *outputFlags) writer(client *transcript.Client) *outputWriter {
+	w := &outputWriter{client: client, format: transcript.FormatText, pathTemplate: *f.output, colors: newPalette(*f.noColor), logf: cliLog.infof}
+	if *f.template != "" {
+		w.tmpl = parseUserTemplate(*f.template)
+	}
+
+	// An explicit --format wins over the --output extension, which wins over the config file
+	name := *f.format
+	if !isSet(f.fs, "format") && w.pathTemplate != "" {
+		if format, ok := formatForExtension(filepath.Ext(w.pathTemplate)); ok {
+			name = string(format)
+		}
+	}
+	if name != "" {
+		format, err := transcript.ParseFormat(name)
+		if err != nil {
+			cliLog.usagef("Invalid --format: %v", err)
+		}
+		w.format = format
+	}
+
+	switch {
+	case *f.fields != "":
+		fields, err := transcript.ParseFields(*f.fields)
+		if err != nil {
+			cliLog.usagef("Invalid --fields: %v", err)
+		}
+		w.fields = fields
+	case *f.noTimestamps && w.format != transcript.FormatText:
+		w.fields = []transcript.Field{transcript.FieldText}
+	}
+	if w.fields != nil && w.format != transcript.FormatJSON && w.format != transcript.FormatCSV {
+		cliLog.usagef("--fields and --no-timestamps require --format json or csv")
+	}
+	w.pipeline = parsePipeline(*f.pipeline)
+	switch *f.sponsorBlock {
+	case "", "strip", "label":
+		w.sponsorBlock = *f.sponsorBlock
+	default:
+		cliLog.usagef("Invalid --sponsorblock %q: want strip or label", *f.sponsorBlock)
+	}
+	return w
+}
+
+// parsePipeline parses a --pipeline flag, exiting with a usage error on unknown steps
+func parsePipeline(list string) transcript.Pipeline {
+	p, err := transcript.ParsePipeline(list)
+	if err != nil {
+		cliLog.usagef("Invalid --pipeline: %v", err)
+	}
+	return p
+}
+
+// formatForExtension maps a file extension such as ".srt" to its output format
+func formatForExtension(ext string) (transcript.Format, bool) {
+	ext = strings.ToLower(strings.TrimPrefix(ext, "."))
+	for _, format := range transcript.Formats {
+		if format.Extension() == ext {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// perVideo reports whether the output template yields a distinct file for each video
+func (w *outputWriter) perVideo() bool {
+	return strings.Contains(w.pathTemplate, "{id}") || strings.Contains(w.pathTemplate, "{title}")
+}
+
+// write prints a transcript to stdout, or to the file named by the output template.
+// It returns the path written, or "" for stdout.
+func (w *outputWriter) write(r batchResult) (string, error) {
+	if w.sponsorBlock != "" {
+		filter, err := w.client.SponsorBlockFilter(r.videoID, w.sponsorBlock == "label")
+		if err != nil {
+			return "", fmt.Errorf("error fetching SponsorBlock segments: %v", err)
+		}
+		r.entries, _ = filter.Process(r.entries)
+	}
+	if w.pipeline != nil {
+		entries, err := w.pipeline.Process(r.entries)
+		if err != nil {
+			return "", err
+		}
+		r.entries = entries
+	}
+	if w.pathTemplate == "" && w.tmpl == nil && w.format == transcript.FormatText {
+		if w.colors.enabled {
+			w.writeHuman(&r)
+			return "", nil
+		}
+		fmt.Printf("Transcript for video %s:\n%s\n", r.videoID, transcript.ConcatenateTranscript(r.entries))
+		return "", nil
+	}
+
+	content, err := w.render(&r)
+	if err != nil {
+		return "", err
+	}
+	if w.pathTemplate == "" {
+		_, err := os.Stdout.Write(content)
+		return "", err
+	}
+
+	path, err := w.path(&r)
+	if err != nil {
+		return "", err
+	}
+	if err := writeFile(path, content); err != nil {
+		return "", err
+	}
+	w.logf("Wrote %s", path)
+	return path, nil
+}
+
+// writeHuman prints a colored, timestamped transcript with a header per chapter
+func (w *outputWriter) writeHuman(r *batchResult) {
+	fmt.Println(w.colors.bold(fmt.Sprintf("Transcript for video %s:", r.videoID)))
+
+	// Chapters come with the fetched watch page; cached transcripts and backend fetches, which
+	// do not scrape it, are printed without headers
+	chapters := r.chapters
+	next := 0
+	for _, e := range r.entries {
+		for next < len(chapters) && chapters[next].Start <= e.Start {
+			fmt.Printf("\n%s\n", w.colors.heading(chapters[next].Title))
+			next++
+		}
+		fmt.Printf("%s %s\n", w.colors.dim(fmt.Sprintf("[%s]", transcript.FormatClock(e.Start))), e.Text)
+	}
+}
+
+// render produces the file contents for a result, through the user template if one is set
+func (w *outputWriter) render(r *batchResult) ([]byte, error) {
+	var buf bytes.Buffer
+	if w.tmpl == nil {
+		err := transcript.WriteFields(&buf, w.format, r.entries, w.fields)
+		return buf.Bytes(), err
+	}
+
+	data := templateData{
+		VideoID:     r.videoID,
+		Language:    r.track.LanguageCode,
+		IsGenerated: r.track.IsGenerated,
+		Entries:     r.entries,
+	}
+	if usesMetadata(w.tmpl) {
+		md, err := w.metadata(r)
+		if err != nil {
+			return nil, err
+		}
+		data.Metadata = md
+	}
+	if err := w.tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("error executing template: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// metadata fetches the video's metadata once per result
+func (w *outputWriter) metadata(r *batchResult) (transcript.VideoMetadata, error) {
+	if r.metadata == nil {
+		md, err := w.client.GetVideoMetadata(r.videoID)
+		if err != nil {
+			return transcript.VideoMetadata{}, fmt.Errorf("error fetching metadata: %v", err)
+		}
+		r.metadata = &md
+	}
+	return *r.metadata, nil
+}
+
+// path expands the output template for a result, fetching video metadata only when needed
+func (w *outputWriter) path(r *batchResult) (string, error) {
+	fields := map[string]string{
+		"id":   r.videoID,
+		"lang": r.track.LanguageCode,
+		"ext":  w.format.Extension(),
+	}
+
+	if strings.Contains(w.pathTemplate, "{title}") || strings.Contains(w.pathTemplate, "{channel}") || strings.Contains(w.pathTemplate, "{date}") {
+		md, err := w.metadata(r)
+		if err != nil {
+			return "", err
+		}
+		fields["title"] = md.Title
+		fields["channel"] = md.Author
+		fields["date"] = md.PublishDate
+	}
+
+	return expandTemplate(w.pathTemplate, fields), nil
+}
+
+// expandTemplate replaces {name} placeholders with filename-safe field values
+func expandTemplate(tmpl string, fields map[string]string) string {
+	var replacements []string
+	for name, value := range fields {
+		replacements = append(replacements, "{"+name+"}", sanitizeFilename(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(tmpl)
+}
+
+// sanitizeFilename makes a value usable as a single path component on common filesystems
+func sanitizeFilename(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, s)
+	s = strings.Trim(s, " .")
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// writeFile writes content to path, creating parent directories. The content goes to a
+// temporary file that is renamed into place, so an interrupted write never leaves a partial file.
+func writeFile(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
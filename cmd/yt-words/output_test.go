@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plain title", "plain title"},
+		{"AC/DC: Live?", "AC_DC_ Live_"},
+		{`a\b*c"d<e>f|g`, "a_b_c_d_e_f_g"},
+		{"tab\there", "tab_here"},
+		{" .hidden. ", "hidden"},
+		{"...", "_"},
+		{"", "_"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeFilename(tt.input); got != tt.expected {
+			t.Errorf("sanitizeFilename(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	fields := map[string]string{"id": "abcdefghijk", "title": "Q&A: part 1/2", "lang": "en"}
+	tests := []struct {
+		tmpl     string
+		expected string
+	}{
+		{"{id}.{lang}.txt", "abcdefghijk.en.txt"},
+		{"out/{title}.srt", "out/Q&A_ part 1_2.srt"},
+		{"{unknown}-{id}", "{unknown}-abcdefghijk"},
+		{"static.txt", "static.txt"},
+	}
+
+	for _, tt := range tests {
+		if got := expandTemplate(tt.tmpl, fields); got != tt.expected {
+			t.Errorf("expandTemplate(%q) = %q; want %q", tt.tmpl, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatForExtension(t *testing.T) {
+	tests := []struct {
+		ext    string
+		format transcript.Format
+		ok     bool
+	}{
+		{".srt", transcript.FormatSRT, true},
+		{"VTT", transcript.FormatVTT, true},
+		{".json", transcript.FormatJSON, true},
+		{".txt", transcript.FormatText, true},
+		{".md", transcript.FormatMD, true},
+		{".docx", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		format, ok := formatForExtension(tt.ext)
+		if format != tt.format || ok != tt.ok {
+			t.Errorf("formatForExtension(%q) = %q, %v; want %q, %v", tt.ext, format, ok, tt.format, tt.ok)
+		}
+	}
+}
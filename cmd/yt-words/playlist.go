@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runPlaylist fetches the transcripts of every video in a playlist, one output per video
+func runPlaylist(args []string) {
+	fs := flag.NewFlagSet("playlist", flag.ExitOnError)
+	limit := fs.Int("limit", 0, "fetch at most this many videos from the start of the playlist (0 means all)")
+	b := addBatchRunFlags(fs)
+	positional := b.parse(fs, args)
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s playlist <playlist URL or ID> [--limit n] [--concurrency 3] [--lang code | --langs a,b] [--format f] [--output template] [--out-dir dir]", getBinaryName())
+	}
+	playlistID := transcript.ExtractPlaylistID(positional[0])
+	if playlistID == "" {
+		cliLog.usagef("Invalid YouTube playlist URL or ID: %s", positional[0])
+	}
+
+	videos, err := b.newClient().GetPlaylistVideos(playlistID)
+	if err != nil {
+		cliLog.failf("", err, "Error listing playlist: %v", err)
+	}
+	if *limit > 0 && len(videos) > *limit {
+		videos = videos[:*limit]
+	}
+	cliLog.verbosef("Playlist %s has %d videos", playlistID, len(videos))
+
+	videoIDs := make([]string, 0, len(videos))
+	for _, v := range videos {
+		videoIDs = append(videoIDs, v.VideoID)
+	}
+
+	// Each video gets its own file unless the user chose a destination
+	if *b.outFlags.output == "" && *b.outDir == "" {
+		*b.outFlags.output = "{id}.{ext}"
+	}
+	b.run(videoIDs)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// progressWidth is the number of cells in the progress bar
+const progressWidth = 30
+
+// progress draws a single-line progress bar on stderr for batch operations.
+// A disabled progress only forwards log lines.
+type progress struct {
+	w       io.Writer
+	enabled bool
+	total   int
+	done    int
+	failed  int
+	start   time.Time
+	last    string
+	drawn   bool
+}
+
+// newProgress returns a progress bar that is only drawn when stdout is a terminal and quiet is unset
+func newProgress(total int, quiet bool) *progress {
+	return &progress{
+		w:       os.Stderr,
+		enabled: !quiet && isTerminal(os.Stdout),
+		total:   total,
+		start:   time.Now(),
+	}
+}
+
+// isTerminal reports whether f is attached to a character device such as a TTY
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// advance records one finished video and redraws the bar
+func (p *progress) advance(videoID string, err error) {
+	p.done++
+	status := "ok"
+	if err != nil {
+		p.failed++
+		status = "failed"
+	}
+	p.last = videoID + " " + status
+	p.draw()
+}
+
+// suspend erases the bar while fn writes to the terminal, then redraws it
+func (p *progress) suspend(fn func()) {
+	p.clear()
+	fn()
+	p.draw()
+}
+
+// wrap returns a logging function that writes through logf without corrupting the bar
+func (p *progress) wrap(logf func(format string, v ...interface{})) func(format string, v ...interface{}) {
+	return func(format string, v ...interface{}) {
+		p.suspend(func() { logf(format, v...) })
+	}
+}
+
+// clear erases the bar so other output can be written to the terminal
+func (p *progress) clear() {
+	if p.drawn {
+		fmt.Fprint(p.w, "\r\033[K")
+		p.drawn = false
+	}
+}
+
+// finish erases the bar once the batch is complete
+func (p *progress) finish() {
+	p.clear()
+}
+
+func (p *progress) draw() {
+	if !p.enabled || p.total == 0 {
+		return
+	}
+
+	filled := p.done * progressWidth / p.total
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressWidth-filled)
+
+	eta := "--"
+	if p.done > 0 && p.done < p.total {
+		remaining := time.Since(p.start) / time.Duration(p.done) * time.Duration(p.total-p.done)
+		eta = remaining.Round(time.Second).String()
+	}
+
+	fmt.Fprintf(p.w, "\r\033[K[%s] %d/%d  %d failed  ETA %s  %s", bar, p.done, p.total, p.failed, eta, p.last)
+	p.drawn = true
+}
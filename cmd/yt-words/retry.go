@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// defaultRetryQueue is the queue retry works through when --retry-queue is not given
+const defaultRetryQueue = "retry-queue.json"
+
+// retryQueue holds the videos batch runs failed to fetch, for a later retry run
+type retryQueue struct {
+	Videos []retryItem `json:"videos"`
+}
+
+// retryItem is a failed video of a retryQueue
+type retryItem struct {
+	VideoID  string `json:"videoId"`
+	Attempts int    `json:"attempts"`
+	// Class is the --error-format json code of the last failure, e.g. rate_limited
+	Class       string    `json:"class"`
+	Retryable   bool      `json:"retryable"`
+	LastError   string    `json:"lastError"`
+	LastAttempt time.Time `json:"lastAttempt"`
+}
+
+// loadRetryQueue reads the queue at path; a missing file is an empty queue
+func loadRetryQueue(path string) (*retryQueue, error) {
+	q := &retryQueue{Videos: []retryItem{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, q); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %v", path, err)
+	}
+	return q, nil
+}
+
+// record adds a failed attempt at videoID; it is a no-op on a nil queue so callers need not check
+func (q *retryQueue) record(videoID string, err error) {
+	if q == nil {
+		return
+	}
+	i := q.find(videoID)
+	if i < 0 {
+		q.Videos = append(q.Videos, retryItem{VideoID: videoID})
+		i = len(q.Videos) - 1
+	}
+	item := &q.Videos[i]
+	item.Attempts++
+	item.Class = errorCodes[exitCode(err)]
+	item.Retryable = isRetryable(err)
+	item.LastError = err.Error()
+	item.LastAttempt = time.Now().UTC()
+}
+
+// remove drops a video that was fetched after all
+func (q *retryQueue) remove(videoID string) {
+	if q == nil {
+		return
+	}
+	if i := q.find(videoID); i >= 0 {
+		q.Videos = append(q.Videos[:i], q.Videos[i+1:]...)
+	}
+}
+
+func (q *retryQueue) find(videoID string) int {
+	for i, item := range q.Videos {
+		if item.VideoID == videoID {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes the queue as indented JSON, in video ID order
+func (q *retryQueue) save(path string) error {
+	sort.Slice(q.Videos, func(i, j int) bool { return q.Videos[i].VideoID < q.Videos[j].VideoID })
+	b, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, append(b, '\n'))
+}
+
+// runRetry fetches the videos of a retry queue again with the batch flags, removing those
+// that succeed and counting another attempt at those that fail
+func runRetry(args []string) {
+	fs := flag.NewFlagSet("retry", flag.ExitOnError)
+	all := fs.Bool("all", false, "also retry failures that are not retryable, such as videos without captions")
+	maxAttempts := fs.Int("max-attempts", 5, "skip videos that already failed this many times (0 means no limit)")
+	list := fs.Bool("list", false, "list the queued videos instead of fetching them")
+	b := addBatchRunFlags(fs)
+	positional := b.parse(fs, args)
+	if len(positional) != 0 {
+		cliLog.usagef("Usage: %s retry [--retry-queue %s] [--all] [--max-attempts 5] [--list] plus the batch flags", getBinaryName(), defaultRetryQueue)
+	}
+	if *b.retryQueue == "" {
+		*b.retryQueue = defaultRetryQueue
+	}
+	q, err := loadRetryQueue(*b.retryQueue)
+	if err != nil {
+		cliLog.fatalf("Error reading retry queue: %v", err)
+	}
+
+	if *list {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "VIDEO\tATTEMPTS\tCLASS\tRETRYABLE\tLAST ATTEMPT\tERROR")
+		for _, item := range q.Videos {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%t\t%s\t%s\n", item.VideoID, item.Attempts, item.Class, item.Retryable, item.LastAttempt.Local().Format(time.DateTime), item.LastError)
+		}
+		tw.Flush()
+		return
+	}
+
+	var videoIDs []string
+	for _, item := range q.Videos {
+		if (item.Retryable || *all) && (*maxAttempts <= 0 || item.Attempts < *maxAttempts) {
+			videoIDs = append(videoIDs, item.VideoID)
+		}
+	}
+	if len(videoIDs) == 0 {
+		cliLog.infof("Nothing to retry: %d videos in %s, none retryable or all past --max-attempts", len(q.Videos), *b.retryQueue)
+		return
+	}
+	cliLog.verbosef("Retrying %d of %d queued videos", len(videoIDs), len(q.Videos))
+	b.run(videoIDs)
+}
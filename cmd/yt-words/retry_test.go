@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestRetryQueue_RecordAndRemove(t *testing.T) {
+	q := &retryQueue{}
+	q.record("bbbbbbbbbbb", transcript.ErrTooManyRequests{VideoID: "bbbbbbbbbbb"})
+	q.record("aaaaaaaaaaa", transcript.ErrNoTranscriptFound{VideoID: "aaaaaaaaaaa"})
+	q.record("bbbbbbbbbbb", errors.New("boom"))
+
+	if len(q.Videos) != 2 {
+		t.Fatalf("queue holds %d videos; want 2", len(q.Videos))
+	}
+	b := q.Videos[q.find("bbbbbbbbbbb")]
+	if b.Attempts != 2 || b.Class != "error" || b.Retryable || b.LastError != "boom" {
+		t.Errorf("item after two failures = %+v; want 2 attempts of the last failure", b)
+	}
+	a := q.Videos[q.find("aaaaaaaaaaa")]
+	if a.Attempts != 1 || a.Class != "no_transcript" || a.Retryable {
+		t.Errorf("item = %+v; want 1 attempt of class no_transcript", a)
+	}
+
+	q.remove("bbbbbbbbbbb")
+	q.remove("ccccccccccc")
+	if len(q.Videos) != 1 || q.find("bbbbbbbbbbb") >= 0 {
+		t.Errorf("queue after remove = %+v; want only aaaaaaaaaaa", q.Videos)
+	}
+
+	// A nil queue ignores failures
+	var none *retryQueue
+	none.record("aaaaaaaaaaa", errors.New("boom"))
+	none.remove("aaaaaaaaaaa")
+}
+
+func TestRetryQueue_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), defaultRetryQueue)
+	q, err := loadRetryQueue(path)
+	if err != nil || len(q.Videos) != 0 {
+		t.Fatalf("loadRetryQueue() of a missing file = %+v, %v; want an empty queue", q, err)
+	}
+
+	q.record("bbbbbbbbbbb", errors.New("boom"))
+	q.record("aaaaaaaaaaa", transcript.ErrTooManyRequests{VideoID: "aaaaaaaaaaa"})
+	if err := q.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+	loaded, err := loadRetryQueue(path)
+	if err != nil {
+		t.Fatalf("loadRetryQueue() error = %v", err)
+	}
+	if len(loaded.Videos) != 2 || loaded.Videos[0].VideoID != "aaaaaaaaaaa" || !loaded.Videos[0].Retryable {
+		t.Errorf("loaded queue = %+v; want both videos in ID order", loaded.Videos)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// selectionFlags registers the language selection flags shared by fetching commands
+type selectionFlags struct {
+	fs            *flag.FlagSet
+	lang          *string
+	langs         *string
+	preferManual  *bool
+	generatedOnly *bool
+}
+
+func addSelectionFlags(fs *flag.FlagSet) *selectionFlags {
+	return &selectionFlags{
+		fs:            fs,
+		lang:          fs.String("lang", "", "language code to fetch (prefix match, e.g. en matches en-GB)"),
+		langs:         fs.String("langs", "", "comma-separated language priority list, e.g. de,en"),
+		preferManual:  fs.Bool("prefer-manual", false, "prefer uploader-provided captions over auto-generated ones"),
+		generatedOnly: fs.Bool("generated-only", false, "only consider auto-generated captions"),
+	}
+}
+
+// selection builds the library selection from the parsed flags
+func (f *selectionFlags) selection() transcript.LanguageSelection {
+	if isSet(f.fs, "lang") && isSet(f.fs, "langs") {
+		cliLog.usagef("--lang and --langs cannot be used together")
+	}
+
+	sel := transcript.LanguageSelection{
+		Languages:     transcript.ParseLanguageList(*f.langs),
+		PreferManual:  *f.preferManual,
+		GeneratedOnly: *f.generatedOnly,
+	}
+	// An explicit --lang overrides languages from the config file
+	if *f.lang != "" {
+		sel.Languages = []string{*f.lang}
+	}
+	return sel
+}
+
+// isSet reports whether the named flag was given on the command line
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
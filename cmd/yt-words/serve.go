@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/server"
+)
+
+// runServe runs the HTTP API server until it fails or receives SIGINT/SIGTERM
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	enableGraphQL := fs.Bool("graphql", false, "serve the GraphQL API at /v1/graphql")
+	cacheTTL := fs.Duration("cache-ttl", time.Hour, "how long rendered transcripts are cached (0 disables caching)")
+	cacheSize := fs.Int("cache-size", 1000, "maximum number of cached transcripts")
+	rate := fs.Float64("rate", 1, "requests per second allowed per client (0 disables rate limiting)")
+	burst := fs.Int("burst", 10, "request burst allowed per client")
+	apiKeysFile := fs.String("api-keys-file", "", "file with one API key per line; callers presenting one are rate limited by key instead of IP")
+	jobsDB := fs.String("jobs-db", "", "serve the batch job API, persisting jobs in this SQLite file")
+	workers := fs.Int("workers", 4, "number of job workers when -jobs-db is set")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 30*time.Second, "grace period for in-flight requests on SIGTERM")
+	drainDelay := fs.Duration("drain-delay", 5*time.Second, "how long /readyz fails before the listener closes on SIGTERM, so load balancers stop routing to it")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+	if len(positional) != 0 {
+		cliLog.usagef("Usage: %s serve [--addr :8080] [--graphql] [--rate 1 --burst 10] [--api-keys-file file] [--jobs-db file [--workers 4]] plus the network flags", getBinaryName())
+	}
+
+	var options []server.Option
+	if *enableGraphQL {
+		options = append(options, server.WithGraphQL())
+	}
+	if *cacheTTL > 0 {
+		options = append(options, server.WithCache(*cacheTTL, *cacheSize))
+	}
+	if *rate > 0 {
+		options = append(options, server.WithRateLimit(*rate, *burst))
+	}
+	if *apiKeysFile != "" {
+		keys, err := readInputLines(*apiKeysFile)
+		if err != nil {
+			cliLog.usagef("Error reading API keys: %v", err)
+		}
+		options = append(options, server.WithAPIKeys(keys...))
+	}
+
+	// Upstream requests get the timeout, retries and budget of the network flags, so a hung
+	// fetch cannot pin a handler forever
+	client := newClient(netFlags.options()...)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workersDone := make(chan struct{})
+	if *jobsDB != "" {
+		queue := openQueue(*jobsDB)
+		defer queue.Close()
+		options = append(options, server.WithJobs(queue))
+		go func() {
+			defer close(workersDone)
+			queue.Run(ctx, client, *workers)
+		}()
+	} else {
+		close(workersDone)
+	}
+
+	srv := server.New(client, options...)
+	httpServer := &http.Server{Addr: *addr, Handler: srv}
+
+	errc := make(chan error, 1)
+	go func() {
+		cliLog.infof("HTTP server listening on %s", *addr)
+		errc <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		cliLog.fatalf("Error serving HTTP: %v", err)
+	case <-ctx.Done():
+	}
+
+	cliLog.infof("Shutting down, waiting up to %s for in-flight requests", *shutdownTimeout)
+	srv.Drain()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	// Keep accepting requests while the failing /readyz takes the server out of rotation
+	select {
+	case <-time.After(*drainDelay):
+	case <-shutdownCtx.Done():
+	}
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		cliLog.fatalf("Error shutting down: %v", err)
+	}
+	<-workersDone
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"net"
+
+	ytgrpc "github.com/mjlefevre/yt-words-go/grpc"
+	"github.com/mjlefevre/yt-words-go/grpc/transcriptpb"
+	"google.golang.org/grpc"
+)
+
+// runServeGRPC runs the TranscriptService gRPC server until it fails
+func runServeGRPC(args []string) {
+	fs := flag.NewFlagSet("serve-grpc", flag.ExitOnError)
+	addr := fs.String("addr", ":50051", "address to listen on")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+	if len(positional) != 0 {
+		cliLog.usagef("Usage: %s serve-grpc [--addr :50051] plus the network flags", getBinaryName())
+	}
+	client := newClient(netFlags.options()...)
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		cliLog.fatalf("Error listening on %s: %v", *addr, err)
+	}
+
+	server := grpc.NewServer()
+	transcriptpb.RegisterTranscriptServiceServer(server, ytgrpc.NewServer(client))
+
+	cliLog.infof("gRPC server listening on %s", lis.Addr())
+	if err := server.Serve(lis); err != nil {
+		cliLog.fatalf("Error serving gRPC: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runStats prints word counts, pacing and pauses of a video's transcript
+func runStats(args []string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print statistics as JSON instead of a table")
+	pace := fs.Bool("pace", false, "print the words per minute of every --window as CSV (or JSON with --json) instead")
+	window := fs.Duration("window", time.Minute, "length of the windows of --pace")
+	gaps := fs.Duration("gaps", 0, "list the silences between captions at least this long, e.g. 10s, instead")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s stats <YouTube URL or Video ID> [--json] [--pace [--window 1m] | --gaps 10s] [--lang code | --langs a,b]", getBinaryName())
+	}
+
+	input := positional[0]
+	videoID, err := transcript.ExtractVideoID(input)
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	r := fetchOne(newClient(netFlags.options()...), netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+	if *pace {
+		points := transcript.SpeakingRate(r.entries, window.Seconds())
+		if *asJSON {
+			printJSON(points)
+			return
+		}
+		if err := transcript.WritePaceCSV(os.Stdout, points); err != nil {
+			cliLog.fatalf("Error writing speaking rate: %v", err)
+		}
+		return
+	}
+	if *gaps > 0 {
+		found := transcript.FindGaps(r.entries, gaps.Seconds())
+		if *asJSON {
+			printJSON(found)
+			return
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "Start\tEnd\tDuration\n")
+		for _, g := range found {
+			fmt.Fprintf(tw, "%s\t%s\t%.1fs\n", transcript.FormatClock(g.Start), transcript.FormatClock(g.End), g.Duration)
+		}
+		tw.Flush()
+		return
+	}
+	stats := transcript.ComputeStats(r.entries)
+
+	if *asJSON {
+		printJSON(struct {
+			VideoID  string `json:"videoId"`
+			Language string `json:"language"`
+			transcript.Stats
+		}{videoID, r.track.LanguageCode, stats})
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Video\t%s (%s)\n", videoID, r.track.LanguageCode)
+	fmt.Fprintf(tw, "Duration\t%s\n", transcript.FormatClock(stats.Duration))
+	fmt.Fprintf(tw, "Entries\t%d\n", stats.Entries)
+	fmt.Fprintf(tw, "Words\t%d\n", stats.Words)
+	fmt.Fprintf(tw, "Unique words\t%d\n", stats.UniqueWords)
+	fmt.Fprintf(tw, "Words per minute\t%.1f\n", stats.WordsPerMinute)
+	fmt.Fprintf(tw, "Longest silence\t%.1fs at %s\n", stats.LongestSilence.Duration, transcript.FormatClock(stats.LongestSilence.Start))
+	for i, kw := range stats.TopKeywords {
+		label := ""
+		if i == 0 {
+			label = "Top keywords"
+		}
+		fmt.Fprintf(tw, "%s\t%s (%d)\n", label, kw.Word, kw.Count)
+	}
+	tw.Flush()
+}
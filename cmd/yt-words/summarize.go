@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mjlefevre/yt-words-go/summarize"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// Environment variables configuring the summarize endpoint; the names follow the OpenAI SDKs,
+// which most compatible servers (Ollama, vLLM, LM Studio, OpenRouter) also document
+const (
+	envAPIBase = "OPENAI_BASE_URL"
+	envAPIKey  = "OPENAI_API_KEY"
+	envModel   = "OPENAI_MODEL"
+)
+
+// runSummarize prints an LLM-written summary of a video's transcript with timestamped references
+func runSummarize(args []string) {
+	fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+	apiBase := fs.String("api-base", envOr(envAPIBase, "https://api.openai.com/v1"), "base URL of an OpenAI-compatible API (env "+envAPIBase+")")
+	model := fs.String("model", os.Getenv(envModel), "chat model to use (env "+envModel+")")
+	chunkChars := fs.Int("chunk-chars", summarize.DefaultChunkChars, "maximum transcript characters sent per request")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s summarize <YouTube URL or Video ID> --model name [--api-base URL] [--lang code | --langs a,b]", getBinaryName())
+	}
+	if *model == "" {
+		cliLog.usagef("A model is required: pass --model or set %s", envModel)
+	}
+
+	input := positional[0]
+	videoID, err := transcript.ExtractVideoID(input)
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+
+	r := fetchOne(newClient(netFlags.options()...), netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+
+	summarizer := summarize.New(*apiBase, *model,
+		summarize.WithAPIKey(os.Getenv(envAPIKey)),
+		summarize.WithChunkChars(*chunkChars))
+	summary, err := summarizer.Summarize(context.Background(), r.entries)
+	if err != nil {
+		cliLog.exitf(exitFailure, "Error summarizing transcript: %v", err)
+	}
+	fmt.Println(summary)
+}
+
+// envOr returns the value of the environment variable name, or fallback when it is unset or empty
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// openAIBatchFlags registers the flags turning a multi-video command into an OpenAI Batch API
+// input file, one summarize request per transcript chunk
+type openAIBatchFlags struct {
+	path         *string
+	model        *string
+	systemPrompt *string
+	chunkChars   *int
+}
+
+func addOpenAIBatchFlags(fs *flag.FlagSet) *openAIBatchFlags {
+	return &openAIBatchFlags{
+		path:         fs.String("openai-batch", "", "instead of writing transcripts, write OpenAI Batch API requests summarizing them to this JSONL file"),
+		model:        fs.String("model", os.Getenv(envModel), "chat model of --openai-batch requests (env "+envModel+")"),
+		systemPrompt: fs.String("system-prompt", "", "system prompt of --openai-batch requests (default: summarize accurately, citing timestamps)"),
+		chunkChars:   fs.Int("chunk-chars", summarize.DefaultChunkChars, "maximum transcript characters per --openai-batch request"),
+	}
+}
+
+// openAIBatch is an open --openai-batch file
+type openAIBatch struct {
+	path       string
+	f          *os.File
+	summarizer *summarize.Client
+}
+
+// open creates the --openai-batch file, returning nil when the flag is unset
+func (f *openAIBatchFlags) open() *openAIBatch {
+	if *f.path == "" {
+		return nil
+	}
+	if *f.model == "" {
+		cliLog.usagef("--openai-batch needs a model: pass --model or set %s", envModel)
+	}
+	file, err := os.Create(*f.path)
+	if err != nil {
+		cliLog.fatalf("Error creating batch file: %v", err)
+	}
+	return &openAIBatch{
+		path: *f.path,
+		f:    file,
+		summarizer: summarize.New("", *f.model,
+			summarize.WithSystemPrompt(*f.systemPrompt),
+			summarize.WithChunkChars(*f.chunkChars)),
+	}
+}
+
+// write appends the requests of a fetched transcript, returning the file's path
+func (b *openAIBatch) write(r batchResult) (string, error) {
+	if err := summarize.WriteBatch(b.f, b.summarizer.BatchRequests(r.videoID, r.entries)); err != nil {
+		return "", fmt.Errorf("error writing batch file: %v", err)
+	}
+	return b.path, nil
+}
+
+// close closes the file and reports it; closing a nil batch does nothing
+func (b *openAIBatch) close() {
+	if b == nil {
+		return
+	}
+	if err := b.f.Close(); err != nil {
+		cliLog.fatalf("Error writing batch file: %v", err)
+	}
+	cliLog.infof("Wrote %s", b.path)
+}
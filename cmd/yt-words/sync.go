@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runSync stores the transcripts of a channel's uploads missing from a store, and with
+// --recheck replaces those whose captions changed since they were stored
+func runSync(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	channel := fs.String("channel", "", "channel to sync: @handle, UC... ID or channel URL")
+	storeDir := fs.String("store", "transcripts", "directory transcripts are stored in")
+	limit := fs.Int("limit", 0, "only consider this many of the most recent uploads (0 means all)")
+	recheck := fs.Bool("recheck", false, "also re-fetch stored videos and replace those whose captions changed")
+	bFlags := addBatchFlags(fs)
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if *channel == "" || len(positional) != 0 {
+		cliLog.usagef("Usage: %s sync --channel @handle [--store dir] [--limit n] [--recheck] [--concurrency 3] [--lang code | --langs a,b]", getBinaryName())
+	}
+	st, err := store.Open(*storeDir)
+	if err != nil {
+		cliLog.fatalf("Error opening store: %v", err)
+	}
+
+	client := newClient(append(netFlags.options(), bFlags.options()...)...)
+	channelID, err := client.ResolveChannelID(*channel)
+	if err != nil {
+		cliLog.exitf(exitUsage, "Error resolving channel: %v", err)
+	}
+	uploads, err := client.GetPlaylistVideos(transcript.UploadsPlaylistID(channelID))
+	if err != nil {
+		cliLog.failf("", err, "Error listing uploads of %s: %v", channelID, err)
+	}
+	if *limit > 0 && len(uploads) > *limit {
+		uploads = uploads[:*limit]
+	}
+
+	titles := make(map[string]string, len(uploads))
+	var videoIDs []string
+	for _, v := range uploads {
+		if !*recheck && st.Has(v.VideoID) {
+			continue
+		}
+		titles[v.VideoID] = v.Title
+		videoIDs = append(videoIDs, v.VideoID)
+	}
+	cliLog.verbosef("%d of %d uploads of %s to fetch", len(videoIDs), len(uploads), channelID)
+
+	// The cache would hide changed captions, so every transcript is fetched afresh
+	sel := selFlags.selection()
+	fetch := func(videoID string) batchResult { return fetchOne(client, nil, videoID, sel) }
+	var added, changed, unchanged int
+	var failures []error
+	fetchBatch(context.Background(), videoIDs, *bFlags.concurrency, fetch, func(r batchResult) bool {
+		if r.err != nil {
+			failures = append(failures, r.err)
+			cliLog.failure(r.videoID, r.err, "Skipping %s for now: %v", r.videoID, r.err)
+			return bFlags.tolerates(len(failures))
+		}
+		rec := store.Record{
+			VideoID:      r.videoID,
+			Title:        titles[r.videoID],
+			ChannelID:    channelID,
+			LanguageCode: r.track.LanguageCode,
+			IsGenerated:  r.track.IsGenerated,
+			FetchedAt:    time.Now().UTC(),
+			Fingerprint:  transcript.Fingerprint(r.entries),
+			Entries:      r.entries,
+		}
+		old, err := st.Get(r.videoID)
+		stored := err == nil
+		if stored {
+			if old.Fingerprint == rec.Fingerprint || (old.Fingerprint == "" && transcript.Fingerprint(old.Entries) == rec.Fingerprint) {
+				unchanged++
+				return true
+			}
+			rec.Author, rec.Published = old.Author, old.Published
+		}
+		if err := st.Put(rec); err != nil {
+			cliLog.fatalf("Error storing %s: %v", r.videoID, err)
+		}
+		if stored {
+			changed++
+			cliLog.infof("Updated %s (%s): its captions changed", r.videoID, rec.Title)
+		} else {
+			added++
+			cliLog.verbosef("Stored %s (%s), %d entries", r.videoID, rec.Title, len(rec.Entries))
+		}
+		return true
+	})
+
+	cliLog.infof("Synced %s: %d new, %d changed, %d unchanged, %d failed", channelID, added, changed, unchanged, len(failures))
+	if len(failures) > 0 {
+		cliLog.exitf(batchExitCode(failures), "%d of %d videos failed; they will be fetched again by the next sync", len(failures), len(videoIDs))
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// templateData is the value passed to --template templates
+type templateData struct {
+	VideoID     string
+	Language    string
+	IsGenerated bool
+	Metadata    transcript.VideoMetadata
+	Entries     []transcript.TranscriptEntry
+}
+
+// templateFuncs are available to --template templates in addition to the text/template builtins
+var templateFuncs = template.FuncMap{
+	// timestamp renders seconds as HH:MM:SS.mmm
+	"timestamp": func(seconds float64) string {
+		return transcript.FormatTimestamp(seconds, '.')
+	},
+	// endTime returns the time an entry stops being displayed
+	"endTime": func(e transcript.TranscriptEntry) float64 {
+		return e.Start + e.Duration
+	},
+	"add":   func(a, b int) int { return a + b },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+	"text":  transcript.ConcatenateTranscript,
+}
+
+// parseUserTemplate parses value as a template file if such a file exists, else as an inline template
+func parseUserTemplate(value string) *template.Template {
+	source, name := value, "template"
+	if info, err := os.Stat(value); err == nil && info.Mode().IsRegular() {
+		b, err := os.ReadFile(value)
+		if err != nil {
+			cliLog.usagef("Error reading template: %v", err)
+		}
+		source, name = string(b), value
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(source)
+	if err != nil {
+		cliLog.usagef("Invalid --template: %v", err)
+	}
+	return tmpl
+}
+
+// usesMetadata reports whether a template refers to .Metadata, which costs an extra request
+func usesMetadata(tmpl *template.Template) bool {
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && strings.Contains(t.Tree.Root.String(), ".Metadata") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+	"text/template"
+)
+
+func TestUsesMetadata(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected bool
+	}{
+		{"{{range .Entries}}{{.Text}}\n{{end}}", false},
+		{"# {{.Metadata.Title}}", true},
+		{"{{with .Metadata}}{{.Author}}{{end}}", true},
+		{`{{define "head"}}{{.Metadata.Title}}{{end}}{{template "head" .}}`, true},
+		{"Metadata", false},
+	}
+
+	for _, tt := range tests {
+		tmpl := template.Must(template.New("test").Funcs(templateFuncs).Parse(tt.source))
+		if got := usesMetadata(tmpl); got != tt.expected {
+			t.Errorf("usesMetadata(%q) = %v; want %v", tt.source, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+	"github.com/mjlefevre/yt-words-go/translate"
+)
+
+// translateFlags registers the flags for translating fetched transcripts with an external service
+type translateFlags struct {
+	with *string
+	to   *string
+
+	translator transcript.Translator
+}
+
+func addTranslateFlags(fs *flag.FlagSet) *translateFlags {
+	return &translateFlags{
+		with: fs.String("translate-with", "", "translate with this service: "+strings.Join(translate.Providers, ", ")+" (keys are read from the environment)"),
+		to:   fs.String("translate-to", "", "target language code for --translate-with, e.g. de"),
+	}
+}
+
+// setup validates the flags and creates the translator; call it after parsing
+func (f *translateFlags) setup() {
+	if *f.with == "" {
+		if *f.to != "" {
+			cliLog.usagef("--translate-to requires --translate-with")
+		}
+		return
+	}
+	if *f.to == "" {
+		cliLog.usagef("--translate-with requires --translate-to")
+	}
+	tr, err := translate.FromEnv(*f.with)
+	if err != nil {
+		cliLog.usagef("Invalid --translate-with: %v", err)
+	}
+	f.translator = tr
+}
+
+// apply translates a successfully fetched result, keeping entry timing; without a translator it is a no-op
+func (f *translateFlags) apply(r batchResult) batchResult {
+	if f.translator == nil || r.err != nil {
+		return r
+	}
+	r.entries, r.err = transcript.TranslateEntries(f.translator, r.entries, r.track.LanguageCode, *f.to)
+	if r.err == nil {
+		r.track.LanguageCode = *f.to
+	}
+	return r
+}
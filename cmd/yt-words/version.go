@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time with -ldflags "-X main.version=v1.2.3 -X main.buildDate=2024-01-02T03:04:05Z";
+// otherwise they are derived from the build info embedded by the Go toolchain
+var (
+	version   string
+	buildDate string
+)
+
+// versionString describes the module version, VCS revision and build date of the binary
+func versionString() string {
+	v, revision, date, modified := version, "", buildDate, false
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" {
+			v = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+			case "vcs.time":
+				if date == "" {
+					date = s.Value
+				}
+			case "vcs.modified":
+				modified = s.Value == "true"
+			}
+		}
+	}
+
+	if v == "" {
+		v = "(devel)"
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	if revision == "" {
+		revision = "unknown"
+	}
+	if date == "" {
+		date = "unknown"
+	}
+
+	return fmt.Sprintf("%s %s (revision %s, built %s, %s %s/%s)",
+		getBinaryName(), v, revision, date, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runWatch polls a channel's feed and ingests transcripts of videos missing from the store
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	channel := fs.String("channel", "", "channel to watch: @handle, UC... ID or channel URL")
+	storeDir := fs.String("store", "transcripts", "directory transcripts are stored in")
+	interval := fs.Duration("interval", 15*time.Minute, "time between feed polls")
+	webhook := fs.String("webhook", "", "URL to POST each newly stored transcript to, as JSON")
+	lang := fs.String("lang", "", "language code to fetch (default: English, then first available)")
+	since := fs.String("since", "", "ignore videos published before this date (YYYY-MM-DD)")
+	once := fs.Bool("once", false, "poll a single time and exit")
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if *channel == "" || len(positional) != 0 {
+		cliLog.usagef("Usage: %s watch --channel @handle [--store dir] [--interval 15m] [--webhook URL]", getBinaryName())
+	}
+
+	var notBefore time.Time
+	if *since != "" {
+		t, err := time.Parse("2006-01-02", *since)
+		if err != nil {
+			cliLog.usagef("Invalid --since date %q: %v", *since, err)
+		}
+		notBefore = t
+	}
+
+	st, err := store.Open(*storeDir)
+	if err != nil {
+		cliLog.fatalf("Error opening store: %v", err)
+	}
+
+	// Feed and caption requests get the timeout and retries of the network flags, so a hung
+	// fetch cannot stall the watcher forever
+	client := newClient(netFlags.options()...)
+	channelID, err := client.ResolveChannelID(*channel)
+	if err != nil {
+		cliLog.exitf(exitUsage, "Error resolving channel: %v", err)
+	}
+
+	w := &watcher{
+		client:    client,
+		store:     st,
+		channelID: channelID,
+		lang:      *lang,
+		notBefore: notBefore,
+		webhook:   *webhook,
+		http:      &http.Client{Timeout: 30 * time.Second},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cliLog.infof("Watching channel %s every %s, storing transcripts in %s", channelID, *interval, *storeDir)
+	for {
+		w.poll(ctx)
+		if *once {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*interval):
+		}
+	}
+}
+
+type watcher struct {
+	client    *transcript.Client
+	store     *store.Store
+	channelID string
+	lang      string
+	notBefore time.Time
+	webhook   string
+	http      *http.Client
+}
+
+// poll ingests every feed video not yet in the store. Failures are logged and retried on the
+// next poll, since captions of freshly published videos often appear only after a while.
+func (w *watcher) poll(ctx context.Context) {
+	videos, err := w.client.GetChannelFeed(w.channelID)
+	if err != nil {
+		cliLog.errorf("Error fetching channel feed: %v", err)
+		return
+	}
+
+	// The feed lists newest first; ingest in publication order
+	for i := len(videos) - 1; i >= 0 && ctx.Err() == nil; i-- {
+		v := videos[i]
+		if w.store.Has(v.VideoID) || (!w.notBefore.IsZero() && v.Published.Before(w.notBefore)) {
+			continue
+		}
+
+		rec, err := w.fetch(v)
+		if err != nil {
+			cliLog.failure(v.VideoID, err, "Skipping %s (%s) for now: %v", v.VideoID, v.Title, err)
+			continue
+		}
+		if err := w.store.Put(rec); err != nil {
+			cliLog.errorf("Error storing %s: %v", v.VideoID, err)
+			continue
+		}
+		cliLog.infof("Stored transcript of %s (%s), %d entries", v.VideoID, v.Title, len(rec.Entries))
+
+		if w.webhook != "" {
+			if err := w.notify(ctx, rec); err != nil {
+				cliLog.errorf("Error notifying webhook for %s: %v", v.VideoID, err)
+			}
+		}
+	}
+}
+
+func (w *watcher) fetch(v transcript.FeedVideo) (store.Record, error) {
+	t, err := w.client.FindTranscript(v.VideoID, w.lang)
+	if err != nil {
+		return store.Record{}, err
+	}
+	entries, err := w.client.FetchTranscript(t)
+	if err != nil {
+		return store.Record{}, err
+	}
+
+	return store.Record{
+		VideoID:      v.VideoID,
+		Title:        v.Title,
+		ChannelID:    v.ChannelID,
+		Author:       v.Author,
+		LanguageCode: t.LanguageCode,
+		IsGenerated:  t.IsGenerated,
+		Published:    v.Published,
+		FetchedAt:    time.Now().UTC(),
+		Entries:      entries,
+	}, nil
+}
+
+// notify POSTs the record as JSON to the webhook URL
+func (w *watcher) notify(ctx context.Context, rec store.Record) error {
+	body, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
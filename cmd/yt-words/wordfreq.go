@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// runWordFreq prints how often each word of a video's transcript is said, for word clouds
+func runWordFreq(args []string) {
+	fs := flag.NewFlagSet("wordfreq", flag.ExitOnError)
+	format := fs.String("format", "csv", "output format: csv (term,count) or json")
+	output := fs.String("output", "", "write to this file instead of stdout")
+	minCount := fs.Int("min-count", 1, "leave out words said fewer times")
+	limit := fs.Int("limit", 0, "keep only this many of the most frequent words (0 keeps all)")
+	selFlags := addSelectionFlags(fs)
+	netFlags := addNetworkFlags(fs)
+	logFlags := addLogFlags(fs)
+	cfg.applyDefaults(fs)
+	positional := parseInterspersed(fs, args)
+	logFlags.apply()
+
+	if len(positional) != 1 {
+		cliLog.usagef("Usage: %s wordfreq <YouTube URL or Video ID> [--format csv|json] [--min-count n] [--limit n] [--output path]", getBinaryName())
+	}
+	outFormat, err := transcript.ParseFormat(*format)
+	if err != nil || (outFormat != transcript.FormatCSV && outFormat != transcript.FormatJSON) {
+		cliLog.usagef("Invalid --format %q: want csv or json", *format)
+	}
+
+	videoID, err := transcript.ExtractVideoID(positional[0])
+	if err != nil {
+		cliLog.usagef("%v", err)
+	}
+	r := fetchOne(newClient(netFlags.options()...), netFlags.cache(), videoID, selFlags.selection())
+	if r.err != nil {
+		cliLog.failf(videoID, r.err, "Error fetching transcript: %v", r.err)
+	}
+
+	counts := transcript.WordFrequencies(r.entries)
+	for i, c := range counts {
+		if c.Count < *minCount {
+			counts = counts[:i]
+			break
+		}
+	}
+	if *limit > 0 && len(counts) > *limit {
+		counts = counts[:*limit]
+	}
+
+	var buf bytes.Buffer
+	if err := transcript.WriteWordFrequencies(&buf, outFormat, counts); err != nil {
+		cliLog.fatalf("Error writing word frequencies: %v", err)
+	}
+	if *output == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := writeFile(*output, buf.Bytes()); err != nil {
+		cliLog.fatalf("Error writing word frequencies: %v", err)
+	}
+	cliLog.infof("Wrote %s", *output)
+}
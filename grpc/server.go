@@ -0,0 +1,195 @@
+// Package grpc implements the TranscriptService gRPC API on top of the transcript client.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/grpc/transcriptpb"
+	"github.com/mjlefevre/yt-words-go/server"
+	"github.com/mjlefevre/yt-words-go/transcript"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// DefaultBatchConcurrency is the number of videos fetched in parallel by BatchGetTranscripts
+const DefaultBatchConcurrency = 4
+
+// Server implements transcriptpb.TranscriptServiceServer
+type Server struct {
+	transcriptpb.UnimplementedTranscriptServiceServer
+
+	client      *transcript.Client
+	concurrency int
+}
+
+// NewServer creates a TranscriptService backed by client
+func NewServer(client *transcript.Client) *Server {
+	return &Server{client: client, concurrency: DefaultBatchConcurrency}
+}
+
+// GetTranscript fetches the transcript of a single video
+func (s *Server) GetTranscript(ctx context.Context, req *transcriptpb.GetTranscriptRequest) (*transcriptpb.GetTranscriptResponse, error) {
+	videoID, err := transcript.ExtractVideoID(req.GetVideoId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid YouTube URL or video ID: %q", req.GetVideoId())
+	}
+
+	entries, err := s.fetch(ctx, videoID, req.GetLanguage())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &transcriptpb.GetTranscriptResponse{VideoId: videoID, Entries: toEntries(entries)}, nil
+}
+
+// ListLanguages returns the caption tracks available for a video
+func (s *Server) ListLanguages(ctx context.Context, req *transcriptpb.ListLanguagesRequest) (*transcriptpb.ListLanguagesResponse, error) {
+	videoID, err := transcript.ExtractVideoID(req.GetVideoId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid YouTube URL or video ID: %q", req.GetVideoId())
+	}
+
+	transcripts, err := s.client.ListAvailableTranscriptsContext(ctx, videoID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &transcriptpb.ListLanguagesResponse{VideoId: videoID}
+	for _, t := range transcripts {
+		resp.Tracks = append(resp.Tracks, &transcriptpb.Track{
+			LanguageCode: t.LanguageCode,
+			Language:     t.Language,
+			IsGenerated:  t.IsGenerated,
+		})
+	}
+	return resp, nil
+}
+
+// BatchGetTranscripts fetches several videos concurrently and streams each result as soon as it is ready.
+// Per-video failures are reported in the response's error field rather than aborting the stream.
+func (s *Server) BatchGetTranscripts(req *transcriptpb.BatchGetTranscriptsRequest, stream transcriptpb.TranscriptService_BatchGetTranscriptsServer) error {
+	if n := len(req.GetVideoIds()); n > server.MaxRequestVideos {
+		return status.Errorf(codes.InvalidArgument, "at most %d video ids per request, got %d", server.MaxRequestVideos, n)
+	}
+
+	ctx := stream.Context()
+	results := make(chan *transcriptpb.BatchGetTranscriptsResponse)
+	sem := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+
+	go func() {
+		defer close(results)
+		for _, input := range req.GetVideoIds() {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			}
+
+			wg.Add(1)
+			go func(input string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				resp := &transcriptpb.BatchGetTranscriptsResponse{VideoId: input}
+				if videoID, err := transcript.ExtractVideoID(input); err != nil {
+					resp.Error = "invalid YouTube URL or video ID"
+				} else if entries, err := s.fetch(ctx, videoID, req.GetLanguage()); err != nil {
+					resp.VideoId = videoID
+					resp.Error = err.Error()
+				} else {
+					resp.VideoId = videoID
+					resp.Entries = toEntries(entries)
+				}
+
+				select {
+				case results <- resp:
+				case <-ctx.Done():
+				}
+			}(input)
+		}
+		wg.Wait()
+	}()
+
+	for resp := range results {
+		if err := stream.Send(resp); err != nil {
+			// Drain so that worker goroutines can exit
+			for range results {
+			}
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// fetch fetches a transcript with the upstream requests bound to the RPC context, so they
+// stop when the call is cancelled or its deadline passes
+func (s *Server) fetch(ctx context.Context, videoID, language string) ([]transcript.TranscriptEntry, error) {
+	var opts transcript.FetchOptions
+	if language != "" {
+		opts.Languages = []string{language}
+	}
+	result, err := s.client.GetTranscriptWithOptions(ctx, videoID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result.Entries, nil
+}
+
+func toEntries(entries []transcript.TranscriptEntry) []*transcriptpb.Entry {
+	out := make([]*transcriptpb.Entry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, &transcriptpb.Entry{Text: e.Text, Start: e.Start, Duration: e.Duration})
+	}
+	return out
+}
+
+// toStatus maps the transcript package's typed errors onto gRPC status codes
+func toStatus(err error) error {
+	var (
+		unavailable *transcript.ErrVideoUnavailable
+		disabled    *transcript.ErrTranscriptsDisabled
+		circuitOpen transcript.ErrCircuitOpen
+		overBudget  transcript.ErrBudgetExceeded
+	)
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.As(err, new(transcript.ErrInvalidVideoID)):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, new(transcript.ErrTooManyRequests)):
+		return retryStatus(err, upstreamRetryAfter)
+	case errors.As(err, &circuitOpen):
+		return retryStatus(err, max(time.Until(circuitOpen.Until), time.Second))
+	case errors.As(err, &overBudget):
+		return retryStatus(err, max(time.Until(overBudget.ResetAt), time.Second))
+	case errors.As(err, &unavailable):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, new(transcript.ErrNoTranscriptFound)), errors.As(err, new(*transcript.ErrNoTranscriptFound)), errors.As(err, new(transcript.ErrEmptyTranscript)):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &disabled), errors.As(err, new(transcript.ErrTranscriptsDisabled)):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.As(err, new(transcript.ErrMembersOnly)), errors.As(err, new(transcript.ErrRegionBlocked)):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// upstreamRetryAfter is the retry delay given for a 429 from YouTube, which tells us no wait
+const upstreamRetryAfter = time.Minute
+
+// retryStatus is a ResourceExhausted status for err telling the client when to retry
+func retryStatus(err error, after time.Duration) error {
+	st := status.New(codes.ResourceExhausted, err.Error())
+	if detailed, derr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(after)}); derr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,212 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/grpc/transcriptpb"
+	"github.com/mjlefevre/yt-words-go/server"
+	"github.com/mjlefevre/yt-words-go/transcript"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+const watchPage = `{"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?lang=en","name":{"simpleText":"English"},"languageCode":"en"}]}}}`
+
+const captionXML = `<transcript><text start="1" dur="2">hi there</text></transcript>`
+
+// fakeTransport serves a single English track for every video except "unavailabl"
+func fakeTransport(r *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, watchPage
+	switch {
+	case r.URL.Path == "/api/timedtext":
+		body = captionXML
+	case r.URL.Query().Get("v") == "unavailabl1":
+		status, body = http.StatusNotFound, ""
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func startServer(t *testing.T) transcriptpb.TranscriptServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	client := transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport)))
+	transcriptpb.RegisterTranscriptServiceServer(server, NewServer(client))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return transcriptpb.NewTranscriptServiceClient(conn)
+}
+
+func TestGetTranscript(t *testing.T) {
+	client := startServer(t)
+
+	resp, err := client.GetTranscript(context.Background(), &transcriptpb.GetTranscriptRequest{
+		VideoId: "https://youtu.be/abcdefghijk",
+	})
+	if err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+	if resp.GetVideoId() != "abcdefghijk" || len(resp.GetEntries()) != 1 || resp.GetEntries()[0].GetText() != "hi there" {
+		t.Errorf("unexpected response: %v", resp)
+	}
+
+	_, err = client.GetTranscript(context.Background(), &transcriptpb.GetTranscriptRequest{VideoId: "unavailabl1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetTranscript(unavailable) code = %v; want %v", status.Code(err), codes.NotFound)
+	}
+
+	_, err = client.GetTranscript(context.Background(), &transcriptpb.GetTranscriptRequest{VideoId: "not a video id"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetTranscript(invalid) code = %v; want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestListLanguages(t *testing.T) {
+	resp, err := startServer(t).ListLanguages(context.Background(), &transcriptpb.ListLanguagesRequest{VideoId: "abcdefghijk"})
+	if err != nil {
+		t.Fatalf("ListLanguages() error = %v", err)
+	}
+	if len(resp.GetTracks()) != 1 || resp.GetTracks()[0].GetLanguageCode() != "en" {
+		t.Errorf("unexpected tracks: %v", resp.GetTracks())
+	}
+}
+
+func TestBatchGetTranscripts(t *testing.T) {
+	stream, err := startServer(t).BatchGetTranscripts(context.Background(), &transcriptpb.BatchGetTranscriptsRequest{
+		VideoIds: []string{"abcdefghijk", "unavailabl1", "bcdefghijkl"},
+	})
+	if err != nil {
+		t.Fatalf("BatchGetTranscripts() error = %v", err)
+	}
+
+	results := make(map[string]*transcriptpb.BatchGetTranscriptsResponse)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		results[resp.GetVideoId()] = resp
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("got %d results; want 3", len(results))
+	}
+	if results["unavailabl1"].GetError() == "" {
+		t.Error("expected error for unavailable video")
+	}
+	if len(results["bcdefghijkl"].GetEntries()) != 1 {
+		t.Errorf("unexpected result: %v", results["bcdefghijkl"])
+	}
+}
+
+func TestBatchGetTranscripts_TooManyVideos(t *testing.T) {
+	var upstream int
+	srv := NewServer(transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		upstream++
+		return fakeTransport(r)
+	}))))
+
+	ids := strings.Split(strings.TrimSuffix(strings.Repeat("abcdefghijk,", server.MaxRequestVideos+1), ","), ",")
+	// The request is refused before the stream is touched
+	err := srv.BatchGetTranscripts(&transcriptpb.BatchGetTranscriptsRequest{VideoIds: ids}, nil)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("BatchGetTranscripts() error = %v; want %v", err, codes.InvalidArgument)
+	}
+	if upstream != 0 {
+		t.Errorf("made %d upstream requests for an oversized batch; want 0", upstream)
+	}
+}
+
+func TestServer_CancelledRPC(t *testing.T) {
+	var upstream int
+	srv := NewServer(transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		upstream++
+		return fakeTransport(r)
+	}))))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := srv.GetTranscript(ctx, &transcriptpb.GetTranscriptRequest{VideoId: "abcdefghijk"})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("GetTranscript() code = %v; want %v", status.Code(err), codes.Canceled)
+	}
+	_, err = srv.ListLanguages(ctx, &transcriptpb.ListLanguagesRequest{VideoId: "abcdefghijk"})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("ListLanguages() code = %v; want %v", status.Code(err), codes.Canceled)
+	}
+	if upstream != 0 {
+		t.Errorf("made %d upstream requests for cancelled RPCs; want 0", upstream)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err       error
+		code      codes.Code
+		retryable bool
+	}{
+		{context.Canceled, codes.Canceled, false},
+		{context.DeadlineExceeded, codes.DeadlineExceeded, false},
+		{transcript.ErrInvalidVideoID{Input: "nope"}, codes.InvalidArgument, false},
+		{&transcript.ErrVideoUnavailable{VideoID: "abcdefghijk"}, codes.NotFound, false},
+		{transcript.ErrNoTranscriptFound{VideoID: "abcdefghijk", Language: "de"}, codes.NotFound, false},
+		{transcript.ErrEmptyTranscript{VideoID: "abcdefghijk"}, codes.NotFound, false},
+		{transcript.ErrTranscriptsDisabled{VideoID: "abcdefghijk"}, codes.FailedPrecondition, false},
+		{transcript.ErrMembersOnly{VideoID: "abcdefghijk"}, codes.PermissionDenied, false},
+		{transcript.ErrRegionBlocked{VideoID: "abcdefghijk"}, codes.PermissionDenied, false},
+		{transcript.ErrTooManyRequests{VideoID: "abcdefghijk"}, codes.ResourceExhausted, true},
+		{transcript.ErrCircuitOpen{Until: time.Now().Add(time.Minute)}, codes.ResourceExhausted, true},
+		{transcript.ErrBudgetExceeded{Max: 10, Per: time.Hour, ResetAt: time.Now().Add(time.Minute)}, codes.ResourceExhausted, true},
+		{transcript.ErrNotYetAvailable{VideoID: "abcdefghijk"}, codes.Unavailable, false},
+	}
+	for _, tt := range tests {
+		st := status.Convert(toStatus(tt.err))
+		if st.Code() != tt.code {
+			t.Errorf("toStatus(%T) code = %v; want %v", tt.err, st.Code(), tt.code)
+		}
+		var retry *errdetails.RetryInfo
+		for _, d := range st.Details() {
+			if r, ok := d.(*errdetails.RetryInfo); ok {
+				retry = r
+			}
+		}
+		if tt.retryable && (retry == nil || retry.GetRetryDelay().AsDuration() < time.Second) {
+			t.Errorf("toStatus(%T) RetryInfo = %v; want a retry delay of at least a second", tt.err, retry)
+		}
+		if !tt.retryable && retry != nil {
+			t.Errorf("toStatus(%T) RetryInfo = %v; want none", tt.err, retry)
+		}
+	}
+}
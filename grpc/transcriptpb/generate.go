@@ -0,0 +1,4 @@
+// Package transcriptpb contains the generated protobuf and gRPC code for TranscriptService.
+package transcriptpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative transcript.proto
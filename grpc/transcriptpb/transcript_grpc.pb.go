@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: transcript.proto
+
+package transcriptpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TranscriptService_GetTranscript_FullMethodName       = "/ytwords.v1.TranscriptService/GetTranscript"
+	TranscriptService_ListLanguages_FullMethodName       = "/ytwords.v1.TranscriptService/ListLanguages"
+	TranscriptService_BatchGetTranscripts_FullMethodName = "/ytwords.v1.TranscriptService/BatchGetTranscripts"
+)
+
+// TranscriptServiceClient is the client API for TranscriptService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TranscriptService exposes the transcript client over gRPC.
+type TranscriptServiceClient interface {
+	// GetTranscript fetches the transcript of a single video.
+	GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*GetTranscriptResponse, error)
+	// ListLanguages returns the caption tracks available for a video.
+	ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error)
+	// BatchGetTranscripts streams one result per requested video as each fetch completes.
+	BatchGetTranscripts(ctx context.Context, in *BatchGetTranscriptsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchGetTranscriptsResponse], error)
+}
+
+type transcriptServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTranscriptServiceClient(cc grpc.ClientConnInterface) TranscriptServiceClient {
+	return &transcriptServiceClient{cc}
+}
+
+func (c *transcriptServiceClient) GetTranscript(ctx context.Context, in *GetTranscriptRequest, opts ...grpc.CallOption) (*GetTranscriptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTranscriptResponse)
+	err := c.cc.Invoke(ctx, TranscriptService_GetTranscript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcriptServiceClient) ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLanguagesResponse)
+	err := c.cc.Invoke(ctx, TranscriptService_ListLanguages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcriptServiceClient) BatchGetTranscripts(ctx context.Context, in *BatchGetTranscriptsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BatchGetTranscriptsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TranscriptService_ServiceDesc.Streams[0], TranscriptService_BatchGetTranscripts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchGetTranscriptsRequest, BatchGetTranscriptsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranscriptService_BatchGetTranscriptsClient = grpc.ServerStreamingClient[BatchGetTranscriptsResponse]
+
+// TranscriptServiceServer is the server API for TranscriptService service.
+// All implementations must embed UnimplementedTranscriptServiceServer
+// for forward compatibility.
+//
+// TranscriptService exposes the transcript client over gRPC.
+type TranscriptServiceServer interface {
+	// GetTranscript fetches the transcript of a single video.
+	GetTranscript(context.Context, *GetTranscriptRequest) (*GetTranscriptResponse, error)
+	// ListLanguages returns the caption tracks available for a video.
+	ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error)
+	// BatchGetTranscripts streams one result per requested video as each fetch completes.
+	BatchGetTranscripts(*BatchGetTranscriptsRequest, grpc.ServerStreamingServer[BatchGetTranscriptsResponse]) error
+	mustEmbedUnimplementedTranscriptServiceServer()
+}
+
+// UnimplementedTranscriptServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTranscriptServiceServer struct{}
+
+func (UnimplementedTranscriptServiceServer) GetTranscript(context.Context, *GetTranscriptRequest) (*GetTranscriptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTranscript not implemented")
+}
+func (UnimplementedTranscriptServiceServer) ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLanguages not implemented")
+}
+func (UnimplementedTranscriptServiceServer) BatchGetTranscripts(*BatchGetTranscriptsRequest, grpc.ServerStreamingServer[BatchGetTranscriptsResponse]) error {
+	return status.Error(codes.Unimplemented, "method BatchGetTranscripts not implemented")
+}
+func (UnimplementedTranscriptServiceServer) mustEmbedUnimplementedTranscriptServiceServer() {}
+func (UnimplementedTranscriptServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeTranscriptServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TranscriptServiceServer will
+// result in compilation errors.
+type UnsafeTranscriptServiceServer interface {
+	mustEmbedUnimplementedTranscriptServiceServer()
+}
+
+func RegisterTranscriptServiceServer(s grpc.ServiceRegistrar, srv TranscriptServiceServer) {
+	// If the following call panics, it indicates UnimplementedTranscriptServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TranscriptService_ServiceDesc, srv)
+}
+
+func _TranscriptService_GetTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscriptServiceServer).GetTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscriptService_GetTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscriptServiceServer).GetTranscript(ctx, req.(*GetTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscriptService_ListLanguages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLanguagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscriptServiceServer).ListLanguages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscriptService_ListLanguages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscriptServiceServer).ListLanguages(ctx, req.(*ListLanguagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscriptService_BatchGetTranscripts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchGetTranscriptsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TranscriptServiceServer).BatchGetTranscripts(m, &grpc.GenericServerStream[BatchGetTranscriptsRequest, BatchGetTranscriptsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TranscriptService_BatchGetTranscriptsServer = grpc.ServerStreamingServer[BatchGetTranscriptsResponse]
+
+// TranscriptService_ServiceDesc is the grpc.ServiceDesc for TranscriptService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TranscriptService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ytwords.v1.TranscriptService",
+	HandlerType: (*TranscriptServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTranscript",
+			Handler:    _TranscriptService_GetTranscript_Handler,
+		},
+		{
+			MethodName: "ListLanguages",
+			Handler:    _TranscriptService_ListLanguages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchGetTranscripts",
+			Handler:       _TranscriptService_BatchGetTranscripts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "transcript.proto",
+}
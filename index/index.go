@@ -0,0 +1,87 @@
+// Package index keeps a local full-text index of stored transcripts.
+//
+// Transcripts are indexed as passages of a few consecutive captions, so every hit points to
+// the moment of the video it was said. The index is behind the Index interface; SQLite with
+// its FTS5 extension is the default implementation.
+package index
+
+import (
+	"fmt"
+
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// PassageChars is the length passages of captions are merged up to before indexing
+const PassageChars = 300
+
+// Hit is an indexed passage matching a query
+type Hit struct {
+	VideoID string  `json:"videoId"`
+	Title   string  `json:"title,omitempty"`
+	Author  string  `json:"author,omitempty"`
+	Start   float64 `json:"start"`
+	Text    string  `json:"text"`
+}
+
+// Query is a ranked search of the index
+type Query struct {
+	// Text holds the words every passage must contain; "quoted phrases" must appear as written
+	Text string
+	// Channel restricts results to a channel, by ID or by name ignoring case
+	Channel string
+	Limit   int
+}
+
+// Result is a passage matching a Query
+type Result struct {
+	Hit
+	// Snippet is the part of the passage around the match, matched words in [brackets]
+	Snippet string `json:"snippet"`
+	// Score is the BM25 relevance of the passage, higher is better
+	Score float64 `json:"score"`
+	// Link opens the video at the start of the passage
+	Link string `json:"link"`
+}
+
+// Index is a full-text index of transcripts
+type Index interface {
+	// Add indexes a record, replacing what was indexed for the same video
+	Add(rec store.Record) error
+	// Remove drops a video from the index
+	Remove(videoID string) error
+	// Search returns up to limit passages matching query, as for Query.Text, in video and time
+	// order
+	Search(query string, limit int) ([]Hit, error)
+	// Rank returns the passages matching q, most relevant first
+	Rank(q Query) ([]Result, error)
+	Close() error
+}
+
+// Open opens the default index, SQLite FTS5, at path
+func Open(path string) (Index, error) {
+	return OpenSQLite(path)
+}
+
+// Ingest adds every record of st to idx, returning how many were indexed
+func Ingest(idx Index, st *store.Store) (int, error) {
+	ids, err := st.List()
+	if err != nil {
+		return 0, err
+	}
+	for i, id := range ids {
+		rec, err := st.Get(id)
+		if err != nil {
+			return i, err
+		}
+		if err := idx.Add(rec); err != nil {
+			return i, fmt.Errorf("error indexing %s: %v", id, err)
+		}
+	}
+	return len(ids), nil
+}
+
+// passages merges the entries of a record into the chunks that are indexed
+func passages(rec store.Record) []transcript.Chunk {
+	return transcript.ChunkEntries(rec.Entries, PassageChars)
+}
@@ -0,0 +1,98 @@
+package index
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestIngestAndSearch(t *testing.T) {
+	dir := t.TempDir()
+	st, err := store.Open(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatalf("store.Open() error = %v", err)
+	}
+	st.Put(store.Record{VideoID: "abcdefghijk", Title: "Compilers", FetchedAt: time.Now(), Entries: []transcript.TranscriptEntry{
+		{Text: "Today we write a parser.", Start: 0, Duration: 2},
+		// Filler longer than a passage, so the entries around it are indexed apart
+		{Text: strings.Repeat("lorem ipsum ", 30), Start: 2, Duration: 60},
+		{Text: "The parser builds a syntax tree.", Start: 62, Duration: 3},
+	}})
+	st.Put(store.Record{VideoID: "bcdefghijkl", Title: "Gardening", FetchedAt: time.Now(), Entries: []transcript.TranscriptEntry{
+		{Text: "Tomatoes need sun.", Start: 5, Duration: 2},
+	}})
+
+	idx, err := Open(filepath.Join(dir, "index.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer idx.Close()
+	n, err := Ingest(idx, st)
+	if err != nil || n != 2 {
+		t.Fatalf("Ingest() = %d, %v; want 2, nil", n, err)
+	}
+
+	hits, err := idx.Search("Parser", 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(hits) != 2 || hits[0].Start != 0 || hits[1].Start != 62 || hits[1].Title != "Compilers" {
+		t.Errorf("Search(parser) = %+v; want the passages at 0s and 62s of Compilers", hits)
+	}
+	if hits, _ := idx.Search(`tomatoes "sun`, 10); len(hits) != 1 || hits[0].VideoID != "bcdefghijkl" {
+		t.Errorf("Search(tomatoes sun) = %+v; want the gardening passage", hits)
+	}
+
+	// Re-adding a video replaces it instead of duplicating its passages
+	rec, _ := st.Get("bcdefghijkl")
+	if err := idx.Add(rec); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if hits, _ := idx.Search("tomatoes", 10); len(hits) != 1 {
+		t.Errorf("Search(tomatoes) after re-adding = %+v; want 1 hit", hits)
+	}
+	if err := idx.Remove("bcdefghijkl"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if hits, _ := idx.Search("tomatoes", 10); len(hits) != 0 {
+		t.Errorf("Search(tomatoes) after Remove = %+v; want none", hits)
+	}
+}
+
+func TestRank(t *testing.T) {
+	idx, err := Open(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer idx.Close()
+	idx.Add(store.Record{VideoID: "abcdefghijk", Title: "Types", Author: "Lang Talks", ChannelID: "UC1", Entries: []transcript.TranscriptEntry{
+		{Text: "A type system catches errors. The type system of Go is simple.", Start: 90, Duration: 5},
+	}})
+	idx.Add(store.Record{VideoID: "bcdefghijkl", Title: "Cooking", Author: "Chef", ChannelID: "UC2", Entries: []transcript.TranscriptEntry{
+		{Text: "This system of cooking has one type of pan.", Start: 10, Duration: 5},
+	}})
+
+	results, err := idx.Rank(Query{Text: `"type system"`})
+	if err != nil {
+		t.Fatalf("Rank() error = %v", err)
+	}
+	if len(results) != 1 || results[0].VideoID != "abcdefghijk" || results[0].Link != "https://youtu.be/abcdefghijk?t=90" {
+		t.Fatalf("Rank(phrase) = %+v; want only the passage saying \"type system\"", results)
+	}
+	if !strings.Contains(results[0].Snippet, "[type system]") || results[0].Score <= 0 {
+		t.Errorf("result = %+v; want a snippet marking the phrase and a positive score", results[0])
+	}
+
+	results, _ = idx.Rank(Query{Text: "type system"})
+	if len(results) != 2 || results[0].VideoID != "abcdefghijk" {
+		t.Errorf("Rank(words) = %+v; want both passages, the one repeating the words first", results)
+	}
+	results, _ = idx.Rank(Query{Text: "type system", Channel: "chef"})
+	if len(results) != 1 || results[0].VideoID != "bcdefghijkl" {
+		t.Errorf("Rank(channel chef) = %+v; want the cooking passage", results)
+	}
+}
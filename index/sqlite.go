@@ -0,0 +1,189 @@
+package index
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mjlefevre/yt-words-go/store"
+	"github.com/mjlefevre/yt-words-go/transcript"
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS videos (
+	video_id   TEXT PRIMARY KEY,
+	title      TEXT NOT NULL,
+	author     TEXT NOT NULL,
+	channel_id TEXT NOT NULL,
+	language   TEXT NOT NULL,
+	fetched_at INTEGER NOT NULL
+);
+CREATE VIRTUAL TABLE IF NOT EXISTS passages USING fts5(
+	text,
+	video_id UNINDEXED,
+	start UNINDEXED,
+	tokenize = 'unicode61 remove_diacritics 2'
+);
+`
+
+// SQLite is an Index stored in a SQLite database with an FTS5 table of passages
+type SQLite struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens or creates the index database at path
+func OpenSQLite(path string) (*SQLite, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error initializing index database: %v", err)
+	}
+	return &SQLite{db: db}, nil
+}
+
+// Close closes the underlying database
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// Add indexes a record, replacing what was indexed for the same video
+func (s *SQLite) Add(rec store.Record) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := remove(tx, rec.VideoID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO videos (video_id, title, author, channel_id, language, fetched_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		rec.VideoID, rec.Title, rec.Author, rec.ChannelID, rec.LanguageCode, rec.FetchedAt.UnixNano())
+	if err != nil {
+		return err
+	}
+	for _, p := range passages(rec) {
+		if _, err := tx.Exec(`INSERT INTO passages (text, video_id, start) VALUES (?, ?, ?)`, p.Text, rec.VideoID, p.Start); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Remove drops a video from the index
+func (s *SQLite) Remove(videoID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := remove(tx, videoID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func remove(tx *sql.Tx, videoID string) error {
+	if _, err := tx.Exec(`DELETE FROM passages WHERE video_id = ?`, videoID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`DELETE FROM videos WHERE video_id = ?`, videoID)
+	return err
+}
+
+// Search returns up to limit passages matching query, as for Query.Text, in video and time
+// order
+func (s *SQLite) Search(query string, limit int) ([]Hit, error) {
+	match := matchExpression(query)
+	if match == "" {
+		return nil, fmt.Errorf("query has no words")
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.Query(`
+		SELECT p.video_id, v.title, v.author, p.start, p.text
+		FROM passages p JOIN videos v ON v.video_id = p.video_id
+		WHERE passages MATCH ?
+		ORDER BY p.video_id, p.start
+		LIMIT ?`, match, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error searching index: %v", err)
+	}
+	defer rows.Close()
+
+	hits := []Hit{}
+	for rows.Next() {
+		var h Hit
+		if err := rows.Scan(&h.VideoID, &h.Title, &h.Author, &h.Start, &h.Text); err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+// Rank returns the passages matching q, most relevant first
+func (s *SQLite) Rank(q Query) ([]Result, error) {
+	match := matchExpression(q.Text)
+	if match == "" {
+		return nil, fmt.Errorf("query has no words")
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.Query(`
+		SELECT p.video_id, v.title, v.author, p.start, p.text,
+			snippet(passages, 0, '[', ']', '…', 16), -bm25(passages)
+		FROM passages p JOIN videos v ON v.video_id = p.video_id
+		WHERE passages MATCH ?
+			AND (? = '' OR v.channel_id = ? OR v.author = ? COLLATE NOCASE)
+		ORDER BY bm25(passages), p.video_id, p.start
+		LIMIT ?`, match, q.Channel, q.Channel, q.Channel, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error searching index: %v", err)
+	}
+	defer rows.Close()
+
+	results := []Result{}
+	for rows.Next() {
+		var r Result
+		if err := rows.Scan(&r.VideoID, &r.Title, &r.Author, &r.Start, &r.Text, &r.Snippet, &r.Score); err != nil {
+			return nil, err
+		}
+		r.Link = transcript.DeepLink(r.VideoID, r.Start)
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// matchExpression turns free text into an FTS5 query requiring each of its words and quoted
+// phrases, every one quoted so that FTS5 operators and punctuation are taken literally
+func matchExpression(query string) string {
+	var terms []string
+	for i, part := range strings.Split(query, `"`) {
+		words := strings.FieldsFunc(part, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+		})
+		if len(words) == 0 {
+			continue
+		}
+		// Odd parts were between quotes
+		if i%2 == 1 {
+			terms = append(terms, `"`+strings.Join(words, " ")+`"`)
+			continue
+		}
+		for _, w := range words {
+			terms = append(terms, `"`+w+`"`)
+		}
+	}
+	return strings.Join(terms, " ")
+}
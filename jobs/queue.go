@@ -0,0 +1,271 @@
+// Package jobs implements a persistent queue of batch transcript jobs.
+//
+// A job is a list of videos fetched in the background by a bounded pool of workers.
+// Jobs and their per-video results are stored in SQLite, so they survive restarts and
+// can be queried by ID from any process sharing the database file.
+package jobs
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// ErrJobNotFound is returned when no job has the requested ID
+var ErrJobNotFound = errors.New("job not found")
+
+// Status is the state of a job or of one of its items
+type Status string
+
+// Job and item states. A finished job is failed when none of its videos could be fetched.
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Job summarizes a batch of videos and the progress made on it
+type Job struct {
+	ID         string     `json:"id"`
+	Language   string     `json:"language,omitempty"`
+	Status     Status     `json:"status"`
+	Total      int        `json:"total"`
+	Completed  int        `json:"completed"`
+	Failed     int        `json:"failed"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Item is the outcome of one video within a job
+type Item struct {
+	VideoID string                       `json:"videoId"`
+	Status  Status                       `json:"status"`
+	Error   string                       `json:"error,omitempty"`
+	Entries []transcript.TranscriptEntry `json:"entries,omitempty"`
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id          TEXT PRIMARY KEY,
+	language    TEXT NOT NULL,
+	created_at  INTEGER NOT NULL,
+	finished_at INTEGER
+);
+CREATE TABLE IF NOT EXISTS job_items (
+	job_id     TEXT NOT NULL REFERENCES jobs(id),
+	position   INTEGER NOT NULL,
+	video_id   TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	error      TEXT NOT NULL DEFAULT '',
+	entries    TEXT,
+	updated_at INTEGER NOT NULL,
+	PRIMARY KEY (job_id, position)
+);
+CREATE INDEX IF NOT EXISTS job_items_status ON job_items(status);
+`
+
+// Queue is a SQLite-backed job queue
+type Queue struct {
+	db     *sql.DB
+	wake   chan struct{}
+	now    func() time.Time
+	lease  time.Duration
+	logger transcript.Logger
+}
+
+// Option configures a Queue
+type Option func(*Queue)
+
+// WithLogger sets the logger workers report database errors to. By default they are discarded.
+func WithLogger(logger transcript.Logger) Option {
+	return func(q *Queue) {
+		if logger != nil {
+			q.logger = logger
+		}
+	}
+}
+
+// nopLogger discards everything
+type nopLogger struct{}
+
+func (nopLogger) Printf(string, ...interface{}) {}
+
+// Open opens or creates the queue database at path
+func Open(path string, options ...Option) (*Queue, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	// A single connection serializes writers inside this process; busy_timeout covers other processes
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error initializing job database: %v", err)
+	}
+
+	q := &Queue{db: db, wake: make(chan struct{}, 1), now: time.Now, lease: LeaseTimeout, logger: nopLogger{}}
+	for _, option := range options {
+		option(q)
+	}
+	return q, nil
+}
+
+// Close closes the underlying database
+func (q *Queue) Close() error {
+	return q.db.Close()
+}
+
+// Enqueue creates a job fetching videoIDs in language (empty prefers English)
+func (q *Queue) Enqueue(videoIDs []string, language string) (Job, error) {
+	if len(videoIDs) == 0 {
+		return Job{}, errors.New("a job needs at least one video")
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Job{}, err
+	}
+	now := q.now()
+
+	tx, err := q.db.Begin()
+	if err != nil {
+		return Job{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO jobs (id, language, created_at) VALUES (?, ?, ?)`, id, language, now.UnixNano()); err != nil {
+		return Job{}, err
+	}
+	for i, videoID := range videoIDs {
+		_, err := tx.Exec(`INSERT INTO job_items (job_id, position, video_id, status, updated_at) VALUES (?, ?, ?, ?, ?)`,
+			id, i, videoID, StatusPending, now.UnixNano())
+		if err != nil {
+			return Job{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return Job{}, err
+	}
+
+	// Wake an idle worker in this process, if any
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return q.Get(id)
+}
+
+// Get returns the current state of a job
+func (q *Queue) Get(id string) (Job, error) {
+	jobs, err := q.query(`WHERE j.id = ? GROUP BY j.id`, id)
+	if err != nil {
+		return Job{}, err
+	}
+	if len(jobs) == 0 {
+		return Job{}, ErrJobNotFound
+	}
+	return jobs[0], nil
+}
+
+// List returns the most recently created jobs, newest first
+func (q *Queue) List(limit int) ([]Job, error) {
+	return q.query(`GROUP BY j.id ORDER BY j.created_at DESC LIMIT ?`, limit)
+}
+
+// query selects job summaries; clause must contain the GROUP BY j.id of the aggregate
+func (q *Queue) query(clause string, args ...interface{}) ([]Job, error) {
+	rows, err := q.db.Query(`
+		SELECT j.id, j.language, j.created_at, j.finished_at,
+			COUNT(i.position),
+			SUM(i.status = 'done'),
+			SUM(i.status = 'failed'),
+			SUM(i.status = 'running')
+		FROM jobs j JOIN job_items i ON i.job_id = j.id
+		`+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		var (
+			job              Job
+			created          int64
+			finished         sql.NullInt64
+			done, failed, on int
+		)
+		if err := rows.Scan(&job.ID, &job.Language, &created, &finished, &job.Total, &done, &failed, &on); err != nil {
+			return nil, err
+		}
+
+		job.CreatedAt = time.Unix(0, created)
+		job.Completed = done + failed
+		job.Failed = failed
+		switch {
+		case finished.Valid:
+			t := time.Unix(0, finished.Int64)
+			job.FinishedAt = &t
+			job.Status = StatusDone
+			// A job none of whose videos could be fetched failed as a whole
+			if done == 0 {
+				job.Status = StatusFailed
+			}
+		case job.Completed > 0 || on > 0:
+			job.Status = StatusRunning
+		default:
+			job.Status = StatusPending
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// Items returns the per-video results of a job in submission order
+func (q *Queue) Items(id string) ([]Item, error) {
+	if _, err := q.Get(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.Query(`SELECT video_id, status, error, entries FROM job_items WHERE job_id = ? ORDER BY position`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		var (
+			item    Item
+			entries sql.NullString
+		)
+		if err := rows.Scan(&item.VideoID, &item.Status, &item.Error, &entries); err != nil {
+			return nil, err
+		}
+		if entries.Valid {
+			if err := json.Unmarshal([]byte(entries.String), &item.Entries); err != nil {
+				return nil, fmt.Errorf("error decoding stored entries for %s: %v", item.VideoID, err)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,297 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+const watchPage = `{"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?lang=en","name":{"simpleText":"English"},"languageCode":"en"}]}}}`
+
+const captionXML = `<transcript><text start="1" dur="2">hi there</text></transcript>`
+
+// fakeTransport serves a single English track for every video except "unavailabl1"
+func fakeTransport(r *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, watchPage
+	switch {
+	case r.URL.Path == "/api/timedtext":
+		body = captionXML
+	case r.URL.Query().Get("v") == "unavailabl1":
+		status, body = http.StatusNotFound, ""
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func openTestQueue(t *testing.T) *Queue {
+	q, err := Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { q.Close() })
+	return q
+}
+
+func TestQueue_EnqueueAndRun(t *testing.T) {
+	q := openTestQueue(t)
+
+	job, err := q.Enqueue([]string{"abcdefghijk", "unavailabl1", "bcdefghijkl"}, "")
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	if job.Status != StatusPending || job.Total != 3 {
+		t.Errorf("new job = %+v; want pending with 3 items", job)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), 2)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err = q.Get(job.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if job.Status == StatusDone || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if job.Status != StatusDone || job.Completed != 3 || job.Failed != 1 || job.FinishedAt == nil {
+		t.Fatalf("finished job = %+v; want done with 3 completed and 1 failed", job)
+	}
+
+	items, err := q.Items(job.ID)
+	if err != nil {
+		t.Fatalf("Items() error = %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items; want 3", len(items))
+	}
+	if items[0].Status != StatusDone || len(items[0].Entries) != 1 || items[0].Entries[0].Text != "hi there" {
+		t.Errorf("items[0] = %+v", items[0])
+	}
+	if items[1].Status != StatusFailed || items[1].Error == "" {
+		t.Errorf("items[1] = %+v; want failed with error", items[1])
+	}
+}
+
+func TestQueue_AllItemsFailed(t *testing.T) {
+	q := openTestQueue(t)
+	job, err := q.Enqueue([]string{"unavailabl1"}, "")
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), 1)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for job.FinishedAt == nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		if job, err = q.Get(job.ID); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	cancel()
+	<-done
+
+	if job.Status != StatusFailed || job.Failed != 1 {
+		t.Errorf("finished job = %+v; want failed", job)
+	}
+}
+
+func TestQueue_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	q, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	job, err := q.Enqueue([]string{"abcdefghijk"}, "en")
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	q.Close()
+
+	q, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	defer q.Close()
+
+	got, err := q.Get(job.ID)
+	if err != nil {
+		t.Fatalf("Get() after reopen error = %v", err)
+	}
+	if got.Language != "en" || got.Total != 1 {
+		t.Errorf("Get() = %+v; want the persisted job", got)
+	}
+
+	list, err := q.List(10)
+	if err != nil || len(list) != 1 {
+		t.Errorf("List() = %v, %v; want one job", list, err)
+	}
+}
+
+func TestQueue_NotFound(t *testing.T) {
+	q := openTestQueue(t)
+	if _, err := q.Get("missing"); err != ErrJobNotFound {
+		t.Errorf("Get(missing) error = %v; want ErrJobNotFound", err)
+	}
+	if _, err := q.Items("missing"); err != ErrJobNotFound {
+		t.Errorf("Items(missing) error = %v; want ErrJobNotFound", err)
+	}
+}
+
+func TestQueue_ClaimRespectsLeases(t *testing.T) {
+	q := openTestQueue(t)
+	job, err := q.Enqueue([]string{"abcdefghijk", "bcdefghijkl"}, "")
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	// Another worker is fetching the first item; the worker of the second died long ago
+	now := q.now()
+	q.db.Exec(`UPDATE job_items SET status = ?, updated_at = ? WHERE job_id = ? AND position = 0`, StatusRunning, now.UnixNano(), job.ID)
+	q.db.Exec(`UPDATE job_items SET status = ?, updated_at = ? WHERE job_id = ? AND position = 1`, StatusRunning, now.Add(-2*LeaseTimeout).UnixNano(), job.ID)
+
+	item, ok, err := q.claim(context.Background())
+	if err != nil || !ok || item.videoID != "bcdefghijkl" {
+		t.Fatalf("claim() = %+v, %v, %v; want the item with the expired lease", item, ok, err)
+	}
+	if item, ok, _ := q.claim(context.Background()); ok {
+		t.Errorf("claim() = %+v; want no item while the other lease is live", item)
+	}
+}
+
+func TestQueue_CancelReleasesItem(t *testing.T) {
+	q := openTestQueue(t)
+	job, err := q.Enqueue([]string{"abcdefghijk"}, "")
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	fetching := make(chan struct{})
+	client := transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		// The watch page request hangs until it is cancelled
+		close(fetching)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, client, 1)
+	}()
+	<-fetching
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after cancel")
+	}
+
+	items, err := q.Items(job.ID)
+	if err != nil {
+		t.Fatalf("Items() error = %v", err)
+	}
+	if items[0].Status != StatusPending {
+		t.Errorf("item status after cancel = %s; want pending", items[0].Status)
+	}
+}
+
+// logRecorder records the messages logged by workers
+type logRecorder struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *logRecorder) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, fmt.Sprintf(format, v...))
+}
+
+func (l *logRecorder) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.msgs)
+}
+
+func TestQueue_WorkerSurvivesDatabaseErrors(t *testing.T) {
+	logs := &logRecorder{}
+	q, err := Open(filepath.Join(t.TempDir(), "jobs.db"), WithLogger(logs))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer q.Close()
+	job, err := q.Enqueue([]string{"abcdefghijk"}, "")
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	// Claims fail while the items table is missing
+	if _, err := q.db.Exec(`ALTER TABLE job_items RENAME TO job_items_away`); err != nil {
+		t.Fatalf("renaming table: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), 1)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logs.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if logs.count() == 0 {
+		t.Fatal("worker logged no database error")
+	}
+	if _, err := q.db.Exec(`ALTER TABLE job_items_away RENAME TO job_items`); err != nil {
+		t.Fatalf("restoring table: %v", err)
+	}
+
+	for job.FinishedAt == nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		if job, err = q.Get(job.ID); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+	cancel()
+	<-done
+	if job.Status != StatusDone {
+		t.Errorf("job after the database recovered = %+v; want done", job)
+	}
+}
@@ -0,0 +1,199 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// pollInterval is how often idle workers check for items enqueued by other processes
+const pollInterval = time.Second
+
+// LeaseTimeout is how long a running item may go without a heartbeat from its worker before
+// other workers take it over, as its process has likely died
+const LeaseTimeout = 2 * time.Minute
+
+// claimedItem is a pending item a worker has taken ownership of
+type claimedItem struct {
+	jobID    string
+	position int
+	videoID  string
+	language string
+}
+
+// maxBackoff caps how long a worker waits after repeated database errors
+const maxBackoff = 30 * time.Second
+
+// Run processes pending items with the given number of workers until ctx is cancelled.
+// Several processes may share a queue. Items whose worker stopped renewing their lease for
+// LeaseTimeout, such as those of a process that died, are claimed again.
+// Database errors, such as SQLITE_BUSY under contention, are logged and retried after a
+// growing backoff rather than stopping the worker. Items being fetched when ctx is cancelled
+// are put back as pending.
+func (q *Queue) Run(ctx context.Context, client *transcript.Client, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx, client)
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *Queue) work(ctx context.Context, client *transcript.Client) {
+	backoff := time.Duration(0)
+	// fail logs a database error and waits before the worker tries again
+	fail := func(format string, err error) {
+		backoff = min(max(2*backoff, pollInterval), maxBackoff)
+		q.logger.Printf(format+", retrying in %s", err, backoff)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+	}
+
+	for ctx.Err() == nil {
+		item, ok, err := q.claim(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			fail("Error claiming a job item: %v", err)
+			continue
+		}
+		if !ok {
+			select {
+			case <-ctx.Done():
+			case <-q.wake:
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		stop := q.heartbeat(item)
+		var opts transcript.FetchOptions
+		if item.language != "" {
+			opts.Languages = []string{item.language}
+		}
+		result, err := client.GetTranscriptWithOptions(ctx, item.videoID, opts)
+		stop()
+		if ctx.Err() != nil {
+			// The fetch was cut short, not failed; another run picks the item up again
+			if err := q.release(item); err != nil {
+				q.logger.Printf("Error releasing %s of job %s: %v", item.videoID, item.jobID, err)
+			}
+			return
+		}
+		// On failure the item keeps its running status, so it is claimed again once its lease expires
+		if err := q.complete(item, result.Entries, err); err != nil {
+			fail("Error storing a job item: %v", err)
+			continue
+		}
+		backoff = 0
+	}
+}
+
+// heartbeat renews the lease of a running item until the returned function is called
+func (q *Queue) heartbeat(item claimedItem) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(q.lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// A failed renewal only risks another worker fetching the item as well
+				q.db.Exec(`UPDATE job_items SET updated_at = ? WHERE job_id = ? AND position = ? AND status = ?`,
+					q.now().UnixNano(), item.jobID, item.position, StatusRunning)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// claim atomically marks the oldest pending item, or running item with an expired lease, as running
+func (q *Queue) claim(ctx context.Context) (claimedItem, bool, error) {
+	for {
+		var item claimedItem
+		var status Status
+		var updatedAt int64
+		expired := q.now().Add(-q.lease).UnixNano()
+		err := q.db.QueryRowContext(ctx, `
+			SELECT i.job_id, i.position, i.video_id, j.language, i.status, i.updated_at
+			FROM job_items i JOIN jobs j ON j.id = i.job_id
+			WHERE i.status = ? OR (i.status = ? AND i.updated_at < ?)
+			ORDER BY j.created_at, i.position
+			LIMIT 1`, StatusPending, StatusRunning, expired).Scan(&item.jobID, &item.position, &item.videoID, &item.language, &status, &updatedAt)
+		if err == sql.ErrNoRows {
+			return claimedItem{}, false, nil
+		}
+		if err != nil {
+			return claimedItem{}, false, err
+		}
+
+		// Matching the row as read makes the claim fail if another worker claimed or renewed it
+		res, err := q.db.ExecContext(ctx, `UPDATE job_items SET status = ?, updated_at = ? WHERE job_id = ? AND position = ? AND status = ? AND updated_at = ?`,
+			StatusRunning, q.now().UnixNano(), item.jobID, item.position, status, updatedAt)
+		if err != nil {
+			return claimedItem{}, false, err
+		}
+		// Another process may have claimed the same item in between; try the next one
+		if n, _ := res.RowsAffected(); n == 1 {
+			return item, true, nil
+		}
+	}
+}
+
+// release puts a running item back as pending
+func (q *Queue) release(item claimedItem) error {
+	_, err := q.db.Exec(`UPDATE job_items SET status = ?, updated_at = ? WHERE job_id = ? AND position = ? AND status = ?`,
+		StatusPending, q.now().UnixNano(), item.jobID, item.position, StatusRunning)
+	return err
+}
+
+// complete stores the outcome of an item and finishes its job once nothing is left to do
+func (q *Queue) complete(item claimedItem, entries []transcript.TranscriptEntry, fetchErr error) error {
+	status, errText := StatusDone, ""
+	var stored sql.NullString
+	if fetchErr != nil {
+		status, errText = StatusFailed, fetchErr.Error()
+	} else {
+		b, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		stored = sql.NullString{String: string(b), Valid: true}
+	}
+
+	now := q.now().UnixNano()
+	tx, err := q.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE job_items SET status = ?, error = ?, entries = ?, updated_at = ? WHERE job_id = ? AND position = ?`,
+		status, errText, stored, now, item.jobID, item.position)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE jobs SET finished_at = ? WHERE id = ? AND finished_at IS NULL AND NOT EXISTS (
+		SELECT 1 FROM job_items WHERE job_id = ? AND status IN (?, ?))`,
+		now, item.jobID, item.jobID, StatusPending, StatusRunning)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
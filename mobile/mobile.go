@@ -0,0 +1,97 @@
+// Package mobile exposes a gomobile-friendly surface over the transcript package.
+// Every call takes and returns plain strings so it can be bound for Android and iOS
+// with `gomobile bind`; structured results are returned as JSON.
+package mobile
+
+import (
+	"encoding/json"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// Client wraps a transcript.Client for use from mobile apps
+type Client struct {
+	client *transcript.Client
+}
+
+// entry is the JSON shape of a single transcript entry
+type entry struct {
+	Text     string  `json:"text"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
+}
+
+// track is the JSON shape of an available caption track
+type track struct {
+	LanguageCode string `json:"languageCode"`
+	Language     string `json:"language"`
+	IsGenerated  bool   `json:"isGenerated"`
+}
+
+// NewClient creates a client; proxyURL may be empty to connect directly
+func NewClient(proxyURL string) *Client {
+	var options []transcript.ClientOption
+	if proxyURL != "" {
+		options = append(options, transcript.WithProxy(proxyURL))
+	}
+	return newClient(options...)
+}
+
+func newClient(options ...transcript.ClientOption) *Client {
+	return &Client{client: transcript.NewClient(options...)}
+}
+
+// GetTranscriptJSON fetches a transcript and returns it as a JSON array of entries.
+// An empty lang selects English if available, otherwise the first track.
+func (c *Client) GetTranscriptJSON(videoID string, lang string) (string, error) {
+	var (
+		entries []transcript.TranscriptEntry
+		err     error
+	)
+	if lang == "" {
+		entries, err = c.client.GetTranscript(videoID)
+	} else {
+		entries, err = c.client.GetTranscriptWithLanguage(videoID, lang)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	out := make([]entry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, entry{Text: e.Text, Start: e.Start, Duration: e.Duration})
+	}
+	return marshal(out)
+}
+
+// GetTranscriptText fetches a transcript and returns its text, one entry per line
+func (c *Client) GetTranscriptText(videoID string) (string, error) {
+	return c.client.GetTranscriptString(videoID)
+}
+
+// ListLanguagesJSON returns the available caption tracks as a JSON array
+func (c *Client) ListLanguagesJSON(videoID string) (string, error) {
+	transcripts, err := c.client.ListAvailableTranscripts(videoID)
+	if err != nil {
+		return "", err
+	}
+
+	out := make([]track, 0, len(transcripts))
+	for _, t := range transcripts {
+		out = append(out, track{LanguageCode: t.LanguageCode, Language: t.Language, IsGenerated: t.IsGenerated})
+	}
+	return marshal(out)
+}
+
+// ExtractVideoID returns the video ID contained in a URL, or an error if none is found
+func ExtractVideoID(input string) (string, error) {
+	return transcript.ExtractVideoID(input)
+}
+
+func marshal(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
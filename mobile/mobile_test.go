@@ -0,0 +1,64 @@
+package mobile
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+const watchPage = `{"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?lang=en","name":{"simpleText":"English"},"languageCode":"en"}]}}}`
+
+const captionXML = `<transcript><text start="1" dur="2">hi there</text></transcript>`
+
+func testClient() *Client {
+	return newClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := watchPage
+		if r.URL.Path == "/api/timedtext" {
+			body = captionXML
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})))
+}
+
+func TestGetTranscriptJSON(t *testing.T) {
+	out, err := testClient().GetTranscriptJSON("abcdefghijk", "")
+	if err != nil {
+		t.Fatalf("GetTranscriptJSON() error = %v", err)
+	}
+
+	var entries []entry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(entries) != 1 || entries[0].Text != "hi there" || entries[0].Start != 1 {
+		t.Errorf("unexpected entries: %s", out)
+	}
+}
+
+func TestListLanguagesJSON(t *testing.T) {
+	out, err := testClient().ListLanguagesJSON("abcdefghijk")
+	if err != nil {
+		t.Fatalf("ListLanguagesJSON() error = %v", err)
+	}
+
+	want := `[{"languageCode":"en","language":"English","isGenerated":false}]`
+	if out != want {
+		t.Errorf("ListLanguagesJSON() = %s; want %s", out, want)
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cachedResponse is a fully rendered successful response
+type cachedResponse struct {
+	contentType string
+	body        []byte
+}
+
+type cacheItem struct {
+	key     string
+	value   cachedResponse
+	expires time.Time
+}
+
+// responseCache is an LRU cache of rendered responses with a fixed time-to-live
+type responseCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	items      map[string]*list.Element
+	order      *list.List // front is most recently used
+	now        func() time.Time
+}
+
+func newResponseCache(ttl time.Duration, maxEntries int) *responseCache {
+	return &responseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return cachedResponse{}, false
+	}
+	item := el.Value.(*cacheItem)
+	if c.now().After(item.expires) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return cachedResponse{}, false
+	}
+	c.order.MoveToFront(el)
+	return item.value, true
+}
+
+func (c *responseCache) set(key string, value cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if el, ok := c.items[key]; ok {
+		item := el.Value.(*cacheItem)
+		item.value, item.expires = value, expires
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&cacheItem{key: key, value: value, expires: expires})
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheItem).key)
+	}
+}
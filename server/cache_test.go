@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestResponseCache_Expiry(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newResponseCache(time.Minute, 0)
+	c.now = func() time.Time { return now }
+
+	c.set("a", cachedResponse{body: []byte("x")})
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("expected cache hit before expiry")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.get("a"); ok {
+		t.Error("expected cache miss after expiry")
+	}
+}
+
+func TestResponseCache_Eviction(t *testing.T) {
+	c := newResponseCache(time.Hour, 2)
+	c.set("a", cachedResponse{})
+	c.set("b", cachedResponse{})
+	c.get("a")
+	c.set("c", cachedResponse{})
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	if _, ok := c.get("a"); !ok {
+		t.Error("expected recently used entry to be kept")
+	}
+}
+
+func TestServer_Cache(t *testing.T) {
+	fetches := 0
+	client := transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fetches++
+		return fakeTransport(r)
+	})))
+	srv := New(client, WithCache(time.Hour, 10))
+
+	var cacheHeaders []string
+	for _, path := range []string{
+		"/v1/videos/abcdefghijk/transcript?format=srt",
+		"/v1/videos/abcdefghijk/transcript?format=srt",
+		"/v1/videos/abcdefghijk/transcript?format=vtt",
+	} {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		cacheHeaders = append(cacheHeaders, rec.Header().Get("X-Cache"))
+	}
+
+	if cacheHeaders[0] != "MISS" || cacheHeaders[1] != "HIT" || cacheHeaders[2] != "MISS" {
+		t.Errorf("X-Cache headers = %v; want [MISS HIT MISS]", cacheHeaders)
+	}
+	if fetches != 4 {
+		t.Errorf("made %d upstream requests; want 4", fetches)
+	}
+}
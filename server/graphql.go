@@ -0,0 +1,394 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+const graphqlSchema = `
+schema {
+	query: Query
+}
+
+type Query {
+	# Looks up a video by ID or URL
+	video(id: String!): Video
+}
+
+type Video {
+	id: String!
+	metadata: Metadata!
+	languages: [Track!]!
+	chapters: [Chapter!]!
+	# Empty lang prefers English, then the first available track
+	transcript(lang: String): Transcript!
+}
+
+type Metadata {
+	title: String!
+	author: String!
+	channelId: String!
+	description: String!
+	keywords: [String!]!
+	lengthSeconds: Int!
+	viewCount: Float!
+	isLive: Boolean!
+}
+
+type Track {
+	languageCode: String!
+	language: String!
+	isGenerated: Boolean!
+}
+
+type Chapter {
+	title: String!
+	start: Float!
+}
+
+type Transcript {
+	languageCode: String!
+	language: String!
+	isGenerated: Boolean!
+	# Entries overlapping the [from, to) window in seconds; both bounds are optional
+	entries(from: Float, to: Float): [Entry!]!
+	text: String!
+}
+
+type Entry {
+	text: String!
+	start: Float!
+	duration: Float!
+}
+`
+
+// Limits on the size of a GraphQL query. The schema nests 4 fields deep; the depth leaves room
+// for the introspection queries of GraphQL tools. graphqlMaxFetches allows a watch page, a
+// track list and one transcript for each of MaxRequestVideos videos.
+const (
+	graphqlMaxDepth       = 8
+	graphqlMaxQueryLength = 64 << 10
+	graphqlMaxFetches     = 3 * MaxRequestVideos
+)
+
+// graphqlHandler builds the relay-compatible handler serving graphqlSchema. Every video a
+// query looks up, aliases included, counts against MaxRequestVideos and the rate limit, and
+// the fetches of a query are shared by the fields needing them.
+func (s *Server) graphqlHandler() http.Handler {
+	schema := graphql.MustParseSchema(graphqlSchema, &queryResolver{client: s.client},
+		graphql.MaxDepth(graphqlMaxDepth), graphql.MaxQueryLength(graphqlMaxQueryLength))
+	h := &relay.Handler{Schema: schema}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := newGraphQLRequest()
+		if s.limiter != nil {
+			key := s.clientKey(r)
+			req.charge = func() bool {
+				ok, _ := s.limiter.allowN(key, 1)
+				if !ok {
+					s.metrics.rateLimited.Inc()
+				}
+				return ok
+			}
+		}
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), graphqlRequestKey{}, req)))
+	})
+}
+
+// graphqlRequestKey is the context key of the graphqlRequest of a query
+type graphqlRequestKey struct{}
+
+// graphqlRequest is the state the resolvers of one query share
+type graphqlRequest struct {
+	mu      sync.Mutex
+	videos  int
+	fetches int
+	// charge takes a rate limit token for a video beyond the first; nil without a rate limit
+	charge func() bool
+	// pages, tracks and entries hold the fetches of the query by video ID or track URL
+	pages   map[string]*graphqlFetch
+	tracks  map[string]*graphqlFetch
+	entries map[string]*graphqlFetch
+}
+
+func newGraphQLRequest() *graphqlRequest {
+	return &graphqlRequest{
+		pages:   map[string]*graphqlFetch{},
+		tracks:  map[string]*graphqlFetch{},
+		entries: map[string]*graphqlFetch{},
+	}
+}
+
+// graphqlFetch is a fetch shared by the resolvers of a query; the first of them runs it
+type graphqlFetch struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// errTooManyVideos, errRateLimited and errTooComplex are returned for the parts of a query beyond its limits
+var (
+	errTooManyVideos = fmt.Errorf("at most %d videos per query", MaxRequestVideos)
+	errRateLimited   = errors.New("rate limit exceeded, retry later")
+	errTooComplex    = fmt.Errorf("at most %d fetches per query", graphqlMaxFetches)
+)
+
+// fetch returns the result of the fetch stored under key in m, running fn if it is the first
+// request for it. Every distinct fetch counts against graphqlMaxFetches.
+func (req *graphqlRequest) fetch(m map[string]*graphqlFetch, key string, fn func() (interface{}, error)) (interface{}, error) {
+	req.mu.Lock()
+	f, ok := m[key]
+	if !ok {
+		if req.fetches >= graphqlMaxFetches {
+			req.mu.Unlock()
+			return nil, errTooComplex
+		}
+		req.fetches++
+		f = &graphqlFetch{}
+		m[key] = f
+	}
+	req.mu.Unlock()
+
+	f.once.Do(func() { f.value, f.err = fn() })
+	return f.value, f.err
+}
+
+// lookup counts a video of the query, failing once the query exceeds its limits
+func (req *graphqlRequest) lookup() error {
+	if req == nil {
+		return nil
+	}
+	req.mu.Lock()
+	defer req.mu.Unlock()
+	req.videos++
+	switch {
+	case req.videos > MaxRequestVideos:
+		return errTooManyVideos
+	case req.videos > 1 && req.charge != nil && !req.charge():
+		return errRateLimited
+	}
+	return nil
+}
+
+// graphqlError exposes the same error codes as the REST API in the GraphQL extensions field
+type graphqlError struct {
+	err error
+}
+
+func (e graphqlError) Error() string {
+	return e.err.Error()
+}
+
+func (e graphqlError) Extensions() map[string]interface{} {
+	_, code := errorStatus(e.err)
+	switch e.err {
+	case errTooManyVideos:
+		code = "too_many_videos"
+	case errRateLimited:
+		code = "rate_limited"
+	case errTooComplex:
+		code = "query_too_complex"
+	}
+	return map[string]interface{}{"code": code}
+}
+
+type queryResolver struct {
+	client *transcript.Client
+}
+
+func (q *queryResolver) Video(ctx context.Context, args struct{ ID string }) (*videoResolver, error) {
+	req, _ := ctx.Value(graphqlRequestKey{}).(*graphqlRequest)
+	if req == nil {
+		req = newGraphQLRequest()
+	}
+	if err := req.lookup(); err != nil {
+		return nil, graphqlError{err}
+	}
+	videoID, err := transcript.ExtractVideoID(args.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YouTube video ID: %s", args.ID)
+	}
+	return &videoResolver{client: q.client, req: req, id: videoID}, nil
+}
+
+type videoResolver struct {
+	client *transcript.Client
+	req    *graphqlRequest
+	id     string
+}
+
+func (v *videoResolver) ID() string {
+	return v.id
+}
+
+// page returns the watch page of the video, fetched once per query. Fetches are bound to
+// the context of the HTTP request, so they stop when its client goes away.
+func (v *videoResolver) page(ctx context.Context) (*transcript.WatchPage, error) {
+	page, err := v.req.fetch(v.req.pages, v.id, func() (interface{}, error) {
+		return v.client.GetWatchPageContext(ctx, v.id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page.(*transcript.WatchPage), nil
+}
+
+// transcripts returns the caption tracks of the video, listed once per query. With a
+// backend they are listed through it, as the watch page is not needed.
+func (v *videoResolver) transcripts(ctx context.Context) ([]transcript.Transcript, error) {
+	tracks, err := v.req.fetch(v.req.tracks, v.id, func() (interface{}, error) {
+		if v.client.HasBackend() {
+			return v.client.ListAvailableTranscriptsContext(ctx, v.id)
+		}
+		page, err := v.page(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return page.Transcripts()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tracks.([]transcript.Transcript), nil
+}
+
+func (v *videoResolver) Metadata(ctx context.Context) (*metadataResolver, error) {
+	page, err := v.page(ctx)
+	if err != nil {
+		return nil, graphqlError{err}
+	}
+	md, err := page.Metadata()
+	if err != nil {
+		return nil, graphqlError{err}
+	}
+	return &metadataResolver{md}, nil
+}
+
+func (v *videoResolver) Chapters(ctx context.Context) ([]*chapterResolver, error) {
+	page, err := v.page(ctx)
+	if err != nil {
+		return nil, graphqlError{err}
+	}
+	chapters, err := page.Chapters()
+	if err != nil {
+		return nil, graphqlError{err}
+	}
+
+	out := make([]*chapterResolver, 0, len(chapters))
+	for _, ch := range chapters {
+		out = append(out, &chapterResolver{ch})
+	}
+	return out, nil
+}
+
+func (v *videoResolver) Languages(ctx context.Context) ([]*trackResolver, error) {
+	transcripts, err := v.transcripts(ctx)
+	if err != nil {
+		return nil, graphqlError{err}
+	}
+
+	tracks := make([]*trackResolver, 0, len(transcripts))
+	for _, t := range transcripts {
+		tracks = append(tracks, &trackResolver{t})
+	}
+	return tracks, nil
+}
+
+func (v *videoResolver) Transcript(ctx context.Context, args struct{ Lang *string }) (*transcriptResolver, error) {
+	lang := ""
+	if args.Lang != nil {
+		lang = *args.Lang
+	}
+
+	transcripts, err := v.transcripts(ctx)
+	if err != nil {
+		return nil, graphqlError{err}
+	}
+	// The same selection as FindTranscript, from the tracks already listed
+	var sel transcript.LanguageSelection
+	if lang != "" {
+		sel.Languages = []string{lang}
+	}
+	t, ok := sel.Choose(transcripts)
+	if !ok {
+		return nil, graphqlError{transcript.ErrNoTranscriptFound{VideoID: v.id, Language: lang}}
+	}
+
+	entries, err := v.req.fetch(v.req.entries, t.BaseURL, func() (interface{}, error) {
+		return v.client.FetchTranscriptContext(ctx, t)
+	})
+	if err != nil {
+		return nil, graphqlError{err}
+	}
+	return &transcriptResolver{trackResolver: trackResolver{t}, entries: entries.([]transcript.TranscriptEntry)}, nil
+}
+
+type metadataResolver struct {
+	md transcript.VideoMetadata
+}
+
+func (m *metadataResolver) Title() string       { return m.md.Title }
+func (m *metadataResolver) Author() string      { return m.md.Author }
+func (m *metadataResolver) ChannelID() string   { return m.md.ChannelID }
+func (m *metadataResolver) Description() string { return m.md.Description }
+func (m *metadataResolver) Keywords() []string {
+	if m.md.Keywords == nil {
+		return []string{}
+	}
+	return m.md.Keywords
+}
+func (m *metadataResolver) LengthSeconds() int32 { return int32(m.md.LengthSeconds) }
+func (m *metadataResolver) ViewCount() float64   { return float64(m.md.ViewCount) }
+func (m *metadataResolver) IsLive() bool         { return m.md.IsLive }
+
+type chapterResolver struct {
+	ch transcript.Chapter
+}
+
+func (r *chapterResolver) Title() string  { return r.ch.Title }
+func (r *chapterResolver) Start() float64 { return r.ch.Start }
+
+type trackResolver struct {
+	t transcript.Transcript
+}
+
+func (r *trackResolver) LanguageCode() string { return r.t.LanguageCode }
+func (r *trackResolver) Language() string     { return r.t.Language }
+func (r *trackResolver) IsGenerated() bool    { return r.t.IsGenerated }
+
+type transcriptResolver struct {
+	trackResolver
+	entries []transcript.TranscriptEntry
+}
+
+func (r *transcriptResolver) Entries(args struct{ From, To *float64 }) []*entryResolver {
+	out := make([]*entryResolver, 0, len(r.entries))
+	for _, e := range r.entries {
+		if args.From != nil && e.Start+e.Duration <= *args.From {
+			continue
+		}
+		if args.To != nil && e.Start >= *args.To {
+			continue
+		}
+		out = append(out, &entryResolver{e})
+	}
+	return out
+}
+
+func (r *transcriptResolver) Text() string {
+	return transcript.ConcatenateTranscript(r.entries)
+}
+
+type entryResolver struct {
+	e transcript.TranscriptEntry
+}
+
+func (r *entryResolver) Text() string      { return r.e.Text }
+func (r *entryResolver) Start() float64    { return r.e.Start }
+func (r *entryResolver) Duration() float64 { return r.e.Duration }
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func graphqlQuery(t *testing.T, srv *Server, query string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/graphql", strings.NewReader(string(body))))
+	return rec
+}
+
+func TestGraphQL(t *testing.T) {
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithGraphQL())
+
+	rec := graphqlQuery(t, srv, `{ video(id: "abcdefghijk") {
+		metadata { title author lengthSeconds }
+		languages { languageCode }
+		transcript { languageCode entries(from: 0.5, to: 10) { text start } }
+	} }`)
+
+	want := `{"data":{"video":{"metadata":{"title":"Test video","author":"Tester","lengthSeconds":3},` +
+		`"languages":[{"languageCode":"en"}],` +
+		`"transcript":{"languageCode":"en","entries":[{"text":"hi there","start":1}]}}}}`
+	if rec.Body.String() != want {
+		t.Errorf("body = %s; want %s", rec.Body.String(), want)
+	}
+}
+
+func TestGraphQL_ErrorCode(t *testing.T) {
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithGraphQL())
+
+	rec := graphqlQuery(t, srv, `{ video(id: "unavailabl1") { languages { languageCode } } }`)
+	if !strings.Contains(rec.Body.String(), `"extensions":{"code":"video_unavailable"}`) {
+		t.Errorf("body %s does not contain the error code", rec.Body.String())
+	}
+}
+
+func TestGraphQL_AliasesAreRateLimited(t *testing.T) {
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithGraphQL(), WithRateLimit(0.001, 2))
+
+	rec := graphqlQuery(t, srv, `{ a: video(id: "abcdefghijk") { id } b: video(id: "abcdefghijk") { id } c: video(id: "abcdefghijk") { id } }`)
+	if !strings.Contains(rec.Body.String(), `"extensions":{"code":"rate_limited"}`) {
+		t.Errorf("body %s; want the third video rate limited", rec.Body.String())
+	}
+}
+
+func TestGraphQL_SharesFetches(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	client := transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		return fakeTransport(r)
+	})))
+	srv := New(client, WithGraphQL())
+
+	rec := graphqlQuery(t, srv, `{
+		a: video(id: "abcdefghijk") { metadata { title } chapters { title } languages { languageCode } transcript { text } }
+		b: video(id: "abcdefghijk") { metadata { author } en: transcript(lang: "en") { text } }
+	}`)
+	if strings.Contains(rec.Body.String(), `"errors"`) {
+		t.Fatalf("body = %s; want no errors", rec.Body.String())
+	}
+	if requests["/watch"] != 1 || requests["/api/timedtext"] != 1 {
+		t.Errorf("requests = %v; want one watch page and one transcript request", requests)
+	}
+}
+
+func TestGraphQL_Cancelled(t *testing.T) {
+	var upstream int
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		upstream++
+		return fakeTransport(r)
+	}))), WithGraphQL())
+
+	body, _ := json.Marshal(map[string]string{"query": `{ video(id: "abcdefghijk") { metadata { title } transcript { text } } }`})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/graphql", strings.NewReader(string(body))).WithContext(ctx))
+	if upstream != 0 {
+		t.Errorf("made %d upstream requests for a cancelled query; want 0", upstream)
+	}
+}
+
+func TestGraphQL_Backend(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	client := transcript.NewClient(transcript.WithBackend(transcript.DataAPIBackend("KEY", "TOKEN")),
+		transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requests[r.URL.Path]++
+			mu.Unlock()
+			body := `{"items":[{"id":"track1","snippet":{"language":"en","name":"English","trackKind":"standard"}}]}`
+			if r.URL.Path == "/youtube/v3/captions/track1" {
+				body = "1\n00:00:01,000 --> 00:00:03,000\nhi there\n"
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+		})))
+	srv := New(client, WithGraphQL())
+
+	rec := graphqlQuery(t, srv, `{ video(id: "abcdefghijk") { languages { languageCode } transcript { text } } }`)
+	want := `{"data":{"video":{"languages":[{"languageCode":"en"}],"transcript":{"text":"hi there"}}}}`
+	if rec.Body.String() != want {
+		t.Errorf("body = %s; want %s", rec.Body.String(), want)
+	}
+	if requests["/watch"] != 0 || requests["/youtube/v3/captions"] != 1 || requests["/youtube/v3/captions/track1"] != 1 {
+		t.Errorf("requests = %v; want tracks listed and downloaded through the backend only", requests)
+	}
+}
+
+func TestGraphQL_MaxDepth(t *testing.T) {
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithGraphQL())
+
+	rec := graphqlQuery(t, srv, `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { name } } } } } } } } }`)
+	if !strings.Contains(rec.Body.String(), "exceeds max depth") {
+		t.Errorf("body = %s; want the query rejected for its depth", rec.Body.String())
+	}
+}
+
+func TestGraphQLRequest_MaxFetches(t *testing.T) {
+	req := newGraphQLRequest()
+	fn := func() (interface{}, error) { return nil, nil }
+	for i := 0; i < graphqlMaxFetches; i++ {
+		if _, err := req.fetch(req.pages, strconv.Itoa(i), fn); err != nil {
+			t.Fatalf("fetch %d error = %v", i, err)
+		}
+	}
+	if _, err := req.fetch(req.pages, "0", fn); err != nil {
+		t.Errorf("repeated fetch error = %v; want the shared result", err)
+	}
+	if _, err := req.fetch(req.entries, "more", fn); err != errTooComplex {
+		t.Errorf("fetch beyond the limit error = %v; want errTooComplex", err)
+	}
+}
+
+func TestGraphQL_Disabled(t *testing.T) {
+	rec := graphqlQuery(t, newTestServer(), `{ video(id: "abcdefghijk") { id } }`)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d; want 404 when GraphQL is disabled", rec.Code)
+	}
+}
@@ -0,0 +1,23 @@
+package server
+
+import "net/http"
+
+// Drain marks the server as shutting down: /readyz starts failing so that load balancers
+// stop routing new traffic, while in-flight and health requests are still served
+func (s *Server) Drain() {
+	s.draining.Store(true)
+}
+
+// handleHealthz reports that the process is alive
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReadyz reports whether the server should receive traffic
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if s.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "draining"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
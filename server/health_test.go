@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHealthAndReadiness(t *testing.T) {
+	srv := newTestServer()
+
+	get := func(path string) int {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	if code := get("/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz = %d; want 200", code)
+	}
+	if code := get("/readyz"); code != http.StatusOK {
+		t.Errorf("/readyz = %d; want 200", code)
+	}
+
+	srv.Drain()
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz while draining = %d; want 503", code)
+	}
+	if code := get("/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz while draining = %d; want 200", code)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	srv := New(nil, WithRateLimit(0.001, 1))
+	for i := 0; i < 2; i++ {
+		srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/videos/nope/languages", nil))
+	}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`ytwords_http_requests_total{code="400",route="GET /v1/videos/{id}/languages"} 1`,
+		`ytwords_rate_limited_total 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output does not contain %q", want)
+		}
+	}
+}
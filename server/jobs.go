@@ -0,0 +1,171 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mjlefevre/yt-words-go/jobs"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// WithJobs serves the batch job API backed by queue:
+//
+//	POST /v1/jobs              {"videoIds": [...], "lang": "en"}
+//	POST /v1/jobs              {"playlist": "PL...", "limit": 500} or {"channel": "@handle"}
+//	GET  /v1/jobs
+//	GET  /v1/jobs/{id}
+//	GET  /v1/jobs/{id}/results
+//
+// A playlist or channel is expanded into its videos when the job is submitted, up to limit
+// or MaxJobVideos. The queue's workers must be run separately with jobs.Queue.Run.
+func WithJobs(queue *jobs.Queue) Option {
+	return func(s *Server) {
+		s.jobs = queue
+	}
+}
+
+// MaxJobVideos bounds the videos a playlist or channel job is expanded into
+const MaxJobVideos = 1000
+
+func (s *Server) registerJobRoutes() {
+	s.mux.HandleFunc("POST /v1/jobs", s.handleCreateJob)
+	s.mux.HandleFunc("GET /v1/jobs", s.handleListJobs)
+	s.mux.HandleFunc("GET /v1/jobs/{id}", s.handleGetJob)
+	s.mux.HandleFunc("GET /v1/jobs/{id}/results", s.handleJobResults)
+}
+
+func (s *Server) handleCreateJob(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		VideoIDs []string `json:"videoIds"`
+		Playlist string   `json:"playlist"`
+		Channel  string   `json:"channel"`
+		Limit    int      `json:"limit"`
+		Lang     string   `json:"lang"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid JSON body: "+err.Error())
+		return
+	}
+	if len(req.VideoIDs) > MaxRequestVideos {
+		writeError(w, http.StatusBadRequest, "too_many_videos", fmt.Sprintf("at most %d videoIds per job", MaxRequestVideos))
+		return
+	}
+	// Invalid requests are rejected before they cost the client any tokens
+	videoIDs := make([]string, 0, len(req.VideoIDs))
+	for _, input := range req.VideoIDs {
+		videoID, err := transcript.ExtractVideoID(input)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_video_id", "invalid YouTube video ID: "+input)
+			return
+		}
+		videoIDs = append(videoIDs, videoID)
+	}
+	if req.Playlist != "" || req.Channel != "" {
+		// Listing costs upstream requests too, so a client already out of tokens is turned away first
+		if !s.ready(w, r) {
+			return
+		}
+		listed, ok := s.expandJobSource(w, r, req.Playlist, req.Channel, req.Limit)
+		if !ok {
+			return
+		}
+		videoIDs = append(videoIDs, listed...)
+	}
+	if len(videoIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "videoIds must not be empty")
+		return
+	}
+	if !s.charge(w, r, len(videoIDs)) {
+		return
+	}
+
+	job, err := s.jobs.Enqueue(videoIDs, req.Lang)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return
+	}
+	w.Header().Set("Location", "/v1/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// expandJobSource lists the videos of a playlist and of the uploads of a channel, at most
+// limit (or MaxJobVideos) of each, writing the error response when listing fails
+func (s *Server) expandJobSource(w http.ResponseWriter, r *http.Request, playlist, channel string, limit int) ([]string, bool) {
+	if limit <= 0 || limit > MaxJobVideos {
+		limit = MaxJobVideos
+	}
+
+	var playlistIDs []string
+	if playlist != "" {
+		playlistID := transcript.ExtractPlaylistID(playlist)
+		if playlistID == "" {
+			writeError(w, http.StatusBadRequest, "invalid_playlist", "invalid YouTube playlist URL or ID: "+playlist)
+			return nil, false
+		}
+		playlistIDs = append(playlistIDs, playlistID)
+	}
+	if channel != "" {
+		channelID, err := s.client.ResolveChannelIDContext(r.Context(), channel)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_channel", err.Error())
+			return nil, false
+		}
+		playlistIDs = append(playlistIDs, transcript.UploadsPlaylistID(channelID))
+	}
+
+	var videoIDs []string
+	for _, playlistID := range playlistIDs {
+		videos, err := s.client.GetPlaylistVideosContext(r.Context(), playlistID)
+		if err != nil {
+			writeFetchError(w, err)
+			return nil, false
+		}
+		for i, v := range videos {
+			if i >= limit {
+				break
+			}
+			videoIDs = append(videoIDs, v.VideoID)
+		}
+	}
+	return videoIDs, true
+}
+
+func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	list, err := s.jobs.List(100)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return
+	}
+	if list == nil {
+		list = []jobs.Job{}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleJobResults(w http.ResponseWriter, r *http.Request) {
+	items, err := s.jobs.Items(r.PathValue("id"))
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func writeJobError(w http.ResponseWriter, err error) {
+	if errors.Is(err, jobs.ErrJobNotFound) {
+		writeError(w, http.StatusNotFound, "job_not_found", err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+}
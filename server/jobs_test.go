@@ -0,0 +1,119 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/jobs"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestJobsAPI(t *testing.T) {
+	queue, err := jobs.Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("jobs.Open() error = %v", err)
+	}
+	defer queue.Close()
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithJobs(queue))
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/jobs",
+		strings.NewReader(`{"videoIds":["https://youtu.be/abcdefghijk","bcdefghijkl"]}`)))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("POST /v1/jobs = %d %s; want 202", rec.Code, rec.Body.String())
+	}
+
+	var job jobs.Job
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+		t.Fatalf("invalid job JSON: %v", err)
+	}
+	if job.Total != 2 || job.Status != jobs.StatusPending {
+		t.Errorf("created job = %+v; want 2 pending items", job)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/v1/jobs/"+job.ID {
+		t.Errorf("Location = %q", loc)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/jobs/"+job.ID+"/results", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"videoId":"abcdefghijk","status":"pending"`) {
+		t.Errorf("GET results = %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/jobs/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET missing job = %d; want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(`{"videoIds":[]}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("POST empty job = %d; want 400", rec.Code)
+	}
+}
+
+func TestJobsAPI_Playlist(t *testing.T) {
+	queue, err := jobs.Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("jobs.Open() error = %v", err)
+	}
+	defer queue.Close()
+	page := `<html><script>var ytInitialData = {"contents":{"playlistVideoListRenderer":{"contents":[` +
+		`{"playlistVideoRenderer":{"videoId":"abcdefghijk","title":{"simpleText":"First"}}},` +
+		`{"playlistVideoRenderer":{"videoId":"bcdefghijkl","title":{"simpleText":"Second"}}},` +
+		`{"playlistVideoRenderer":{"videoId":"cdefghijklm","title":{"simpleText":"Third"}}}]}}};</script></html>`
+	client := transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/playlist" {
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(page)), Request: r}, nil
+		}
+		return fakeTransport(r)
+	})))
+	srv := New(client, WithJobs(queue))
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/jobs",
+		strings.NewReader(`{"playlist":"https://www.youtube.com/playlist?list=PLabcdefghijklmnop","limit":2}`)))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("POST /v1/jobs = %d %s; want 202", rec.Code, rec.Body.String())
+	}
+	var job jobs.Job
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+		t.Fatalf("invalid job JSON: %v", err)
+	}
+	if job.Total != 2 {
+		t.Errorf("playlist job = %+v; want the first 2 videos", job)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(`{"playlist":"not a playlist"}`)))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "invalid_playlist") {
+		t.Errorf("POST invalid playlist = %d %s; want 400 invalid_playlist", rec.Code, rec.Body.String())
+	}
+}
+
+func TestJobsAPI_InvalidRequestsNotCharged(t *testing.T) {
+	queue, err := jobs.Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("jobs.Open() error = %v", err)
+	}
+	defer queue.Close()
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithJobs(queue), WithRateLimit(0.001, 1))
+
+	var codes []int
+	for _, body := range []string{`{"videoIds":`, `{"videoIds":["abcdefghijk","not a video"]}`, `{"videoIds":[]}`, `{"videoIds":["abcdefghijk"]}`} {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(body)))
+		codes = append(codes, rec.Code)
+	}
+	want := []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusAccepted}
+	if fmt.Sprint(codes) != fmt.Sprint(want) {
+		t.Errorf("status codes = %v; want %v, the invalid requests costing no token", codes, want)
+	}
+}
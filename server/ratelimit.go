@@ -0,0 +1,169 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket refilled continuously at the limiter's rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps one token bucket per client key
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens per second
+	burst   float64
+	buckets map[string]*bucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes a token for key. When none is available it reports how long until one will be.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	return l.allowN(key, 1)
+}
+
+// allowN takes n tokens for key. A request costing more than the burst is let through with a
+// full bucket and leaves it in debt, so it is slow to repeat rather than impossible.
+func (l *rateLimiter) allowN(key string, n int) (bool, time.Duration) {
+	if n <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	need := math.Min(float64(n), l.burst)
+	if b.tokens >= need {
+		b.tokens -= float64(n)
+		return true, 0
+	}
+	return false, time.Duration((need - b.tokens) / l.rate * float64(time.Second))
+}
+
+// ready reports whether key has a token left without taking it, or how long until it will
+func (l *rateLimiter) ready(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		return true, 0
+	}
+	tokens := math.Min(l.burst, b.tokens+l.now().Sub(b.last).Seconds()*l.rate)
+	if tokens >= 1 {
+		return true, 0
+	}
+	return false, time.Duration((1 - tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops buckets that have been idle long enough to be full again, at most once a minute
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < time.Minute {
+		return
+	}
+	l.swept = now
+
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > full {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// clientKey identifies the caller by API key when it supplies one of the keys given to
+// WithAPIKeys, otherwise by remote IP, so made-up keys cannot buy fresh buckets
+func (s *Server) clientKey(r *http.Request) string {
+	key := r.Header.Get("X-API-Key")
+	if auth := r.Header.Get("Authorization"); key == "" && strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key != "" && s.apiKeys[key] {
+		return "key:" + key
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// rateLimited rejects the request with 429 if its client has exhausted its bucket
+func (s *Server) rateLimited(w http.ResponseWriter, r *http.Request) bool {
+	if s.limiter == nil {
+		return false
+	}
+
+	ok, wait := s.limiter.allow(s.clientKey(r))
+	if ok {
+		return false
+	}
+	s.tooManyRequests(w, wait)
+	return true
+}
+
+// charge takes n tokens from the client of a request that pays one per video and is exempt
+// from the per-request charge, rejecting it with 429 when they are not available
+func (s *Server) charge(w http.ResponseWriter, r *http.Request, n int) bool {
+	if s.limiter == nil {
+		return true
+	}
+	ok, wait := s.limiter.allowN(s.clientKey(r), n)
+	if !ok {
+		s.metrics.rateLimited.Inc()
+		s.tooManyRequests(w, wait)
+	}
+	return ok
+}
+
+// ready rejects the request with 429 if its client has no token left, without charging it,
+// before work whose cost is only known afterwards
+func (s *Server) ready(w http.ResponseWriter, r *http.Request) bool {
+	if s.limiter == nil {
+		return true
+	}
+	ok, wait := s.limiter.ready(s.clientKey(r))
+	if !ok {
+		s.metrics.rateLimited.Inc()
+		s.tooManyRequests(w, wait)
+	}
+	return ok
+}
+
+// tooManyRequests writes a 429 response asking the client to wait
+func (s *Server) tooManyRequests(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	writeError(w, http.StatusTooManyRequests, "rate_limited", "rate limit exceeded, retry later")
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := newRateLimiter(1, 2)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.allow("a"); !ok {
+			t.Fatalf("request %d within burst was rejected", i)
+		}
+	}
+	ok, wait := l.allow("a")
+	if ok {
+		t.Fatal("request beyond burst was allowed")
+	}
+	if wait != time.Second {
+		t.Errorf("wait = %v; want 1s", wait)
+	}
+	if ok, _ := l.allow("b"); !ok {
+		t.Error("other client was rejected")
+	}
+
+	now = now.Add(time.Second)
+	if ok, _ := l.allow("a"); !ok {
+		t.Error("request after refill was rejected")
+	}
+}
+
+func TestRateLimiter_AllowN(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := newRateLimiter(1, 2)
+	l.now = func() time.Time { return now }
+
+	if ok, _ := l.allowN("a", 5); !ok {
+		t.Fatal("request costing more than the burst was rejected with a full bucket")
+	}
+	ok, wait := l.allow("a")
+	if ok {
+		t.Fatal("request was allowed while the bucket is in debt")
+	}
+	if wait != 4*time.Second {
+		t.Errorf("wait = %v; want 4s", wait)
+	}
+	if ok, _ := l.ready("a"); ok {
+		t.Error("ready() = true while the bucket is in debt")
+	}
+	if ok, _ := l.ready("b"); !ok {
+		t.Error("ready() = false for a new client")
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	srv := New(nil, WithAPIKeys("secret"))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	if key := srv.clientKey(r); key != "ip:10.0.0.1" {
+		t.Errorf("clientKey() = %q; want ip:10.0.0.1", key)
+	}
+
+	r.Header.Set("X-API-Key", "made-up")
+	if key := srv.clientKey(r); key != "ip:10.0.0.1" {
+		t.Errorf("clientKey() with an unknown key = %q; want ip:10.0.0.1", key)
+	}
+
+	r.Header.Del("X-API-Key")
+	r.Header.Set("Authorization", "Bearer secret")
+	if key := srv.clientKey(r); key != "key:secret" {
+		t.Errorf("clientKey() = %q; want key:secret", key)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	srv := New(nil, WithRateLimit(0.001, 1))
+
+	var codes []int
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/videos/nope/languages", nil))
+		codes = append(codes, rec.Code)
+		if rec.Code == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+			t.Error("429 response has no Retry-After header")
+		}
+	}
+
+	if codes[0] != http.StatusBadRequest || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("status codes = %v; want [400 429]", codes)
+	}
+}
+
+func TestServer_RateLimitPerVideo(t *testing.T) {
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithRateLimit(0.001, 2))
+
+	var codes []int
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/batch/transcripts/stream?ids=abcdefghijk,bcdefghijkl,cdefghijklm", nil))
+		codes = append(codes, rec.Code)
+	}
+	// The first batch is let through with a full bucket but leaves it in debt
+	if codes[0] == http.StatusTooManyRequests || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("status codes = %v; want the second batch of three videos rate limited", codes)
+	}
+}
+
+func TestServer_TooManyVideos(t *testing.T) {
+	ids := strings.TrimSuffix(strings.Repeat("abcdefghijk,", MaxRequestVideos+1), ",")
+	rec := httptest.NewRecorder()
+	newTestServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/batch/transcripts/stream?ids="+ids, nil))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "too_many_videos") {
+		t.Errorf("status = %d, body %s; want 400 too_many_videos", rec.Code, rec.Body.String())
+	}
+}
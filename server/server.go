@@ -0,0 +1,295 @@
+// Package server implements the yt-words HTTP API.
+//
+//	GET /healthz, /readyz, /metrics
+//	GET /v1/videos/{id}/transcript?lang=en&format=json|srt|vtt|text
+//	GET /v1/videos/{id}/languages
+//	GET /v1/videos/{id}/transcript/stream?lang=en        (Server-Sent Events)
+//	GET /v1/batch/transcripts/stream?ids=a,b,c&lang=en   (Server-Sent Events)
+//	POST /v1/graphql                                     (when enabled with WithGraphQL)
+//	POST /v1/jobs, GET /v1/jobs/{id}[/results]           (when enabled with WithJobs)
+//
+// Failures are reported as JSON bodies of the form {"error": {"code": ..., "message": ...}}.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/jobs"
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// Server serves transcripts fetched through a transcript.Client
+type Server struct {
+	client  *transcript.Client
+	mux     *http.ServeMux
+	graphql bool
+	cache   *responseCache
+	limiter *rateLimiter
+	apiKeys map[string]bool
+	metrics *metrics
+	jobs    *jobs.Queue
+
+	draining atomic.Bool
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithGraphQL additionally serves the GraphQL schema at POST /v1/graphql
+func WithGraphQL() Option {
+	return func(s *Server) {
+		s.graphql = true
+	}
+}
+
+// WithCache caches rendered transcripts in memory, keyed by video, language and format.
+// Entries expire after ttl; the least recently used are evicted beyond maxEntries (0 means unbounded).
+func WithCache(ttl time.Duration, maxEntries int) Option {
+	return func(s *Server) {
+		s.cache = newResponseCache(ttl, maxEntries)
+	}
+}
+
+// WithRateLimit limits each client, identified by remote IP or one of the keys of WithAPIKeys,
+// to rate requests per second with bursts of up to burst requests. Requests for several
+// videos cost one request per video.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(s *Server) {
+		s.limiter = newRateLimiter(rate, burst)
+	}
+}
+
+// WithAPIKeys gives the callers presenting one of keys, as X-API-Key or bearer token, a rate
+// limit of their own instead of that of their IP
+func WithAPIKeys(keys ...string) Option {
+	return func(s *Server) {
+		if s.apiKeys == nil {
+			s.apiKeys = make(map[string]bool, len(keys))
+		}
+		for _, key := range keys {
+			s.apiKeys[key] = true
+		}
+	}
+}
+
+// MaxRequestVideos bounds the videos a single batch stream, job, GraphQL query or gRPC batch may ask for
+const MaxRequestVideos = 100
+
+// New creates a Server backed by client
+func New(client *transcript.Client, options ...Option) *Server {
+	s := &Server{client: client, mux: http.NewServeMux(), metrics: newMetrics()}
+	for _, opt := range options {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /healthz", s.handleHealthz)
+	s.mux.HandleFunc("GET /readyz", s.handleReadyz)
+	s.mux.Handle("GET /metrics", s.metrics.handler())
+	s.mux.HandleFunc("GET /v1/videos/{id}/transcript", s.handleTranscript)
+	s.mux.HandleFunc("GET /v1/videos/{id}/languages", s.handleLanguages)
+	s.mux.HandleFunc("GET /v1/videos/{id}/transcript/stream", s.handleTranscriptStream)
+	s.mux.HandleFunc("GET /v1/batch/transcripts/stream", s.handleBatchStream)
+	if s.graphql {
+		s.mux.Handle("POST /v1/graphql", s.graphqlHandler())
+	}
+	if s.jobs != nil {
+		s.registerJobRoutes()
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w}
+	defer func() {
+		s.metrics.observe(r, rec.status, time.Since(start))
+	}()
+
+	// Probes and scrapes must never be rate limited, and batches charge per video themselves
+	switch {
+	case r.URL.Path == "/healthz", r.URL.Path == "/readyz", r.URL.Path == "/metrics":
+	case r.URL.Path == "/v1/batch/transcripts/stream", r.Method == http.MethodPost && r.URL.Path == "/v1/jobs":
+	default:
+		if s.rateLimited(rec, r) {
+			s.metrics.rateLimited.Inc()
+			return
+		}
+	}
+	s.mux.ServeHTTP(rec, r)
+}
+
+// track is the JSON representation of an available caption track
+type track struct {
+	LanguageCode string `json:"languageCode"`
+	Language     string `json:"language"`
+	IsGenerated  bool   `json:"isGenerated"`
+}
+
+func (s *Server) handleTranscript(w http.ResponseWriter, r *http.Request) {
+	videoID, ok := videoIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	format := transcript.FormatJSON
+	if name := r.URL.Query().Get("format"); name != "" {
+		f, err := transcript.ParseFormat(name)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_format", err.Error())
+			return
+		}
+		format = f
+	}
+
+	lang := r.URL.Query().Get("lang")
+	cacheKey := videoID + "|" + lang + "|" + string(format)
+	if s.cache != nil {
+		if resp, ok := s.cache.get(cacheKey); ok {
+			w.Header().Set("Content-Type", resp.contentType)
+			w.Header().Set("X-Cache", "HIT")
+			s.metrics.cacheHits.Inc()
+			w.Write(resp.body)
+			return
+		}
+	}
+
+	// The upstream fetch stops when the client disconnects or the server shuts down
+	var opts transcript.FetchOptions
+	if lang != "" {
+		opts.Languages = []string{lang}
+	}
+	result, err := s.client.GetTranscriptWithOptions(r.Context(), videoID, opts)
+	if err != nil {
+		writeFetchError(w, err)
+		return
+	}
+	entries := result.Entries
+
+	var body bytes.Buffer
+	if err := transcript.WriteFormat(&body, format, entries); err != nil {
+		writeError(w, http.StatusInternalServerError, "format_error", err.Error())
+		return
+	}
+	if s.cache != nil {
+		s.cache.set(cacheKey, cachedResponse{contentType: format.ContentType(), body: body.Bytes()})
+		w.Header().Set("X-Cache", "MISS")
+		s.metrics.cacheMisses.Inc()
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Write(body.Bytes())
+}
+
+func (s *Server) handleLanguages(w http.ResponseWriter, r *http.Request) {
+	videoID, ok := videoIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	transcripts, err := s.client.ListAvailableTranscriptsContext(r.Context(), videoID)
+	if err != nil {
+		writeFetchError(w, err)
+		return
+	}
+
+	tracks := make([]track, 0, len(transcripts))
+	for _, t := range transcripts {
+		tracks = append(tracks, track{LanguageCode: t.LanguageCode, Language: t.Language, IsGenerated: t.IsGenerated})
+	}
+	writeJSON(w, http.StatusOK, tracks)
+}
+
+// videoIDParam extracts and validates the {id} path segment, writing a 400 on failure
+func videoIDParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	videoID, err := transcript.ExtractVideoID(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_video_id", "invalid YouTube video ID: "+r.PathValue("id"))
+		return "", false
+	}
+	return videoID, true
+}
+
+// errorBody is the JSON envelope for all error responses
+type errorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	var body errorBody
+	body.Error.Code = code
+	body.Error.Message = message
+	writeJSON(w, status, body)
+}
+
+// writeFetchError maps the transcript package's typed errors onto HTTP statuses
+func writeFetchError(w http.ResponseWriter, err error) {
+	status, code := errorStatus(err)
+	if wait := retryAfter(err); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	}
+	writeError(w, status, code, err.Error())
+}
+
+// upstreamRetryAfter is the Retry-After of a 429 from YouTube, which tells us no wait
+const upstreamRetryAfter = time.Minute
+
+// retryAfter returns how long a client should wait before retrying after err, or 0
+func retryAfter(err error) time.Duration {
+	var (
+		circuitOpen transcript.ErrCircuitOpen
+		overBudget  transcript.ErrBudgetExceeded
+	)
+	switch {
+	case errors.As(err, new(transcript.ErrTooManyRequests)):
+		return upstreamRetryAfter
+	case errors.As(err, &circuitOpen):
+		return max(time.Until(circuitOpen.Until), time.Second)
+	case errors.As(err, &overBudget):
+		return max(time.Until(overBudget.ResetAt), time.Second)
+	}
+	return 0
+}
+
+func errorStatus(err error) (int, string) {
+	switch {
+	case errors.As(err, new(*transcript.ErrVideoUnavailable)), errors.As(err, new(transcript.ErrVideoUnavailable)):
+		return http.StatusNotFound, "video_unavailable"
+	case errors.As(err, new(transcript.ErrNoTranscriptFound)), errors.As(err, new(*transcript.ErrNoTranscriptFound)):
+		return http.StatusNotFound, "no_transcript"
+	case errors.As(err, new(transcript.ErrEmptyTranscript)):
+		return http.StatusNotFound, "empty_transcript"
+	case errors.As(err, new(transcript.ErrTranscriptsDisabled)), errors.As(err, new(*transcript.ErrTranscriptsDisabled)):
+		return http.StatusForbidden, "transcripts_disabled"
+	case errors.As(err, new(transcript.ErrMembersOnly)):
+		return http.StatusForbidden, "members_only"
+	case errors.As(err, new(transcript.ErrRegionBlocked)):
+		return http.StatusUnavailableForLegalReasons, "region_blocked"
+	case errors.As(err, new(transcript.ErrNotYetAvailable)):
+		return http.StatusServiceUnavailable, "not_yet_available"
+	case errors.As(err, new(transcript.ErrTooManyRequests)):
+		return http.StatusTooManyRequests, "rate_limited"
+	case errors.As(err, new(transcript.ErrCircuitOpen)):
+		return http.StatusServiceUnavailable, "circuit_open"
+	case errors.As(err, new(transcript.ErrBudgetExceeded)):
+		return http.StatusServiceUnavailable, "budget_exceeded"
+	default:
+		return http.StatusBadGateway, "upstream_error"
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+const watchPage = `{"videoDetails":{"videoId":"abcdefghijk","title":"Test video","author":"Tester","lengthSeconds":"3"},"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?lang=en","name":{"simpleText":"English"},"languageCode":"en"}]}}}`
+
+const captionXML = `<transcript><text start="1" dur="2">hi there</text></transcript>`
+
+// fakeTransport serves a single English track for every video except "unavailabl1"
+func fakeTransport(r *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, watchPage
+	switch {
+	case r.URL.Path == "/api/timedtext":
+		body = captionXML
+	case r.URL.Query().Get("v") == "unavailabl1":
+		status, body = http.StatusNotFound, ""
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func newTestServer() *Server {
+	return New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))))
+}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name:        "JSON transcript",
+			path:        "/v1/videos/abcdefghijk/transcript",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `[{"text":"hi there","start":1,"duration":2}]` + "\n",
+		},
+		{
+			name:        "SRT transcript",
+			path:        "/v1/videos/abcdefghijk/transcript?format=srt&lang=en",
+			status:      http.StatusOK,
+			contentType: "application/x-subrip; charset=utf-8",
+			body:        "1\n00:00:01,000 --> 00:00:03,000\nhi there\n\n",
+		},
+		{
+			name:        "Languages",
+			path:        "/v1/videos/abcdefghijk/languages",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `[{"languageCode":"en","language":"English","isGenerated":false}]` + "\n",
+		},
+		{
+			name:        "Missing language",
+			path:        "/v1/videos/abcdefghijk/transcript?lang=fr",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"error":{"code":"no_transcript","message":"No transcript found for video abcdefghijk in language fr"}}` + "\n",
+		},
+		{
+			name:        "Unavailable video",
+			path:        "/v1/videos/unavailabl1/transcript",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"error":{"code":"video_unavailable","message":"Video unavailabl1 is unavailable"}}` + "\n",
+		},
+		{
+			name:        "Invalid format",
+			path:        "/v1/videos/abcdefghijk/transcript?format=docx",
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"error":{"code":"invalid_format","message":"unknown format: docx"}}` + "\n",
+		},
+	}
+
+	srv := newTestServer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d; want %d", rec.Code, tt.status)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Content-Type = %q; want %q", ct, tt.contentType)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("body = %q; want %q", rec.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+func TestServer_InvalidVideoID(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/videos/nope/languages", nil))
+
+	var body errorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", rec.Body.String(), err)
+	}
+	if rec.Code != http.StatusBadRequest || body.Error.Code != "invalid_video_id" {
+		t.Errorf("got %d %q; want 400 invalid_video_id", rec.Code, body.Error.Code)
+	}
+}
+
+func TestServer_CancelledRequest(t *testing.T) {
+	var upstream int
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		upstream++
+		return fakeTransport(r)
+	}))))
+
+	for _, path := range []string{"/v1/videos/abcdefghijk/transcript", "/v1/videos/abcdefghijk/languages"} {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx))
+	}
+	if upstream != 0 {
+		t.Errorf("made %d upstream requests for cancelled requests; want 0", upstream)
+	}
+}
+
+func TestWriteFetchError_RateLimits(t *testing.T) {
+	tests := []struct {
+		err        error
+		status     int
+		code       string
+		retryAfter string
+	}{
+		{transcript.ErrTooManyRequests{VideoID: "abcdefghijk"}, http.StatusTooManyRequests, "rate_limited", "60"},
+		{transcript.ErrCircuitOpen{Until: time.Now().Add(30 * time.Second)}, http.StatusServiceUnavailable, "circuit_open", "30"},
+		{transcript.ErrBudgetExceeded{Max: 10, Per: time.Hour, ResetAt: time.Now().Add(-time.Second)}, http.StatusServiceUnavailable, "budget_exceeded", "1"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		writeFetchError(rec, tt.err)
+
+		var body errorBody
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		if rec.Code != tt.status || body.Error.Code != tt.code || rec.Header().Get("Retry-After") != tt.retryAfter {
+			t.Errorf("writeFetchError(%v) = %d %q, Retry-After %q; want %d %q, Retry-After %q",
+				tt.err, rec.Code, body.Error.Code, rec.Header().Get("Retry-After"), tt.status, tt.code, tt.retryAfter)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// batchConcurrency is the number of videos fetched in parallel by the batch stream
+const batchConcurrency = 4
+
+// sseWriter writes Server-Sent Events, flushing after each one
+type sseWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// newSSEWriter sets the event-stream headers, or writes a 500 if the connection cannot stream
+func newSSEWriter(w http.ResponseWriter) (*sseWriter, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming_unsupported", "streaming is not supported by this connection")
+		return nil, false
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &sseWriter{w: w, flusher: flusher}, true
+}
+
+// send writes one event whose data is v encoded as JSON
+func (s *sseWriter) send(event string, v interface{}) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "event: %s\ndata: ", event)
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+
+	if _, err := s.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// entry is the JSON representation of a streamed transcript entry
+type entry struct {
+	VideoID  string  `json:"videoId,omitempty"`
+	Text     string  `json:"text"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
+}
+
+// errorEvent carries the same code and message as an HTTP error body
+type errorEvent struct {
+	VideoID string `json:"videoId,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newErrorEvent(videoID string, err error) errorEvent {
+	_, code := errorStatus(err)
+	return errorEvent{VideoID: videoID, Code: code, Message: err.Error()}
+}
+
+// handleTranscriptStream emits an "entry" event per cue as it is decoded, then "done" or "error"
+func (s *Server) handleTranscriptStream(w http.ResponseWriter, r *http.Request) {
+	videoID, ok := videoIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	sse, ok := newSSEWriter(w)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	count := 0
+	err := s.client.StreamTranscriptContext(ctx, videoID, r.URL.Query().Get("lang"), func(e transcript.TranscriptEntry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		count++
+		return sse.send("entry", entry{Text: e.Text, Start: e.Start, Duration: e.Duration})
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		sse.send("error", newErrorEvent(videoID, err))
+		return
+	}
+
+	sse.send("done", map[string]interface{}{"videoId": videoID, "entries": count})
+}
+
+// progress reports that one video of a batch has finished
+type progress struct {
+	VideoID string `json:"videoId"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Entries int    `json:"entries"`
+	Done    int    `json:"done"`
+	Total   int    `json:"total"`
+}
+
+// handleBatchStream fetches ?ids=a,b,c concurrently. Entries of each video are emitted as "entry"
+// events tagged with the video ID, followed by a "progress" event once that video completes.
+func (s *Server) handleBatchStream(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	for _, input := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if input = strings.TrimSpace(input); input != "" {
+			ids = append(ids, input)
+		}
+	}
+	if len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "ids query parameter is required")
+		return
+	}
+	if len(ids) > MaxRequestVideos {
+		writeError(w, http.StatusBadRequest, "too_many_videos", fmt.Sprintf("at most %d ids per request", MaxRequestVideos))
+		return
+	}
+	// Invalid requests are rejected before they cost the client any tokens
+	for i, input := range ids {
+		videoID, err := transcript.ExtractVideoID(input)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_video_id", "invalid YouTube video ID: "+input)
+			return
+		}
+		ids[i] = videoID
+	}
+	if !s.charge(w, r, len(ids)) {
+		return
+	}
+
+	sse, ok := newSSEWriter(w)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	lang := r.URL.Query().Get("lang")
+	var (
+		mu   sync.Mutex
+		done int
+		wg   sync.WaitGroup
+	)
+	sem := make(chan struct{}, batchConcurrency)
+
+	for _, videoID := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(videoID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			p := progress{VideoID: videoID, Status: "ok", Total: len(ids)}
+			// Buffer the video's entries so that events of concurrent fetches never interleave
+			var entries []entry
+			err := s.client.StreamTranscriptContext(ctx, videoID, lang, func(e transcript.TranscriptEntry) error {
+				entries = append(entries, entry{VideoID: videoID, Text: e.Text, Start: e.Start, Duration: e.Duration})
+				return ctx.Err()
+			})
+			p.Entries = len(entries)
+
+			mu.Lock()
+			for _, e := range entries {
+				sse.send("entry", e)
+			}
+			mu.Unlock()
+			if err != nil {
+				p.Status, p.Error = "error", err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			p.Done = done
+			sse.send("progress", p)
+		}(videoID)
+	}
+
+	wg.Wait()
+	if ctx.Err() == nil {
+		sse.send("done", map[string]int{"total": len(ids), "done": done})
+	}
+}
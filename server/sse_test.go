@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// eventNames returns the event types of an SSE body in order
+func eventNames(body string) []string {
+	var names []string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "event: ") {
+			names = append(names, strings.TrimPrefix(line, "event: "))
+		}
+	}
+	return names
+}
+
+func TestTranscriptStream(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/videos/abcdefghijk/transcript/stream", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q; want text/event-stream", ct)
+	}
+
+	want := "event: entry\ndata: {\"text\":\"hi there\",\"start\":1,\"duration\":2}\n\n" +
+		"event: done\ndata: {\"entries\":1,\"videoId\":\"abcdefghijk\"}\n\n"
+	if rec.Body.String() != want {
+		t.Errorf("body = %q; want %q", rec.Body.String(), want)
+	}
+}
+
+func TestTranscriptStream_Error(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/videos/unavailabl1/transcript/stream", nil))
+
+	if names := eventNames(rec.Body.String()); len(names) != 1 || names[0] != "error" {
+		t.Errorf("events = %v; want [error]", names)
+	}
+	if !strings.Contains(rec.Body.String(), `"code":"video_unavailable"`) {
+		t.Errorf("body %q does not contain the error code", rec.Body.String())
+	}
+}
+
+func TestBatchStream(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/batch/transcripts/stream?ids=abcdefghijk,unavailabl1", nil))
+
+	counts := make(map[string]int)
+	for _, name := range eventNames(rec.Body.String()) {
+		counts[name]++
+	}
+	if counts["entry"] != 1 || counts["progress"] != 2 || counts["done"] != 1 {
+		t.Errorf("event counts = %v; want 1 entry, 2 progress, 1 done", counts)
+	}
+	if !strings.Contains(rec.Body.String(), `"videoId":"unavailabl1","status":"error"`) {
+		t.Errorf("body %q does not report the failed video", rec.Body.String())
+	}
+}
+
+func TestBatchStream_MissingIDs(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/batch/transcripts/stream", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d; want 400", rec.Code)
+	}
+}
+
+func TestBatchStream_InvalidIDsNotCharged(t *testing.T) {
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(fakeTransport))), WithRateLimit(0.001, 1))
+
+	var codes []int
+	for _, path := range []string{"/v1/batch/transcripts/stream", "/v1/batch/transcripts/stream?ids=abcdefghijk,nope", "/v1/batch/transcripts/stream?ids=abcdefghijk"} {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		codes = append(codes, rec.Code)
+		if strings.Contains(path, "nope") && !strings.Contains(rec.Body.String(), "invalid_video_id") {
+			t.Errorf("GET %s = %s; want invalid_video_id", path, rec.Body.String())
+		}
+	}
+	if fmt.Sprint(codes) != "[400 400 200]" {
+		t.Errorf("status codes = %v; want [400 400 200], the invalid requests costing no token", codes)
+	}
+}
+
+func TestTranscriptStream_Cancelled(t *testing.T) {
+	var upstream int
+	srv := New(transcript.NewClient(transcript.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		upstream++
+		return fakeTransport(r)
+	}))))
+
+	for _, path := range []string{"/v1/videos/abcdefghijk/transcript/stream", "/v1/batch/transcripts/stream?ids=abcdefghijk"} {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx))
+	}
+	if upstream != 0 {
+		t.Errorf("made %d upstream requests for closed streams; want 0", upstream)
+	}
+}
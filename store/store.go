@@ -0,0 +1,125 @@
+// Package store persists fetched transcripts on disk, one JSON file per video.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// ErrNotFound is returned by Get when the store has no record for a video
+var ErrNotFound = errors.New("transcript not in store")
+
+// Record is a stored transcript together with the metadata it was fetched with
+type Record struct {
+	VideoID      string    `json:"videoId"`
+	Title        string    `json:"title,omitempty"`
+	ChannelID    string    `json:"channelId,omitempty"`
+	Author       string    `json:"author,omitempty"`
+	LanguageCode string    `json:"languageCode,omitempty"`
+	IsGenerated  bool      `json:"isGenerated,omitempty"`
+	Published    time.Time `json:"published,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+	// Fingerprint is the transcript.Fingerprint of Entries, set by Put when empty
+	Fingerprint string                       `json:"fingerprint,omitempty"`
+	Entries     []transcript.TranscriptEntry `json:"entries"`
+}
+
+// Store is a directory of transcript records
+type Store struct {
+	dir string
+}
+
+// Open opens the store rooted at dir, creating the directory if needed
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating store directory: %v", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the directory the store was opened with
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Path returns the file a video's record is stored in
+func (s *Store) Path(videoID string) string {
+	return filepath.Join(s.dir, videoID+".json")
+}
+
+// Has reports whether a record exists for the video
+func (s *Store) Has(videoID string) bool {
+	_, err := os.Stat(s.Path(videoID))
+	return err == nil
+}
+
+// Get loads the record of a video
+func (s *Store) Get(videoID string) (Record, error) {
+	b, err := os.ReadFile(s.Path(videoID))
+	if errors.Is(err, os.ErrNotExist) {
+		return Record{}, ErrNotFound
+	}
+	if err != nil {
+		return Record{}, err
+	}
+
+	var rec Record
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return Record{}, fmt.Errorf("error decoding %s: %v", s.Path(videoID), err)
+	}
+	return rec, nil
+}
+
+// Put writes a record, replacing any previous one for the same video.
+// The file is written to a temporary name first so readers never see partial records.
+func (s *Store) Put(rec Record) error {
+	if rec.VideoID == "" || strings.ContainsAny(rec.VideoID, `/\`) {
+		return fmt.Errorf("invalid video ID for store: %q", rec.VideoID)
+	}
+	if rec.Fingerprint == "" {
+		rec.Fingerprint = transcript.Fingerprint(rec.Entries)
+	}
+
+	b, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, "."+rec.VideoID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path(rec.VideoID))
+}
+
+// List returns the IDs of all stored videos in sorted order
+func (s *Store) List() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(m), ".json"))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestStore(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "nested", "store"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if s.Has("abcdefghijk") {
+		t.Error("Has() = true for empty store")
+	}
+	if _, err := s.Get("abcdefghijk"); err != ErrNotFound {
+		t.Errorf("Get() error = %v; want ErrNotFound", err)
+	}
+
+	rec := Record{
+		VideoID:   "abcdefghijk",
+		Title:     "A video",
+		FetchedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Entries:   []transcript.TranscriptEntry{{Text: "hello", Start: 1, Duration: 2}},
+	}
+	if err := s.Put(rec); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := s.Put(Record{VideoID: "bcdefghijkl"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	got, err := s.Get("abcdefghijk")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Title != rec.Title || !got.FetchedAt.Equal(rec.FetchedAt) || len(got.Entries) != 1 || got.Entries[0] != rec.Entries[0] {
+		t.Errorf("Get() = %+v; want %+v", got, rec)
+	}
+	if got.Fingerprint != transcript.Fingerprint(rec.Entries) {
+		t.Errorf("Get().Fingerprint = %q; want %q", got.Fingerprint, transcript.Fingerprint(rec.Entries))
+	}
+
+	ids, err := s.List()
+	if err != nil || len(ids) != 2 || ids[0] != "abcdefghijk" || ids[1] != "bcdefghijkl" {
+		t.Errorf("List() = %v, %v", ids, err)
+	}
+
+	// No temporary files are left behind
+	files, _ := os.ReadDir(s.Dir())
+	if len(files) != 2 {
+		t.Errorf("store directory has %d files; want 2", len(files))
+	}
+}
+
+func TestStore_InvalidID(t *testing.T) {
+	s, _ := Open(t.TempDir())
+	if err := s.Put(Record{VideoID: "../escape"}); err == nil {
+		t.Error("Put() expected error for path-like video ID")
+	}
+}
@@ -0,0 +1,52 @@
+package summarize
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// BatchRequest is one line of an input file of the OpenAI Batch API
+type BatchRequest struct {
+	// CustomID is "<videoID>-<chunk index>", matching results to their chunk
+	CustomID string      `json:"custom_id"`
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Body     chatRequest `json:"body"`
+}
+
+// BatchRequests returns the chat completions requests Summarize would send for the chunks of
+// a transcript, to be run through the Batch API instead. As the partial summaries are only
+// known once the batch completes, combining them is left to the caller.
+func (c *Client) BatchRequests(videoID string, entries []transcript.TranscriptEntry) []BatchRequest {
+	chunks := transcript.ChunkEntries(entries, c.chunkChars)
+	if len(chunks) == 0 {
+		return nil
+	}
+	prompts := chunkPrompts(chunks)
+	requests := make([]BatchRequest, len(prompts))
+	for i, prompt := range prompts {
+		requests[i] = BatchRequest{
+			CustomID: fmt.Sprintf("%s-%d", videoID, i),
+			Method:   "POST",
+			URL:      "/v1/chat/completions",
+			Body:     c.chatRequest(prompt),
+		}
+	}
+	return requests
+}
+
+// WriteBatch writes requests as JSON Lines, the format of Batch API input files
+func WriteBatch(w io.Writer, requests []BatchRequest) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, r := range requests {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
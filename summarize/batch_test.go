@@ -0,0 +1,36 @@
+package summarize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+func TestBatchRequests(t *testing.T) {
+	entries := []transcript.TranscriptEntry{
+		{Text: "first part of the talk", Start: 0, Duration: 30},
+		{Text: "second part of the talk", Start: 65, Duration: 30},
+	}
+
+	client := New("", "test-model", WithChunkChars(25), WithSystemPrompt("Be brief."))
+	requests := client.BatchRequests("abcdefghijk", entries)
+	if len(requests) != 2 {
+		t.Fatalf("BatchRequests() = %+v; want 2 requests", requests)
+	}
+	if requests[1].CustomID != "abcdefghijk-1" || requests[1].Body.Model != "test-model" || requests[1].Body.Messages[0].Content != "Be brief." {
+		t.Errorf("requests[1] = %+v; want abcdefghijk-1 for test-model with the custom system prompt", requests[1])
+	}
+	if !strings.Contains(requests[1].Body.Messages[1].Content, "part 2 of 2") {
+		t.Errorf("prompt = %q; want it to name part 2 of 2", requests[1].Body.Messages[1].Content)
+	}
+
+	var sb strings.Builder
+	if err := WriteBatch(&sb, requests[:1]); err != nil {
+		t.Fatalf("WriteBatch() error = %v", err)
+	}
+	want := `{"custom_id":"abcdefghijk-0","method":"POST","url":"/v1/chat/completions","body":{"model":"test-model","messages":[{"role":"system","content":"Be brief."},{"role":"user","content":"Summarize part 1 of 2 of a transcript in a few sentences:\n\n[0:00-0:30] first part of the talk"}]}}` + "\n"
+	if sb.String() != want {
+		t.Errorf("WriteBatch() = %s; want %s", sb.String(), want)
+	}
+}
@@ -0,0 +1,193 @@
+// Package summarize condenses transcripts with any OpenAI-compatible chat completions endpoint.
+//
+// Long transcripts are split into chunks that are summarized one at a time; the partial summaries
+// are then merged into a final summary that cites the [m:ss] time ranges of the chunks it draws on.
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// DefaultChunkChars is the default amount of transcript text sent per request
+const DefaultChunkChars = 12000
+
+const systemPrompt = "You summarize video transcripts accurately and concisely. " +
+	"Sections are labeled with time ranges like [12:30-15:00]; when referring to a point, " +
+	"cite the start of its range in the form [12:30]. Never invent content."
+
+// Client calls a chat completions endpoint to summarize transcripts
+type Client struct {
+	baseURL    string
+	model      string
+	apiKey     string
+	system     string
+	chunkChars int
+	httpClient *http.Client
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithAPIKey sets the bearer token sent to the endpoint
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithSystemPrompt replaces the default system prompt, which asks for accurate summaries
+// citing the [m:ss] labels of the sections
+func WithSystemPrompt(prompt string) Option {
+	return func(c *Client) {
+		if prompt != "" {
+			c.system = prompt
+		}
+	}
+}
+
+// WithChunkChars sets the maximum transcript characters per request
+func WithChunkChars(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.chunkChars = n
+		}
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used for requests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// New creates a client for the endpoint at baseURL (e.g. https://api.openai.com/v1) using model
+func New(baseURL, model string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		model:      model,
+		system:     systemPrompt,
+		chunkChars: DefaultChunkChars,
+		httpClient: &http.Client{},
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+// Summarize returns a summary of entries with timestamped section references
+func (c *Client) Summarize(ctx context.Context, entries []transcript.TranscriptEntry) (string, error) {
+	chunks := transcript.ChunkEntries(entries, c.chunkChars)
+	if len(chunks) == 0 {
+		return "", fmt.Errorf("transcript is empty")
+	}
+
+	prompts := chunkPrompts(chunks)
+	if len(chunks) == 1 {
+		return c.complete(ctx, prompts[0])
+	}
+
+	partials := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		partial, err := c.complete(ctx, prompts[i])
+		if err != nil {
+			return "", fmt.Errorf("error summarizing part %d: %v", i+1, err)
+		}
+		partials = append(partials, label(chunk)+" "+partial)
+	}
+
+	return c.complete(ctx, "These are summaries of consecutive sections of one video. Combine them into "+
+		"a short overview followed by key points, each citing its timestamp:\n\n"+strings.Join(partials, "\n\n"))
+}
+
+// chunkPrompts asks for a summary of a lone chunk, or for a few sentences on each of several
+func chunkPrompts(chunks []transcript.Chunk) []string {
+	if len(chunks) == 1 {
+		return []string{"Summarize this transcript, as a short overview followed by key points " +
+			"with their timestamps:\n\n" + label(chunks[0]) + " " + chunks[0].Text}
+	}
+	prompts := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		prompts[i] = fmt.Sprintf("Summarize part %d of %d of a transcript in a few sentences:\n\n%s %s",
+			i+1, len(chunks), label(chunk), chunk.Text)
+	}
+	return prompts
+}
+
+// label renders the time range of a chunk as [m:ss-m:ss]
+func label(chunk transcript.Chunk) string {
+	return fmt.Sprintf("[%s-%s]", transcript.FormatClock(chunk.Start), transcript.FormatClock(chunk.End))
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// chatRequest pairs the system prompt with one user prompt
+func (c *Client) chatRequest(prompt string) chatRequest {
+	return chatRequest{
+		Model: c.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: c.system},
+			{Role: "user", Content: prompt},
+		},
+	}
+}
+
+// complete sends one user prompt and returns the assistant's reply
+func (c *Client) complete(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(c.chatRequest(prompt))
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("chat completions request failed: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	var out chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("error decoding chat completions response: %v", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("chat completions response has no choices")
+	}
+	return strings.TrimSpace(out.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,74 @@
+package summarize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// fakeEndpoint answers each chat request with "summary N" and records the prompts it received
+func fakeEndpoint(t *testing.T, prompts *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected request %s with Authorization %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding request: %v", err)
+		}
+		if req.Model != "test-model" || len(req.Messages) != 2 {
+			t.Errorf("request = %+v; want test-model with system and user messages", req)
+		}
+		*prompts = append(*prompts, req.Messages[1].Content)
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":"summary %d"}}]}`, len(*prompts))
+	}))
+}
+
+func TestSummarize(t *testing.T) {
+	var prompts []string
+	srv := fakeEndpoint(t, &prompts)
+	defer srv.Close()
+
+	entries := []transcript.TranscriptEntry{
+		{Text: "first part of the talk", Start: 0, Duration: 30},
+		{Text: "second part of the talk", Start: 65, Duration: 30},
+	}
+
+	client := New(srv.URL+"/v1/", "test-model", WithAPIKey("secret"), WithChunkChars(25))
+	summary, err := client.Summarize(context.Background(), entries)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+
+	// Two chunk summaries followed by the combining request
+	if len(prompts) != 3 || summary != "summary 3" {
+		t.Fatalf("made %d requests, summary %q; want 3 requests and summary 3", len(prompts), summary)
+	}
+	if !strings.Contains(prompts[1], "[1:05-1:35] second part") {
+		t.Errorf("second prompt = %q; want labeled chunk", prompts[1])
+	}
+	if !strings.Contains(prompts[2], "[0:00-0:30] summary 1") || !strings.Contains(prompts[2], "[1:05-1:35] summary 2") {
+		t.Errorf("final prompt = %q; want labeled partial summaries", prompts[2])
+	}
+}
+
+func TestSummarize_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"bad key"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "test-model")
+	if _, err := client.Summarize(context.Background(), []transcript.TranscriptEntry{{Text: "hi"}}); err == nil || !strings.Contains(err.Error(), "HTTP 401") {
+		t.Errorf("Summarize() error = %v; want HTTP 401", err)
+	}
+	if _, err := client.Summarize(context.Background(), nil); err == nil {
+		t.Error("Summarize(nil) expected error")
+	}
+}
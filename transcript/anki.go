@@ -0,0 +1,32 @@
+package transcript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ankiField makes text safe for a field of a tab-separated Anki import
+var ankiField = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// WriteAnkiTSV writes flashcards for Anki's text importer, one per entry: the original
+// sentence on the front, its translation on the back, and a link to the moment it is spoken.
+// original and translated must correspond entry by entry, as the output of TranslateEntries
+// does; run Clean first so each card holds a whole sentence.
+func WriteAnkiTSV(w io.Writer, videoID string, original, translated []TranscriptEntry) error {
+	if len(original) != len(translated) {
+		return fmt.Errorf("got %d translations for %d entries", len(translated), len(original))
+	}
+	bw := bufio.NewWriter(w)
+	// Header lines Anki reads since 2.1.54; older versions need the separator chosen by hand
+	bw.WriteString("#separator:tab\n#html:false\n#columns:Front\tBack\tLink\n")
+	for i, e := range original {
+		front := strings.TrimSpace(e.Text)
+		if front == "" {
+			continue
+		}
+		fmt.Fprintf(bw, "%s\t%s\t%s\n", ankiField.Replace(front), ankiField.Replace(strings.TrimSpace(translated[i].Text)), DeepLink(videoID, e.Start))
+	}
+	return bw.Flush()
+}
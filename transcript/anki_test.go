@@ -0,0 +1,32 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteAnkiTSV(t *testing.T) {
+	original := []TranscriptEntry{
+		{Text: "Guten Morgen.", Start: 0, Duration: 2},
+		{Text: "Wie\tgeht's?", Start: 75, Duration: 2},
+	}
+	translated := []TranscriptEntry{
+		{Text: "Good morning.", Start: 0, Duration: 2},
+		{Text: "How are\nyou?", Start: 75, Duration: 2},
+	}
+
+	var sb strings.Builder
+	if err := WriteAnkiTSV(&sb, "abcdefghijk", original, translated); err != nil {
+		t.Fatalf("WriteAnkiTSV() error = %v", err)
+	}
+	want := "#separator:tab\n#html:false\n#columns:Front\tBack\tLink\n" +
+		"Guten Morgen.\tGood morning.\thttps://youtu.be/abcdefghijk\n" +
+		"Wie geht's?\tHow are you?\thttps://youtu.be/abcdefghijk?t=75\n"
+	if sb.String() != want {
+		t.Errorf("WriteAnkiTSV() = %q; want %q", sb.String(), want)
+	}
+
+	if err := WriteAnkiTSV(&sb, "abcdefghijk", original, translated[:1]); err == nil {
+		t.Errorf("WriteAnkiTSV() with a missing translation error = nil; want an error")
+	}
+}
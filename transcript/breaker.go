@@ -0,0 +1,99 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BreakerEvent reports a change of state of the circuit breaker of WithCircuitBreaker
+type BreakerEvent struct {
+	// Open is true when the breaker opens and false when a request succeeds again
+	Open bool
+	// Failures is the number of consecutive failed requests
+	Failures int
+	// Until is when an open breaker lets the next request through
+	Until time.Time
+}
+
+// WithCircuitBreaker stops sending requests after failures consecutive requests failed
+// with a network error, HTTP 403, 429 or a 5xx status, as happens once YouTube blocks an IP.
+// For cooldown, requests then fail at once with ErrCircuitOpen. After it, a single request
+// is let through as a probe while the others keep failing with ErrCircuitOpen; the probe
+// closes the breaker on success or opens it again on failure. hook, if not nil, is called on
+// every change of state.
+func WithCircuitBreaker(failures int, cooldown time.Duration, hook func(BreakerEvent)) ClientOption {
+	return func(c *Client) {
+		if failures <= 0 {
+			c.breaker = nil
+			return
+		}
+		c.breaker = &circuitBreaker{threshold: failures, cooldown: cooldown, hook: hook}
+	}
+}
+
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	hook      func(BreakerEvent)
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	// probing is set while the single request let through after the cooldown is in flight
+	probing bool
+}
+
+// allow returns ErrCircuitOpen while the breaker is open or its probe is in flight. probe
+// reports whether the request is the probe, whose outcome decides the state of the breaker.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if time.Now().Before(b.openUntil) {
+		return false, ErrCircuitOpen{Until: b.openUntil}
+	}
+	if b.failures < b.threshold {
+		return false, nil
+	}
+	if b.probing {
+		return false, ErrCircuitOpen{Until: b.openUntil}
+	}
+	b.probing = true
+	return true, nil
+}
+
+// record counts the outcome of a request; probe is the result of allow
+func (b *circuitBreaker) record(probe bool, resp *http.Response, err error) {
+	b.mu.Lock()
+	if probe {
+		b.probing = false
+	}
+	// A cancelled request says nothing about the upstream; the next request probes again
+	if errors.Is(err, context.Canceled) {
+		b.mu.Unlock()
+		return
+	}
+	failed := err != nil || resp.StatusCode == http.StatusForbidden || isTransient(resp, nil)
+
+	var event *BreakerEvent
+	switch {
+	case !failed && b.failures >= b.threshold:
+		event = &BreakerEvent{Open: false}
+		b.failures = 0
+	case !failed:
+		b.failures = 0
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.cooldown)
+			event = &BreakerEvent{Open: true, Failures: b.failures, Until: b.openUntil}
+		}
+	}
+	b.mu.Unlock()
+
+	if event != nil && b.hook != nil {
+		b.hook(*event)
+	}
+}
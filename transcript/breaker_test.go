@@ -0,0 +1,98 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	fake := fakeYouTube(t)
+	blocked := true
+	requests := 0
+	var events []BreakerEvent
+	client := NewClient(
+		WithCircuitBreaker(2, 20*time.Millisecond, func(e BreakerEvent) { events = append(events, e) }),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+			if blocked {
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+			}
+			return fake(r)
+		})),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTranscript("abcdefghijk"); err == nil {
+			t.Fatalf("GetTranscript() while blocked error = nil")
+		}
+	}
+	if len(events) != 1 || !events[0].Open || events[0].Failures != 2 {
+		t.Fatalf("events = %+v; want one open event after 2 failures", events)
+	}
+
+	_, err := client.GetTranscript("abcdefghijk")
+	if !errors.As(err, new(ErrCircuitOpen)) {
+		t.Errorf("GetTranscript() with an open breaker error = %v; want ErrCircuitOpen", err)
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests; want 2, none while the breaker is open", requests)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	blocked = false
+	if _, err := client.GetTranscript("abcdefghijk"); err != nil {
+		t.Fatalf("GetTranscript() after the cooldown error = %v", err)
+	}
+	if len(events) != 2 || events[1].Open {
+		t.Errorf("events = %+v; want a close event after the cooldown", events)
+	}
+}
+
+func TestCircuitBreaker_SingleProbe(t *testing.T) {
+	b := &circuitBreaker{threshold: 1, cooldown: 10 * time.Millisecond}
+	blocked := &http.Response{StatusCode: http.StatusTooManyRequests}
+	ok := &http.Response{StatusCode: http.StatusOK}
+
+	b.record(false, blocked, nil)
+	if _, err := b.allow(); !errors.As(err, new(ErrCircuitOpen)) {
+		t.Fatalf("allow() during the cooldown error = %v; want ErrCircuitOpen", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	probe, err := b.allow()
+	if err != nil || !probe {
+		t.Fatalf("allow() after the cooldown = %v, %v; want the probe", probe, err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := b.allow(); !errors.As(err, new(ErrCircuitOpen)) {
+			t.Errorf("allow() while the probe is in flight error = %v; want ErrCircuitOpen", err)
+		}
+	}
+
+	// A failed probe opens the breaker again
+	b.record(probe, blocked, nil)
+	if _, err := b.allow(); !errors.As(err, new(ErrCircuitOpen)) {
+		t.Errorf("allow() after a failed probe error = %v; want ErrCircuitOpen", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// A cancelled probe leaves the next request to probe
+	probe, _ = b.allow()
+	b.record(probe, nil, context.Canceled)
+	if probe, err = b.allow(); err != nil || !probe {
+		t.Fatalf("allow() after a cancelled probe = %v, %v; want a new probe", probe, err)
+	}
+
+	// A successful probe closes the breaker
+	b.record(probe, ok, nil)
+	for i := 0; i < 3; i++ {
+		if probe, err := b.allow(); err != nil || probe {
+			t.Errorf("allow() after a successful probe = %v, %v; want a plain request", probe, err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package transcript
+
+import (
+	"sync"
+	"time"
+)
+
+// WithRequestBudget allows at most max watch-page fetches in any window of length per, such
+// as 1000 an hour; fetches beyond it fail with ErrBudgetExceeded instead of reaching YouTube.
+// Several budgets, e.g. per hour and per day, can be combined. The budget is shared by
+// everything using the client.
+func WithRequestBudget(max int, per time.Duration) ClientOption {
+	return func(c *Client) {
+		if max > 0 && per > 0 {
+			c.budgets = append(c.budgets, &requestBudget{max: max, per: per})
+		}
+	}
+}
+
+// requestBudget counts the fetches of a sliding window
+type requestBudget struct {
+	max int
+	per time.Duration
+
+	mu    sync.Mutex
+	spent []time.Time // oldest first
+}
+
+// spend records a fetch, or returns ErrBudgetExceeded if the window is full
+func (b *requestBudget) spend(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cutoff := now.Add(-b.per)
+	i := 0
+	for i < len(b.spent) && !b.spent[i].After(cutoff) {
+		i++
+	}
+	b.spent = b.spent[i:]
+
+	if len(b.spent) >= b.max {
+		return ErrBudgetExceeded{Max: b.max, Per: b.per, ResetAt: b.spent[0].Add(b.per)}
+	}
+	b.spent = append(b.spent, now)
+	return nil
+}
+
+// refund takes back the fetch spent at t
+func (b *requestBudget) refund(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := len(b.spent) - 1; i >= 0; i-- {
+		if b.spent[i].Equal(t) {
+			b.spent = append(b.spent[:i], b.spent[i+1:]...)
+			return
+		}
+	}
+}
+
+// spendBudget charges a watch-page fetch to every budget of the client, or to none if one is used up
+func (c *Client) spendBudget() error {
+	now := time.Now()
+	for i, b := range c.budgets {
+		if err := b.spend(now); err != nil {
+			for _, spent := range c.budgets[:i] {
+				spent.refund(now)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package transcript
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRequestBudget(t *testing.T) {
+	client := NewClient(WithRequestBudget(2, time.Hour), WithTransport(fakeYouTube(t)))
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListAvailableTranscripts("abcdefghijk"); err != nil {
+			t.Fatalf("ListAvailableTranscripts() #%d error = %v", i+1, err)
+		}
+	}
+
+	_, err := client.ListAvailableTranscripts("abcdefghijk")
+	var exceeded ErrBudgetExceeded
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("ListAvailableTranscripts() over budget error = %v; want ErrBudgetExceeded", err)
+	}
+	if exceeded.Max != 2 || exceeded.Per != time.Hour || time.Until(exceeded.ResetAt) <= 59*time.Minute {
+		t.Errorf("ErrBudgetExceeded = %+v", exceeded)
+	}
+}
+
+func TestRequestBudget_Window(t *testing.T) {
+	hourly := &requestBudget{max: 1, per: time.Hour}
+	daily := &requestBudget{max: 1, per: 24 * time.Hour}
+	c := &Client{budgets: []*requestBudget{hourly, daily}}
+
+	start := time.Now()
+	if err := hourly.spend(start.Add(-2 * time.Hour)); err != nil {
+		t.Fatalf("spend() error = %v", err)
+	}
+	// The hourly window has room again, but the daily one does not
+	if err := daily.spend(start.Add(-2 * time.Hour)); err != nil {
+		t.Fatalf("spend() error = %v", err)
+	}
+	if err := c.spendBudget(); err == nil {
+		t.Fatalf("spendBudget() error = nil; want the daily budget exceeded")
+	}
+	if len(hourly.spent) != 0 {
+		t.Errorf("hourly budget kept %d fetches; want the refused fetch refunded", len(hourly.spent))
+	}
+}
@@ -0,0 +1,132 @@
+package transcript
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FeedVideo is an upload listed in a channel's public feed
+type FeedVideo struct {
+	VideoID   string
+	Title     string
+	ChannelID string
+	Author    string
+	Published time.Time
+}
+
+var (
+	channelIDPattern     = regexp.MustCompile(`^UC[A-Za-z0-9_-]{22}$`)
+	channelIDPagePattern = regexp.MustCompile(`"(?:externalId|channelId)":"(UC[A-Za-z0-9_-]{22})"`)
+)
+
+// ResolveChannelID returns the UC... channel ID for a channel ID, @handle or channel URL
+func (c *Client) ResolveChannelID(channel string) (string, error) {
+	return c.ResolveChannelIDContext(context.Background(), channel)
+}
+
+// ResolveChannelIDContext is ResolveChannelID with the request bound to ctx
+func (c *Client) ResolveChannelIDContext(ctx context.Context, channel string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	channel = strings.TrimSpace(channel)
+	if channelIDPattern.MatchString(channel) {
+		return channel, nil
+	}
+
+	var pageURL string
+	switch {
+	case strings.HasPrefix(channel, "@"):
+		pageURL = "https://www.youtube.com/" + url.PathEscape(channel)
+	case strings.Contains(channel, "youtube.com/"):
+		u, err := url.Parse(channel)
+		if err != nil {
+			return "", fmt.Errorf("invalid channel URL: %s", channel)
+		}
+		if parts := strings.Split(strings.Trim(u.Path, "/"), "/"); len(parts) == 2 && parts[0] == "channel" && channelIDPattern.MatchString(parts[1]) {
+			return parts[1], nil
+		}
+		pageURL = "https://www.youtube.com" + u.Path
+	default:
+		return "", fmt.Errorf("unrecognized channel: %s (expected a UC... ID, @handle or channel URL)", channel)
+	}
+
+	resp, err := c.getContext(ctx, pageURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("channel %s not found: HTTP %d", channel, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	m := channelIDPagePattern.FindSubmatch(body)
+	if m == nil {
+		return "", fmt.Errorf("could not find the channel ID on the page of %s", channel)
+	}
+	return string(m[1]), nil
+}
+
+// UploadsPlaylistID returns the ID of the playlist holding every upload of a channel, newest
+// first, for use with GetPlaylistVideos. It returns "" for anything but a UC... channel ID.
+func UploadsPlaylistID(channelID string) string {
+	if !channelIDPattern.MatchString(channelID) {
+		return ""
+	}
+	return "UU" + channelID[2:]
+}
+
+// GetChannelFeed returns the most recent uploads of a channel, newest first.
+// YouTube's feed only lists the latest 15 videos.
+func (c *Client) GetChannelFeed(channelID string) ([]FeedVideo, error) {
+	resp, err := c.get("https://www.youtube.com/feeds/videos.xml?channel_id=" + url.QueryEscape(channelID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching feed of channel %s: HTTP %d", channelID, resp.StatusCode)
+	}
+
+	return ParseChannelFeed(resp.Body)
+}
+
+// ParseChannelFeed decodes the Atom feed served at /feeds/videos.xml
+func ParseChannelFeed(r io.Reader) ([]FeedVideo, error) {
+	var feed struct {
+		Entries []struct {
+			VideoID   string `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+			ChannelID string `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+			Title     string `xml:"title"`
+			Author    string `xml:"author>name"`
+			Published string `xml:"published"`
+		} `xml:"entry"`
+	}
+	if err := xml.NewDecoder(r).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("error parsing channel feed: %v", err)
+	}
+
+	videos := make([]FeedVideo, 0, len(feed.Entries))
+	for _, e := range feed.Entries {
+		published, _ := time.Parse(time.RFC3339, e.Published)
+		videos = append(videos, FeedVideo{
+			VideoID:   e.VideoID,
+			Title:     e.Title,
+			ChannelID: e.ChannelID,
+			Author:    e.Author,
+			Published: published,
+		})
+	}
+	return videos, nil
+}
@@ -0,0 +1,99 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns:yt="http://www.youtube.com/xml/schemas/2015" xmlns="http://www.w3.org/2005/Atom">
+ <title>Some Channel</title>
+ <entry>
+  <yt:videoId>abcdefghijk</yt:videoId>
+  <yt:channelId>UCabcdefghijklmnopqrstuv</yt:channelId>
+  <title>Newest video</title>
+  <author><name>Some Channel</name></author>
+  <published>2024-05-01T10:00:00+00:00</published>
+ </entry>
+ <entry>
+  <yt:videoId>bcdefghijkl</yt:videoId>
+  <yt:channelId>UCabcdefghijklmnopqrstuv</yt:channelId>
+  <title>Older video</title>
+  <published>2024-04-01T10:00:00+00:00</published>
+ </entry>
+</feed>`
+
+func TestParseChannelFeed(t *testing.T) {
+	videos, err := ParseChannelFeed(strings.NewReader(sampleFeed))
+	if err != nil {
+		t.Fatalf("ParseChannelFeed() error = %v", err)
+	}
+	if len(videos) != 2 {
+		t.Fatalf("got %d videos; want 2", len(videos))
+	}
+
+	v := videos[0]
+	if v.VideoID != "abcdefghijk" || v.Title != "Newest video" || v.Author != "Some Channel" ||
+		v.ChannelID != "UCabcdefghijklmnopqrstuv" || !v.Published.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("videos[0] = %+v", v)
+	}
+}
+
+func TestUploadsPlaylistID(t *testing.T) {
+	if got := UploadsPlaylistID("UCabcdefghijklmnopqrstuv"); got != "UUabcdefghijklmnopqrstuv" {
+		t.Errorf("UploadsPlaylistID() = %q; want UUabcdefghijklmnopqrstuv", got)
+	}
+	if got := UploadsPlaylistID("@somechannel"); got != "" {
+		t.Errorf("UploadsPlaylistID(@somechannel) = %q; want empty", got)
+	}
+}
+
+func TestResolveChannelID(t *testing.T) {
+	var requested []string
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.Path)
+		return textResponse(r, `<html>{"metadata":{"channelMetadataRenderer":{"externalId":"UCabcdefghijklmnopqrstuv"}}}</html>`), nil
+	})))
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"UCabcdefghijklmnopqrstuv", "UCabcdefghijklmnopqrstuv"},
+		{"https://www.youtube.com/channel/UCabcdefghijklmnopqrstuv", "UCabcdefghijklmnopqrstuv"},
+		{"@somechannel", "UCabcdefghijklmnopqrstuv"},
+		{"https://www.youtube.com/@somechannel/videos", "UCabcdefghijklmnopqrstuv"},
+	}
+	for _, tt := range tests {
+		got, err := client.ResolveChannelID(tt.input)
+		if err != nil || got != tt.expected {
+			t.Errorf("ResolveChannelID(%s) = %s, %v; want %s", tt.input, got, err, tt.expected)
+		}
+	}
+
+	if strings.Join(requested, " ") != "/@somechannel /@somechannel/videos" {
+		t.Errorf("requested %v", requested)
+	}
+
+	if _, err := client.ResolveChannelID("not a channel"); err == nil {
+		t.Error("ResolveChannelID() expected error for unrecognized input")
+	}
+}
+
+func TestResolveChannelID_Context(t *testing.T) {
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		// The channel page hangs until the request is cancelled
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.ResolveChannelIDContext(ctx, "@somechannel"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ResolveChannelIDContext() past the deadline error = %v; want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,57 @@
+package transcript
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Chapter is a titled section of a video, as listed in its description
+type Chapter struct {
+	Title string  `json:"title"`
+	Start float64 `json:"start"`
+}
+
+// chapterLine matches description lines such as "0:00 Intro", "1:02:03 - Q&A" or "(12:30) Wrap-up"
+var chapterLine = regexp.MustCompile(`^\s*\(?((?:\d+:)?\d{1,2}:\d{2})\)?\s*(?:[-–—|:]\s*)?(\S.*?)\s*$`)
+
+// ParseChapters extracts chapters from a video description. Like YouTube, it only accepts
+// a list of at least three ascending timestamps starting at 0:00; otherwise it returns nil.
+func ParseChapters(description string) []Chapter {
+	var chapters []Chapter
+	for _, line := range strings.Split(description, "\n") {
+		m := chapterLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		start := parseClock(m[1])
+		if len(chapters) > 0 && start <= chapters[len(chapters)-1].Start {
+			continue
+		}
+		chapters = append(chapters, Chapter{Title: m[2], Start: start})
+	}
+
+	if len(chapters) < 3 || chapters[0].Start != 0 {
+		return nil
+	}
+	return chapters
+}
+
+// GetChapters fetches a video's description and parses its chapters
+func (c *Client) GetChapters(videoID string) ([]Chapter, error) {
+	md, err := c.GetVideoMetadata(videoID)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChapters(md.Description), nil
+}
+
+// parseClock converts [h:]mm:ss to seconds
+func parseClock(s string) float64 {
+	seconds := 0
+	for _, part := range strings.Split(s, ":") {
+		n, _ := strconv.Atoi(part)
+		seconds = seconds*60 + n
+	}
+	return float64(seconds)
+}
@@ -0,0 +1,37 @@
+package transcript
+
+import "testing"
+
+func TestParseChapters(t *testing.T) {
+	description := "Episode notes\n\n0:00 Intro\n(1:30) - The problem\n12:05 | Solutions\n1:02:03 Q&A\nThanks for watching at 2:00"
+
+	chapters := ParseChapters(description)
+	want := []Chapter{
+		{Title: "Intro", Start: 0},
+		{Title: "The problem", Start: 90},
+		{Title: "Solutions", Start: 725},
+		{Title: "Q&A", Start: 3723},
+	}
+	if len(chapters) != len(want) {
+		t.Fatalf("ParseChapters() = %+v; want %+v", chapters, want)
+	}
+	for i := range want {
+		if chapters[i] != want[i] {
+			t.Errorf("chapters[%d] = %+v; want %+v", i, chapters[i], want[i])
+		}
+	}
+}
+
+func TestParseChapters_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"0:00 Intro\n5:00 End",              // fewer than three
+		"0:30 Intro\n1:00 Middle\n2:00 End", // does not start at 0:00
+	}
+
+	for _, description := range tests {
+		if chapters := ParseChapters(description); chapters != nil {
+			t.Errorf("ParseChapters(%q) = %+v; want nil", description, chapters)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package transcript
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Chunk is a run of consecutive entries merged into one block of text
+type Chunk struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// ChunkEntries groups consecutive entries into chunks of at most maxChars characters of text,
+// for feeding transcripts to tools with input limits. An entry longer than maxChars gets a chunk of its own.
+func ChunkEntries(entries []TranscriptEntry, maxChars int) []Chunk {
+	return chunkBy(entries, maxChars, func(text string) int { return len(text) }, 1)
+}
+
+// ChunkTokens is ChunkEntries with the size of chunks counted in tokens as estimated by
+// EstimateTokens, the unit embedding and language models limit their input by
+func ChunkTokens(entries []TranscriptEntry, maxTokens int) []Chunk {
+	return chunkBy(entries, maxTokens, EstimateTokens, 0)
+}
+
+// EstimateTokens approximates the number of tokens a BPE tokenizer splits text into: about
+// four characters each for English, and at least one per word
+func EstimateTokens(text string) int {
+	return max((utf8.RuneCountInString(text)+3)/4, len(strings.Fields(text)))
+}
+
+// chunkBy groups entries into chunks whose text sizes, plus sep between entries, add up to at
+// most limit
+func chunkBy(entries []TranscriptEntry, limit int, size func(string) int, sep int) []Chunk {
+	var (
+		chunks []Chunk
+		cur    Chunk
+		text   strings.Builder
+		used   int
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			cur.Text = text.String()
+			chunks = append(chunks, cur)
+			text.Reset()
+		}
+	}
+
+	for _, e := range entries {
+		t := strings.TrimSpace(e.Text)
+		if t == "" {
+			continue
+		}
+		n := size(t)
+		if text.Len() > 0 && used+sep+n > limit {
+			flush()
+		}
+		if text.Len() == 0 {
+			cur = Chunk{Start: e.Start}
+			used = n
+		} else {
+			text.WriteByte(' ')
+			used += sep + n
+		}
+		text.WriteString(t)
+		cur.End = e.Start + e.Duration
+	}
+	flush()
+	return chunks
+}
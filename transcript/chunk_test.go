@@ -0,0 +1,47 @@
+package transcript
+
+import "testing"
+
+func TestChunkEntries(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "one two", Start: 0, Duration: 2},
+		{Text: "three", Start: 2, Duration: 2},
+		{Text: "  ", Start: 4, Duration: 1},
+		{Text: "four five six", Start: 5, Duration: 3},
+		{Text: "seven", Start: 8, Duration: 1},
+	}
+
+	chunks := ChunkEntries(entries, 14)
+	want := []Chunk{
+		{Start: 0, End: 4, Text: "one two three"},
+		{Start: 5, End: 8, Text: "four five six"},
+		{Start: 8, End: 9, Text: "seven"},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("ChunkEntries() = %+v; want %+v", chunks, want)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Errorf("chunks[%d] = %+v; want %+v", i, chunks[i], want[i])
+		}
+	}
+}
+
+func TestChunkTokens(t *testing.T) {
+	if got := EstimateTokens("a b c"); got != 3 {
+		t.Errorf("EstimateTokens(%q) = %d; want 3", "a b c", got)
+	}
+	if got := EstimateTokens("internationalization"); got != 5 {
+		t.Errorf("EstimateTokens(%q) = %d; want 5", "internationalization", got)
+	}
+
+	entries := []TranscriptEntry{
+		{Text: "one two", Start: 0, Duration: 2},
+		{Text: "three", Start: 2, Duration: 2},
+		{Text: "four five six", Start: 4, Duration: 2},
+	}
+	chunks := ChunkTokens(entries, 4)
+	if len(chunks) != 2 || chunks[0].Text != "one two three" || chunks[1].Text != "four five six" {
+		t.Errorf("ChunkTokens() = %+v; want [one two three] [four five six]", chunks)
+	}
+}
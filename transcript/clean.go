@@ -0,0 +1,123 @@
+package transcript
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultReflowChars is the default maximum length of a reflowed entry
+const DefaultReflowChars = 200
+
+// annotation matches non-speech markers such as [Music], (applause) and music note symbols
+var annotation = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|[♪♫]+`)
+
+// Clean applies the full cleaning pipeline: StripAnnotations, DedupOverlap, then ReflowSentences
+func Clean(entries []TranscriptEntry) []TranscriptEntry {
+	return ReflowSentences(DedupOverlap(StripAnnotations(entries)), DefaultReflowChars)
+}
+
+// StripAnnotations removes bracketed sound descriptions and music symbols, dropping entries
+// left empty
+func StripAnnotations(entries []TranscriptEntry) []TranscriptEntry {
+	var out []TranscriptEntry
+	for _, e := range entries {
+		e.Text = strings.Join(strings.Fields(annotation.ReplaceAllString(e.Text, " ")), " ")
+		if e.Text != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// DedupOverlap removes the words auto-generated captions repeat from the previous entry:
+// rolling ASR captions often start with the tail of the line before. Entries that only
+// repeat the previous one are dropped. A single shared word is only removed when it is the
+// whole entry, since speakers do repeat words across lines.
+func DedupOverlap(entries []TranscriptEntry) []TranscriptEntry {
+	var (
+		out  []TranscriptEntry
+		prev []string
+	)
+	for _, e := range entries {
+		words := strings.Fields(e.Text)
+		if n := overlap(prev, words); n >= 2 || n == len(words) {
+			words = words[n:]
+		}
+		if len(words) == 0 {
+			continue
+		}
+		prev = strings.Fields(e.Text)
+		e.Text = strings.Join(words, " ")
+		out = append(out, e)
+	}
+	return out
+}
+
+// overlap returns the length of the longest suffix of prev that is a prefix of next,
+// comparing words case-insensitively
+func overlap(prev, next []string) int {
+	max := len(prev)
+	if len(next) < max {
+		max = len(next)
+	}
+	for n := max; n > 0; n-- {
+		match := true
+		for i := 0; i < n; i++ {
+			if !strings.EqualFold(prev[len(prev)-n+i], next[i]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return n
+		}
+	}
+	return 0
+}
+
+// ReflowSentences merges consecutive entries so each one ends at a sentence boundary,
+// starting a new entry early once maxChars would be exceeded
+func ReflowSentences(entries []TranscriptEntry, maxChars int) []TranscriptEntry {
+	var (
+		out     []TranscriptEntry
+		current TranscriptEntry
+		end     float64
+		open    bool
+	)
+	flush := func() {
+		if open {
+			current.Duration = end - current.Start
+			out = append(out, current)
+			open = false
+		}
+	}
+
+	for _, e := range entries {
+		text := strings.TrimSpace(e.Text)
+		if text == "" {
+			continue
+		}
+		if open && maxChars > 0 && len(current.Text)+1+len(text) > maxChars {
+			flush()
+		}
+		if !open {
+			current = TranscriptEntry{Text: text, Start: e.Start}
+			open = true
+		} else {
+			current.Text += " " + text
+		}
+		end = e.Start + e.Duration
+		if endsSentence(text) {
+			flush()
+		}
+	}
+	flush()
+	return out
+}
+
+// endsSentence reports whether text ends with sentence punctuation, allowing closing quotes
+func endsSentence(text string) bool {
+	text = strings.TrimRight(text, `"')”’`)
+	return strings.HasSuffix(text, ".") || strings.HasSuffix(text, "?") || strings.HasSuffix(text, "!") ||
+		strings.HasSuffix(text, "…")
+}
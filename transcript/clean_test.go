@@ -0,0 +1,64 @@
+package transcript
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStripAnnotations(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "[Music]", Start: 0},
+		{Text: "♪ la la ♪ (applause) thanks", Start: 1},
+	}
+	want := []TranscriptEntry{{Text: "la la thanks", Start: 1}}
+	if got := StripAnnotations(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("StripAnnotations() = %+v; want %+v", got, want)
+	}
+}
+
+func TestDedupOverlap(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "so today we are going", Start: 0},
+		{Text: "we are going to build", Start: 2},
+		{Text: "to build", Start: 3},
+		{Text: "build a robot", Start: 4},
+	}
+	want := []TranscriptEntry{
+		{Text: "so today we are going", Start: 0},
+		{Text: "to build", Start: 2},
+		{Text: "build a robot", Start: 4},
+	}
+	if got := DedupOverlap(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("DedupOverlap() = %+v; want %+v", got, want)
+	}
+}
+
+func TestReflowSentences(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "this is the first", Start: 0, Duration: 1},
+		{Text: "sentence.", Start: 1, Duration: 1},
+		{Text: "Is this the second?", Start: 2.5, Duration: 1.5},
+		{Text: "a very long run", Start: 4, Duration: 1},
+		{Text: "without punctuation", Start: 5, Duration: 1},
+	}
+	want := []TranscriptEntry{
+		{Text: "this is the first sentence.", Start: 0, Duration: 2},
+		{Text: "Is this the second?", Start: 2.5, Duration: 1.5},
+		{Text: "a very long run", Start: 4, Duration: 1},
+		{Text: "without punctuation", Start: 5, Duration: 1},
+	}
+	if got := ReflowSentences(entries, 30); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReflowSentences() = %+v; want %+v", got, want)
+	}
+}
+
+func TestClean(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "[Music] welcome back", Start: 0, Duration: 2},
+		{Text: "welcome back everyone.", Start: 1, Duration: 2},
+	}
+	want := []TranscriptEntry{{Text: "welcome back everyone.", Start: 0, Duration: 3}}
+	if got := Clean(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("Clean() = %+v; want %+v", got, want)
+	}
+}
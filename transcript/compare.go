@@ -0,0 +1,159 @@
+package transcript
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Comparison measures how far an auto-generated track strays from the uploader's track of
+// the same language, counted in words as for a word error rate
+type Comparison struct {
+	ReferenceWords int     `json:"referenceWords"` // words of the manual track
+	Substitutions  int     `json:"substitutions"`
+	Deletions      int     `json:"deletions"`  // manual words missing from the generated track
+	Insertions     int     `json:"insertions"` // generated words absent from the manual track
+	WordErrorRate  float64 `json:"wordErrorRate"`
+	// MostChanged lists the manual entries the generated track differs from most, worst first
+	MostChanged []SegmentDiff `json:"mostChanged"`
+}
+
+// SegmentDiff is a manual entry next to the generated captions spoken during it
+type SegmentDiff struct {
+	Start         float64 `json:"start"`
+	Duration      float64 `json:"duration"`
+	Manual        string  `json:"manual"`
+	Generated     string  `json:"generated"`
+	Errors        int     `json:"errors"`
+	WordErrorRate float64 `json:"wordErrorRate"`
+}
+
+// mostChanged is the number of segments reported by CompareTranscripts
+const mostChanged = 10
+
+// CompareTranscripts compares a generated track against the manual one it should match.
+// Each generated entry is attributed to the manual entry its midpoint falls in, and words
+// are aligned within every manual entry, so the cost stays linear in the transcript length.
+// Generated entries outside all manual ones count as insertions.
+func CompareTranscripts(manual, generated []TranscriptEntry) Comparison {
+	spoken := make([][]string, len(manual))
+	var stray []string
+	for _, g := range generated {
+		mid := g.Start + g.Duration/2
+		i := sort.Search(len(manual), func(i int) bool { return manual[i].Start > mid }) - 1
+		if i < 0 || mid >= manual[i].Start+manual[i].Duration {
+			stray = append(stray, tokenize(g.Text)...)
+			continue
+		}
+		spoken[i] = append(spoken[i], tokenize(g.Text)...)
+	}
+
+	c := Comparison{Insertions: len(stray), MostChanged: []SegmentDiff{}}
+	for i, m := range manual {
+		ref := tokenize(m.Text)
+		sub, del, ins := wordEdits(ref, spoken[i])
+		c.ReferenceWords += len(ref)
+		c.Substitutions += sub
+		c.Deletions += del
+		c.Insertions += ins
+
+		if errors := sub + del + ins; errors > 0 {
+			c.MostChanged = append(c.MostChanged, SegmentDiff{
+				Start:         m.Start,
+				Duration:      m.Duration,
+				Manual:        m.Text,
+				Generated:     strings.Join(spoken[i], " "),
+				Errors:        errors,
+				WordErrorRate: errorRate(errors, len(ref)),
+			})
+		}
+	}
+	c.WordErrorRate = errorRate(c.Substitutions+c.Deletions+c.Insertions, c.ReferenceWords)
+
+	sort.SliceStable(c.MostChanged, func(i, j int) bool {
+		return c.MostChanged[i].Errors > c.MostChanged[j].Errors
+	})
+	if len(c.MostChanged) > mostChanged {
+		c.MostChanged = c.MostChanged[:mostChanged]
+	}
+	return c
+}
+
+// CompareCaptions fetches the manual and the auto-generated track of videoID in the language
+// matching languageCode, English when empty, and compares them. ErrNoTranscriptFound is
+// returned unless the video has both.
+func (c *Client) CompareCaptions(videoID string, languageCode string) (Comparison, error) {
+	if languageCode == "" {
+		languageCode = "en"
+	}
+	transcripts, err := c.ListAvailableTranscripts(videoID)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	manual, ok := LanguageSelection{Languages: []string{languageCode}, PreferManual: true}.Choose(transcripts)
+	if !ok || manual.IsGenerated {
+		return Comparison{}, ErrNoTranscriptFound{VideoID: videoID, Language: languageCode}
+	}
+	generated, ok := LanguageSelection{Languages: []string{languageCode}, GeneratedOnly: true}.Choose(transcripts)
+	if !ok {
+		return Comparison{}, ErrNoTranscriptFound{VideoID: videoID, Language: languageCode}
+	}
+
+	tracks, err := c.fetchTracks(context.Background(), map[string]Transcript{"manual": manual, "generated": generated})
+	if err != nil {
+		return Comparison{}, err
+	}
+	return CompareTranscripts(tracks["manual"], tracks["generated"]), nil
+}
+
+// wordEdits counts the substitutions, deletions and insertions of a minimal alignment of hyp
+// to ref
+func wordEdits(ref, hyp []string) (sub, del, ins int) {
+	return alignWords(ref, hyp, func(a, b string) bool { return a == b })
+}
+
+// alignWords is wordEdits with words compared by same
+func alignWords(ref, hyp []string, same func(a, b string) bool) (sub, del, ins int) {
+	type cell struct{ cost, sub, del, ins int }
+	prev := make([]cell, len(hyp)+1)
+	cur := make([]cell, len(hyp)+1)
+	for j := range prev {
+		prev[j] = cell{cost: j, ins: j}
+	}
+	for i := 1; i <= len(ref); i++ {
+		cur[0] = cell{cost: i, del: i}
+		for j := 1; j <= len(hyp); j++ {
+			if same(ref[i-1], hyp[j-1]) {
+				cur[j] = prev[j-1]
+				continue
+			}
+			best := prev[j-1]
+			best.sub++
+			if d := prev[j]; d.cost < best.cost {
+				best = d
+				best.del++
+			}
+			if n := cur[j-1]; n.cost < best.cost {
+				best = n
+				best.ins++
+			}
+			best.cost++
+			cur[j] = best
+		}
+		prev, cur = cur, prev
+	}
+	last := prev[len(hyp)]
+	return last.sub, last.del, last.ins
+}
+
+// errorRate divides errors by the reference length, treating an empty reference as fully wrong
+func errorRate(errors, words int) float64 {
+	if words == 0 {
+		if errors == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(errors) / float64(words)
+}
@@ -0,0 +1,78 @@
+package transcript
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWordEdits(t *testing.T) {
+	tests := []struct {
+		ref, hyp      string
+		sub, del, ins int
+	}{
+		{"the quick brown fox", "the quick brown fox", 0, 0, 0},
+		{"the quick brown fox", "the quack brown fox", 1, 0, 0},
+		{"the quick brown fox", "the brown fox", 0, 1, 0},
+		{"the quick brown fox", "the very quick brown fox", 0, 0, 1},
+		{"", "hello", 0, 0, 1},
+	}
+	for _, tt := range tests {
+		sub, del, ins := wordEdits(tokenize(tt.ref), tokenize(tt.hyp))
+		if sub != tt.sub || del != tt.del || ins != tt.ins {
+			t.Errorf("wordEdits(%q, %q) = %d, %d, %d; want %d, %d, %d", tt.ref, tt.hyp, sub, del, ins, tt.sub, tt.del, tt.ins)
+		}
+	}
+}
+
+func TestCompareTranscripts(t *testing.T) {
+	manual := []TranscriptEntry{
+		{Text: "Welcome to the show.", Start: 0, Duration: 2},
+		{Text: "Today we talk about Go.", Start: 2, Duration: 3},
+	}
+	generated := []TranscriptEntry{
+		{Text: "welcome to the show", Start: 0, Duration: 1.5},
+		{Text: "today we talk about", Start: 2, Duration: 1.5},
+		{Text: "goal", Start: 3.5, Duration: 1},
+		{Text: "bye", Start: 10, Duration: 1},
+	}
+
+	c := CompareTranscripts(manual, generated)
+	if c.ReferenceWords != 9 || c.Substitutions != 1 || c.Deletions != 0 || c.Insertions != 1 {
+		t.Errorf("CompareTranscripts() = %+v; want 9 words, 1 substitution and 1 insertion", c)
+	}
+	if want := 2.0 / 9; c.WordErrorRate != want {
+		t.Errorf("WordErrorRate = %v; want %v", c.WordErrorRate, want)
+	}
+	if len(c.MostChanged) != 1 || c.MostChanged[0].Start != 2 || c.MostChanged[0].Generated != "today we talk about goal" {
+		t.Errorf("MostChanged = %+v; want the second entry", c.MostChanged)
+	}
+}
+
+func TestCompareCaptions(t *testing.T) {
+	fake := fakeYouTube(t)
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/watch" {
+			page := `<html><script>var ytInitialPlayerResponse = {"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+				`{"baseUrl":"https://www.youtube.com/api/timedtext?v=abcdefghijk&lang=en","name":{"simpleText":"English"},"languageCode":"en"},` +
+				`{"baseUrl":"https://www.youtube.com/api/timedtext?v=abcdefghijk&lang=en&kind=asr","name":{"simpleText":"English (auto-generated)"},"languageCode":"en","kind":"asr"}` +
+				`]}}};</script></html>`
+			return textResponse(r, page), nil
+		}
+		return fake(r)
+	})))
+
+	c, err := client.CompareCaptions("abcdefghijk", "en")
+	if err != nil {
+		t.Fatalf("CompareCaptions() error = %v", err)
+	}
+	if c.ReferenceWords == 0 || c.WordErrorRate != 0 {
+		t.Errorf("CompareCaptions() of identical tracks = %+v; want no errors", c)
+	}
+}
+
+func TestCompareCaptions_NoManualTrack(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+	if _, err := client.CompareCaptions("abcdefghijk", "en"); err != (ErrNoTranscriptFound{VideoID: "abcdefghijk", Language: "en"}) {
+		t.Errorf("CompareCaptions() error = %v; want ErrNoTranscriptFound", err)
+	}
+}
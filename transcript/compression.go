@@ -0,0 +1,142 @@
+package transcript
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DecoderFunc wraps a compressed response body in a reader that yields the decoded bytes
+type DecoderFunc func(r io.Reader) (io.ReadCloser, error)
+
+// defaultDecoders returns the content encodings supported out of the box
+func defaultDecoders() map[string]DecoderFunc {
+	return map[string]DecoderFunc{
+		"gzip": func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+		"deflate": newDeflateReader,
+	}
+}
+
+// newDeflateReader decodes Content-Encoding deflate, which is the zlib format, falling back
+// to raw deflate data as some servers send it
+func newDeflateReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+// WithDecoder registers a decoder for an additional Content-Encoding such as "br".
+// The encoding is advertised in Accept-Encoding on every request made by the client.
+func WithDecoder(encoding string, decoder DecoderFunc) ClientOption {
+	return func(c *Client) {
+		c.decoders[strings.ToLower(encoding)] = decoder
+	}
+}
+
+// acceptEncoding builds the Accept-Encoding header value from the registered decoders
+func (c *Client) acceptEncoding() string {
+	encodings := make([]string, 0, len(c.decoders))
+	for enc := range c.decoders {
+		encodings = append(encodings, enc)
+	}
+	sort.Strings(encodings)
+	return strings.Join(encodings, ", ")
+}
+
+// get issues a GET request advertising the supported encodings and returns a response
+// whose body has already been decoded
+func (c *Client) get(rawURL string) (*http.Response, error) {
+	return c.getContext(context.Background(), rawURL)
+}
+
+// getContext is get with the request, including reading the body, bound to ctx
+func (c *Client) getContext(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(req)
+}
+
+// send issues req with the client's default, encoding, User-Agent and Accept-Language headers and decodes the response body
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	if isYouTubeHost(req.URL.Hostname()) {
+		for name, values := range c.headers {
+			if _, set := req.Header[name]; !set {
+				req.Header[name] = append([]string(nil), values...)
+			}
+		}
+	}
+	c.addCookies(req)
+	req.Header.Set("Accept-Encoding", c.acceptEncoding())
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.hl != "" {
+		req.Header.Set("Accept-Language", c.hl)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.decodeBody(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// decodeBody replaces resp.Body with a decoding reader according to Content-Encoding
+func (c *Client) decodeBody(resp *http.Response) error {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" {
+		return nil
+	}
+
+	decoder, ok := c.decoders[encoding]
+	if !ok {
+		return fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+
+	decoded, err := decoder(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error decoding %s response: %v", encoding, err)
+	}
+
+	resp.Body = &decodedBody{ReadCloser: decoded, raw: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	return nil
+}
+
+// decodedBody closes both the decoder and the underlying network body
+type decodedBody struct {
+	io.ReadCloser
+	raw io.ReadCloser
+}
+
+func (b *decodedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if rawErr := b.raw.Close(); err == nil {
+		err = rawErr
+	}
+	return err
+}
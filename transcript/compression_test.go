@@ -0,0 +1,143 @@
+package transcript
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const sampleTranscriptXML = `<?xml version="1.0" encoding="utf-8" ?><transcript>` +
+	`<text start="0.5" dur="1.5">Hello &amp;amp; welcome</text>` +
+	`<text start="2" dur="3">to the show</text></transcript>`
+
+func TestFetchTranscript_Compression(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		encode   func([]byte) []byte
+	}{
+		{
+			name:     "Identity",
+			encoding: "",
+			encode:   func(b []byte) []byte { return b },
+		},
+		{
+			name:     "Gzip",
+			encoding: "gzip",
+			encode: func(b []byte) []byte {
+				var buf bytes.Buffer
+				w := gzip.NewWriter(&buf)
+				w.Write(b)
+				w.Close()
+				return buf.Bytes()
+			},
+		},
+		{
+			name:     "Deflate",
+			encoding: "deflate",
+			encode: func(b []byte) []byte {
+				var buf bytes.Buffer
+				w := zlib.NewWriter(&buf)
+				w.Write(b)
+				w.Close()
+				return buf.Bytes()
+			},
+		},
+		{
+			name:     "Raw deflate",
+			encoding: "deflate",
+			encode: func(b []byte) []byte {
+				var buf bytes.Buffer
+				w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+				w.Write(b)
+				w.Close()
+				return buf.Bytes()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAcceptEncoding string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAcceptEncoding = r.Header.Get("Accept-Encoding")
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Write(tt.encode([]byte(sampleTranscriptXML)))
+			}))
+			defer srv.Close()
+
+			client := NewClient()
+			entries, err := client.fetchTranscript(Transcript{BaseURL: srv.URL})
+			if err != nil {
+				t.Fatalf("fetchTranscript() error = %v", err)
+			}
+
+			if gotAcceptEncoding != "deflate, gzip" {
+				t.Errorf("Accept-Encoding = %q; want %q", gotAcceptEncoding, "deflate, gzip")
+			}
+			if len(entries) != 2 {
+				t.Fatalf("got %d entries; want 2", len(entries))
+			}
+			if entries[0].Text != "Hello & welcome" {
+				t.Errorf("entries[0].Text = %q; want %q", entries[0].Text, "Hello & welcome")
+			}
+		})
+	}
+}
+
+func TestWithDecoder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "rot13") {
+			t.Errorf("Accept-Encoding %q does not advertise rot13", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "rot13")
+		io.WriteString(w, strings.Map(rot13, sampleTranscriptXML))
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithDecoder("rot13", func(r io.Reader) (io.ReadCloser, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(strings.NewReader(strings.Map(rot13, string(b)))), nil
+	}))
+
+	entries, err := client.fetchTranscript(Transcript{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("fetchTranscript() error = %v", err)
+	}
+	if len(entries) != 2 || entries[1].Text != "to the show" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestGet_UnsupportedEncoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "zstd")
+		w.Write([]byte("garbage"))
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient().get(srv.URL); err == nil {
+		t.Error("get() expected error for unsupported encoding")
+	}
+}
+
+func rot13(r rune) rune {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return 'a' + (r-'a'+13)%26
+	case r >= 'A' && r <= 'Z':
+		return 'A' + (r-'A'+13)%26
+	}
+	return r
+}
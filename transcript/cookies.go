@@ -0,0 +1,71 @@
+package transcript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithCookies sends cookies with the requests to the domains they belong to. With the
+// cookies of a signed-in browser session, members-only videos of channels the account
+// has joined can be fetched. Cookies without a domain are sent to youtube.com.
+func WithCookies(cookies []*http.Cookie) ClientOption {
+	return func(c *Client) {
+		c.cookies = append(c.cookies, cookies...)
+	}
+}
+
+// addCookies adds the client's cookies matching the host of req
+func (c *Client) addCookies(req *http.Request) {
+	host := strings.ToLower(req.URL.Hostname())
+	for _, cookie := range c.cookies {
+		domain := strings.ToLower(strings.TrimPrefix(cookie.Domain, "."))
+		if domain == "" {
+			domain = "youtube.com"
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+		}
+	}
+}
+
+// ParseCookiesFile reads cookies in the Netscape cookies.txt format exported by browser
+// extensions and yt-dlp. Expired cookies are skipped.
+func ParseCookiesFile(r io.Reader) ([]*http.Cookie, error) {
+	var cookies []*http.Cookie
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		// Only the line ending is trimmed, as a cookie with an empty value ends in a tab
+		line := strings.TrimRight(scanner.Text(), "\r\n")
+		// Browsers mark HttpOnly cookies with a prefix that looks like a comment
+		line = strings.TrimPrefix(line, "#HttpOnly_")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("cookies file line %d: expected 7 tab-separated fields, got %d", n, len(fields))
+		}
+		expires, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cookies file line %d: invalid expiry %q", n, fields[4])
+		}
+		// An expiry of 0 marks a session cookie
+		if expires != 0 && time.Unix(expires, 0).Before(time.Now()) {
+			continue
+		}
+		cookies = append(cookies, &http.Cookie{
+			Domain: fields[0],
+			Path:   fields[2],
+			Secure: strings.EqualFold(fields[3], "TRUE"),
+			Name:   fields[5],
+			Value:  fields[6],
+		})
+	}
+	return cookies, scanner.Err()
+}
@@ -0,0 +1,76 @@
+package transcript
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const sampleCookiesFile = "# Netscape HTTP Cookie File\n" +
+	".youtube.com\tTRUE\t/\tTRUE\t0\tPREF\tf6=40000000\n" +
+	"#HttpOnly_.youtube.com\tTRUE\t/\tTRUE\t4102444800\tSID\tsecret\n" +
+	".youtube.com\tTRUE\t/\tTRUE\t1000000000\tOLD\texpired\n" +
+	".example.com\tTRUE\t/\tFALSE\t0\tOTHER\tx\n"
+
+func TestParseCookiesFile(t *testing.T) {
+	cookies, err := ParseCookiesFile(strings.NewReader(sampleCookiesFile))
+	if err != nil {
+		t.Fatalf("ParseCookiesFile() error = %v", err)
+	}
+	var names []string
+	for _, c := range cookies {
+		names = append(names, c.Name)
+	}
+	if got := strings.Join(names, ","); got != "PREF,SID,OTHER" {
+		t.Errorf("ParseCookiesFile() names = %s; want PREF,SID,OTHER", got)
+	}
+
+	if _, err := ParseCookiesFile(strings.NewReader("youtube.com TRUE / TRUE 0 A b\n")); err == nil {
+		t.Errorf("ParseCookiesFile() with spaces error = nil; want an error")
+	}
+}
+
+func TestParseCookiesFile_EmptyValue(t *testing.T) {
+	cookies, err := ParseCookiesFile(strings.NewReader(".youtube.com\tTRUE\t/\tTRUE\t0\tCONSENT\t\r\n"))
+	if err != nil {
+		t.Fatalf("ParseCookiesFile() error = %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "CONSENT" || cookies[0].Value != "" {
+		t.Errorf("ParseCookiesFile() = %+v; want CONSENT with an empty value", cookies)
+	}
+}
+
+func TestWithCookies(t *testing.T) {
+	cookies, _ := ParseCookiesFile(strings.NewReader(sampleCookiesFile))
+	fake := fakeYouTube(t)
+	var sent []string
+	client := NewClient(WithCookies(cookies), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		sent = append(sent, r.Header.Get("Cookie"))
+		return fake(r)
+	})))
+
+	if _, err := client.GetTranscript("abcdefghijk"); err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+	for _, cookie := range sent {
+		if cookie != "PREF=f6=40000000; SID=secret" {
+			t.Errorf("Cookie = %q; want PREF and SID only", cookie)
+		}
+	}
+}
+
+func TestMembersOnly(t *testing.T) {
+	page := `<script>var ytInitialPlayerResponse = {"playabilityStatus":{"status":"UNPLAYABLE",` +
+		`"reason":"Join this channel to get access to members-only content like this video, and other exclusive perks.",` +
+		`"errorScreen":{"playerLegacyDesktopYpcOfferRenderer":{"offerId":"sponsors_only_video"}}}};</script>`
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return textResponse(r, page), nil
+	})))
+
+	_, err := client.GetTranscript("abcdefghijk")
+	var membersOnly ErrMembersOnly
+	if !errors.As(err, &membersOnly) || membersOnly.VideoID != "abcdefghijk" {
+		t.Errorf("GetTranscript() error = %v; want ErrMembersOnly", err)
+	}
+}
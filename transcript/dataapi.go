@@ -0,0 +1,222 @@
+package transcript
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Backend lists and downloads the caption tracks of videos. By default the client scrapes
+// the public watch page; WithBackend replaces that for track listing and downloads.
+// Both methods bind their requests to ctx.
+type Backend interface {
+	ListTranscripts(ctx context.Context, videoID string) ([]Transcript, error)
+	FetchTranscript(ctx context.Context, t Transcript) ([]TranscriptEntry, error)
+}
+
+// clientBackend is a Backend that sends its requests through the client it is used with,
+// sharing its retries, rate limit, proxy and logging
+type clientBackend interface {
+	Backend
+	withClient(c *Client) Backend
+}
+
+// WithBackend makes the client list and download caption tracks through b. Metadata,
+// chapters, playlists and channels are still read from the public pages.
+func WithBackend(b Backend) ClientOption {
+	return func(c *Client) {
+		if cb, ok := b.(clientBackend); ok {
+			b = cb.withClient(c)
+		}
+		c.backend = b
+	}
+}
+
+// HasBackend reports whether the client lists and downloads caption tracks through a Backend
+// set with WithBackend rather than the watch page
+func (c *Client) HasBackend() bool {
+	return c.backend != nil
+}
+
+// dataAPIBaseURL is the root of the YouTube Data API v3
+const dataAPIBaseURL = "https://www.googleapis.com/youtube/v3"
+
+// dataAPIBackend uses the captions.list and captions.download endpoints of the official
+// YouTube Data API
+type dataAPIBackend struct {
+	apiKey  string
+	tokens  TokenSource
+	baseURL string
+	c       *Client
+}
+
+// DataAPIBackend returns a Backend using the official YouTube Data API, a ToS-clean path
+// that counts against the project's quota instead of scraping. Listing tracks needs an
+// API key or an OAuth access token; downloading them needs an access token of an account
+// that may edit the video, so in practice only owners can fetch their own captions.
+func DataAPIBackend(apiKey, accessToken string) Backend {
+	var tokens TokenSource
+	if accessToken != "" {
+		tokens = StaticToken(accessToken)
+	}
+	return DataAPIBackendWithTokens(apiKey, tokens)
+}
+
+// DataAPIBackendWithTokens returns a Data API Backend authorized by tokens, such as
+// RefreshTokenSource for long runs that outlive an access token. With the OAuth grant of a
+// channel's owner it also lists and downloads the captions of its private and unlisted
+// videos, which the watch page never shows. apiKey may be empty.
+func DataAPIBackendWithTokens(apiKey string, tokens TokenSource) Backend {
+	return &dataAPIBackend{apiKey: apiKey, tokens: tokens, baseURL: dataAPIBaseURL}
+}
+
+func (b *dataAPIBackend) withClient(c *Client) Backend {
+	bound := *b
+	bound.c = c
+	if ct, ok := b.tokens.(clientTokenSource); ok {
+		bound.tokens = ct.withClient(c)
+	}
+	return &bound
+}
+
+// client returns the client the backend is bound to, or a default one
+func (b *dataAPIBackend) client() *Client {
+	if b.c == nil {
+		return NewClient()
+	}
+	return b.c
+}
+
+// ListTranscripts calls captions.list for videoID
+func (b *dataAPIBackend) ListTranscripts(ctx context.Context, videoID string) ([]Transcript, error) {
+	query := url.Values{"part": {"snippet"}, "videoId": {videoID}}
+	resp, err := b.request(ctx, b.baseURL+"/captions", query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := dataAPIError(resp, videoID); err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				Language  string `json:"language"`
+				Name      string `json:"name"`
+				TrackKind string `json:"trackKind"`
+			} `json:"snippet"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("error parsing captions.list response: %v", err)
+	}
+
+	transcripts := make([]Transcript, 0, len(list.Items))
+	for _, item := range list.Items {
+		name := item.Snippet.Name
+		if name == "" {
+			name = item.Snippet.Language
+		}
+		// The fragment keeps the video ID for error messages; it is never sent
+		transcripts = append(transcripts, Transcript{
+			BaseURL:      b.baseURL + "/captions/" + url.PathEscape(item.ID) + "?tfmt=srt#" + videoID,
+			LanguageCode: item.Snippet.Language,
+			Language:     name,
+			IsGenerated:  strings.EqualFold(item.Snippet.TrackKind, "asr"),
+		})
+	}
+	return transcripts, nil
+}
+
+// FetchTranscript calls captions.download for a track returned by ListTranscripts
+func (b *dataAPIBackend) FetchTranscript(ctx context.Context, t Transcript) ([]TranscriptEntry, error) {
+	u, err := url.Parse(t.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid caption track URL: %v", err)
+	}
+	videoID := u.Fragment
+	u.Fragment = ""
+
+	resp, err := b.request(ctx, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := dataAPIError(resp, videoID); err != nil {
+		return nil, err
+	}
+	return ReadFormat(resp.Body, FormatSRT)
+}
+
+// request issues an authenticated GET of rawURL with query added, bound to ctx
+func (b *dataAPIBackend) request(ctx context.Context, rawURL string, query url.Values) (*http.Response, error) {
+	if b.apiKey == "" && b.tokens == nil {
+		return nil, fmt.Errorf("the YouTube Data API needs an API key or an OAuth access token")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	for name, values := range query {
+		q[name] = values
+	}
+	if b.apiKey != "" {
+		q.Set("key", b.apiKey)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if b.tokens != nil {
+		token, err := b.tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error getting OAuth access token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return b.client().send(req)
+}
+
+// dataAPIError converts an error response of the Data API into the package's error types
+func dataAPIError(resp *http.Response, videoID string) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+			Errors  []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	b, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(b, &body)
+	reason := ""
+	if len(body.Error.Errors) > 0 {
+		reason = body.Error.Errors[0].Reason
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, reason == "quotaExceeded", reason == "rateLimitExceeded":
+		return ErrTooManyRequests{VideoID: videoID}
+	case reason == "captionNotFound":
+		return ErrNoTranscriptFound{VideoID: videoID}
+	case resp.StatusCode == http.StatusNotFound || reason == "videoNotFound":
+		return &ErrVideoUnavailable{VideoID: videoID}
+	}
+	if body.Error.Message == "" {
+		return fmt.Errorf("YouTube Data API request failed: HTTP %d", resp.StatusCode)
+	}
+	return fmt.Errorf("YouTube Data API request failed: %s (HTTP %d)", body.Error.Message, resp.StatusCode)
+}
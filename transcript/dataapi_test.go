@@ -0,0 +1,120 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleCaptionsList = `{"items":[` +
+	`{"id":"AUieDaZ1","snippet":{"videoId":"abcdefghijk","language":"de","name":"Deutsch","trackKind":"standard"}},` +
+	`{"id":"AUieDaZ2","snippet":{"videoId":"abcdefghijk","language":"en","name":"","trackKind":"asr"}}]}`
+
+const sampleCaptionsSRT = "1\n00:00:01,000 --> 00:00:03,500\nHallo Welt\n\n2\n00:00:03,500 --> 00:00:05,000\nZweite Zeile\n"
+
+func TestDataAPIBackend(t *testing.T) {
+	var auth []string
+	client := NewClient(
+		WithBackend(DataAPIBackend("KEY", "TOKEN")),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			auth = append(auth, r.Header.Get("Authorization"))
+			if r.URL.Host != "www.googleapis.com" || r.URL.Query().Get("key") != "KEY" {
+				t.Errorf("unexpected request to %s", r.URL)
+			}
+			switch r.URL.Path {
+			case "/youtube/v3/captions":
+				if r.URL.Query().Get("videoId") != "abcdefghijk" {
+					t.Errorf("captions.list videoId = %q", r.URL.Query().Get("videoId"))
+				}
+				return textResponse(r, sampleCaptionsList), nil
+			case "/youtube/v3/captions/AUieDaZ1":
+				if r.URL.Query().Get("tfmt") != "srt" {
+					t.Errorf("captions.download tfmt = %q; want srt", r.URL.Query().Get("tfmt"))
+				}
+				return textResponse(r, sampleCaptionsSRT), nil
+			}
+			t.Errorf("unexpected request to %s", r.URL)
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+		})),
+	)
+
+	tracks, err := client.ListAvailableTranscripts("abcdefghijk")
+	if err != nil {
+		t.Fatalf("ListAvailableTranscripts() error = %v", err)
+	}
+	if len(tracks) != 2 || tracks[0].Language != "Deutsch" || tracks[1].Language != "en" || !tracks[1].IsGenerated {
+		t.Errorf("ListAvailableTranscripts() = %+v", tracks)
+	}
+
+	entries, err := client.GetTranscriptWithLanguage("abcdefghijk", "de")
+	if err != nil {
+		t.Fatalf("GetTranscriptWithLanguage() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Text != "Hallo Welt" || entries[1].Start != 3.5 {
+		t.Errorf("GetTranscriptWithLanguage() = %+v", entries)
+	}
+	for _, a := range auth {
+		if a != "Bearer TOKEN" {
+			t.Errorf("Authorization = %q; want Bearer TOKEN", a)
+		}
+	}
+}
+
+func TestDataAPIBackend_Errors(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		check  func(error) bool
+	}{
+		{http.StatusForbidden, `{"error":{"code":403,"message":"quota","errors":[{"reason":"quotaExceeded"}]}}`, func(err error) bool {
+			var e ErrTooManyRequests
+			return errors.As(err, &e)
+		}},
+		{http.StatusNotFound, `{"error":{"code":404,"message":"not found","errors":[{"reason":"videoNotFound"}]}}`, func(err error) bool {
+			var e *ErrVideoUnavailable
+			return errors.As(err, &e)
+		}},
+		{http.StatusForbidden, `{"error":{"code":403,"message":"The permissions associated with the request are not sufficient","errors":[{"reason":"forbidden"}]}}`, func(err error) bool {
+			return strings.Contains(err.Error(), "not sufficient")
+		}},
+	}
+
+	for _, tt := range tests {
+		client := NewClient(WithBackend(DataAPIBackend("KEY", "")), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			resp := textResponse(r, tt.body)
+			resp.StatusCode = tt.status
+			return resp, nil
+		})))
+		_, err := client.ListAvailableTranscripts("abcdefghijk")
+		if err == nil || !tt.check(err) {
+			t.Errorf("ListAvailableTranscripts() with HTTP %d error = %v", tt.status, err)
+		}
+	}
+}
+
+func TestHasBackend(t *testing.T) {
+	if NewClient().HasBackend() {
+		t.Error("HasBackend() = true without WithBackend")
+	}
+	if !NewClient(WithBackend(DataAPIBackend("KEY", ""))).HasBackend() {
+		t.Error("HasBackend() = false with WithBackend")
+	}
+}
+
+func TestDataAPIBackend_Context(t *testing.T) {
+	client := NewClient(WithBackend(DataAPIBackend("KEY", "")), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		// The Data API hangs until the request is cancelled
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.ListAvailableTranscriptsContext(ctx, "abcdefghijk"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ListAvailableTranscriptsContext() past the deadline error = %v; want %v", err, context.DeadlineExceeded)
+	}
+}
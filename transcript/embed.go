@@ -0,0 +1,94 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// DefaultEmbeddingTokens is the default chunk size of EmbeddingRecords, small enough for
+// common embedding models
+const DefaultEmbeddingTokens = 512
+
+// EmbeddingRecord is one chunk of a transcript with the fields vector database ingestion
+// scripts expect
+type EmbeddingRecord struct {
+	VideoID    string  `json:"video_id"`
+	ChunkIndex int     `json:"chunk_index"`
+	Start      float64 `json:"start"`
+	End        float64 `json:"end"`
+	Text       string  `json:"text"`
+	Title      string  `json:"title"`
+	Channel    string  `json:"channel"`
+}
+
+// EmbeddingRecords splits entries into chunks of at most maxTokens, as by ChunkTokens, and
+// labels them with the video's ID, title and channel
+func EmbeddingRecords(md VideoMetadata, entries []TranscriptEntry, maxTokens int) []EmbeddingRecord {
+	if maxTokens <= 0 {
+		maxTokens = DefaultEmbeddingTokens
+	}
+	chunks := ChunkTokens(entries, maxTokens)
+	records := make([]EmbeddingRecord, len(chunks))
+	for i, c := range chunks {
+		records[i] = EmbeddingRecord{
+			VideoID:    md.VideoID,
+			ChunkIndex: i,
+			Start:      c.Start,
+			End:        c.End,
+			Text:       c.Text,
+			Title:      md.Title,
+			Channel:    md.Author,
+		}
+	}
+	return records
+}
+
+// WriteEmbeddingJSONL writes records as JSON Lines, one object per line
+func WriteEmbeddingJSONL(w io.Writer, records []EmbeddingRecord) error {
+	return writeJSONL(w, len(records), func(i int) interface{} { return records[i] })
+}
+
+// LoaderDocument is a chunk in the document shape of LangChain and LlamaIndex loaders
+type LoaderDocument struct {
+	PageContent string                 `json:"page_content"`
+	Metadata    map[string]interface{} `json:"metadata"`
+}
+
+// LoaderDocuments turns records into loader documents, the text as page_content and the
+// other fields as metadata, with the video's URL as source like LangChain's YouTube loader
+func LoaderDocuments(records []EmbeddingRecord) []LoaderDocument {
+	docs := make([]LoaderDocument, len(records))
+	for i, r := range records {
+		docs[i] = LoaderDocument{
+			PageContent: r.Text,
+			Metadata: map[string]interface{}{
+				"source":      DeepLink(r.VideoID, r.Start),
+				"video_id":    r.VideoID,
+				"chunk_index": r.ChunkIndex,
+				"start":       r.Start,
+				"end":         r.End,
+				"title":       r.Title,
+				"channel":     r.Channel,
+			},
+		}
+	}
+	return docs
+}
+
+// WriteLoaderJSONL writes documents as JSON Lines, one object per line
+func WriteLoaderJSONL(w io.Writer, docs []LoaderDocument) error {
+	return writeJSONL(w, len(docs), func(i int) interface{} { return docs[i] })
+}
+
+// writeJSONL encodes the n values returned by value, each on a line of its own
+func writeJSONL(w io.Writer, n int, value func(i int) interface{}) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for i := 0; i < n; i++ {
+		if err := enc.Encode(value(i)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,46 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddingRecords(t *testing.T) {
+	md := VideoMetadata{VideoID: "abcdefghijk", Title: "Talk", Author: "Channel"}
+	entries := []TranscriptEntry{
+		{Text: "one two three four", Start: 0, Duration: 2},
+		{Text: "five six seven eight", Start: 2, Duration: 2},
+		{Text: "nine", Start: 4, Duration: 1},
+	}
+
+	records := EmbeddingRecords(md, entries, 6)
+	if len(records) != 2 {
+		t.Fatalf("EmbeddingRecords() = %+v; want 2 records", records)
+	}
+	want := EmbeddingRecord{VideoID: "abcdefghijk", ChunkIndex: 1, Start: 2, End: 5, Text: "five six seven eight nine", Title: "Talk", Channel: "Channel"}
+	if records[1] != want {
+		t.Errorf("records[1] = %+v; want %+v", records[1], want)
+	}
+
+	var sb strings.Builder
+	if err := WriteEmbeddingJSONL(&sb, records[:1]); err != nil {
+		t.Fatalf("WriteEmbeddingJSONL() error = %v", err)
+	}
+	wantLine := `{"video_id":"abcdefghijk","chunk_index":0,"start":0,"end":2,"text":"one two three four","title":"Talk","channel":"Channel"}` + "\n"
+	if sb.String() != wantLine {
+		t.Errorf("WriteEmbeddingJSONL() = %q; want %q", sb.String(), wantLine)
+	}
+}
+
+func TestLoaderDocuments(t *testing.T) {
+	records := []EmbeddingRecord{{VideoID: "abcdefghijk", ChunkIndex: 2, Start: 90, End: 120, Text: "hello", Title: "Talk", Channel: "Channel"}}
+
+	var sb strings.Builder
+	if err := WriteLoaderJSONL(&sb, LoaderDocuments(records)); err != nil {
+		t.Fatalf("WriteLoaderJSONL() error = %v", err)
+	}
+	want := `{"page_content":"hello","metadata":{"channel":"Channel","chunk_index":2,"end":120,"source":"https://youtu.be/abcdefghijk?t=90","start":90,"title":"Talk","video_id":"abcdefghijk"}}` + "\n"
+	if sb.String() != want {
+		t.Errorf("WriteLoaderJSONL() = %q; want %q", sb.String(), want)
+	}
+}
@@ -0,0 +1,124 @@
+package transcript
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// EntityKind tells what an extracted entity is
+type EntityKind string
+
+const (
+	// EntityName is a capitalized name: a person, place, organization or product
+	EntityName    EntityKind = "name"
+	EntityMention EntityKind = "mention"
+	EntityHashtag EntityKind = "hashtag"
+	EntityMoney   EntityKind = "money"
+)
+
+// Entity is a thing mentioned in a transcript, at the start time of its entry
+type Entity struct {
+	Text  string     `json:"text"`
+	Kind  EntityKind `json:"kind"`
+	Start float64    `json:"start"`
+}
+
+// EntityExtractor finds the entities mentioned in a transcript, for tagging and indexing.
+// Implementations backed by an NER model or service can replace NaiveEntityExtractor.
+type EntityExtractor interface {
+	Extract(entries []TranscriptEntry) ([]Entity, error)
+}
+
+// EntityRule reports every match of Pattern as an entity of Kind
+type EntityRule struct {
+	Kind    EntityKind
+	Pattern *regexp.Regexp
+}
+
+// DefaultEntityRules find @mentions, #hashtags and amounts of money
+var DefaultEntityRules = []EntityRule{
+	{Kind: EntityMention, Pattern: regexp.MustCompile(`@[\p{L}\p{N}_.]+[\p{L}\p{N}_]`)},
+	{Kind: EntityHashtag, Pattern: regexp.MustCompile(`#[\p{L}\p{N}_]+`)},
+	{Kind: EntityMoney, Pattern: regexp.MustCompile(`[$€£]\s?\d+(?:[.,]\d+)*(?:\s?(?:million|billion|k))?`)},
+}
+
+// NaiveEntityExtractor takes runs of capitalized words as names and applies its rules for
+// the rest. It needs cased text, so it works far better on manual tracks than on
+// auto-generated ones, which YouTube mostly delivers in lowercase.
+type NaiveEntityExtractor struct {
+	Rules []EntityRule
+}
+
+// NewNaiveEntityExtractor returns an extractor with DefaultEntityRules
+func NewNaiveEntityExtractor() NaiveEntityExtractor {
+	return NaiveEntityExtractor{Rules: append([]EntityRule(nil), DefaultEntityRules...)}
+}
+
+// Extract returns the entities in order of appearance
+func (x NaiveEntityExtractor) Extract(entries []TranscriptEntry) ([]Entity, error) {
+	var entities []Entity
+	sentenceStart := true
+	for _, e := range entries {
+		for _, rule := range x.Rules {
+			for _, m := range rule.Pattern.FindAllString(e.Text, -1) {
+				entities = append(entities, Entity{Text: m, Kind: rule.Kind, Start: e.Start})
+			}
+		}
+		var names []string
+		names, sentenceStart = capitalizedRuns(e.Text, sentenceStart)
+		for _, name := range names {
+			entities = append(entities, Entity{Text: name, Kind: EntityName, Start: e.Start})
+		}
+	}
+	return entities, nil
+}
+
+// capitalizedRuns returns the runs of capitalized words of text. A single capitalized word
+// opening a sentence is skipped, since it is most likely capitalized for that reason alone.
+// It also reports whether text ends a sentence, for the entry that follows.
+func capitalizedRuns(text string, sentenceStart bool) ([]string, bool) {
+	var (
+		runs    []string
+		run     []string
+		atStart bool
+	)
+	flush := func() {
+		if len(run) > 1 || (len(run) == 1 && !atStart) {
+			runs = append(runs, strings.Join(run, " "))
+		}
+		run = nil
+	}
+
+	for _, field := range strings.Fields(text) {
+		word := strings.TrimFunc(field, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+		if word != "" && isCapitalized(word) && !stopWords[strings.ToLower(word)] && word != "I" {
+			if len(run) == 0 {
+				atStart = sentenceStart
+			}
+			run = append(run, word)
+		} else {
+			flush()
+		}
+		if word != "" {
+			sentenceStart = false
+		}
+		// Punctuation inside or after a word ends the run
+		if word != "" && strings.TrimRightFunc(field, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }) != field {
+			flush()
+		}
+		if endsSentence(field) {
+			sentenceStart = true
+		}
+	}
+	flush()
+	return runs, sentenceStart
+}
+
+// isCapitalized reports whether word starts with an upper-case letter
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
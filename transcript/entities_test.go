@@ -0,0 +1,39 @@
+package transcript
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNaiveEntityExtractor(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "Today we visit New York with Ada Lovelace.", Start: 1},
+		{Text: "Follow @gopher and use #golang, it costs $20", Start: 4},
+		{Text: "Then we met Bob. Nobody", Start: 8},
+		{Text: "expected that.", Start: 9},
+	}
+
+	got, err := NewNaiveEntityExtractor().Extract(entries)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	want := []Entity{
+		{Text: "New York", Kind: EntityName, Start: 1},
+		{Text: "Ada Lovelace", Kind: EntityName, Start: 1},
+		{Text: "@gopher", Kind: EntityMention, Start: 4},
+		{Text: "#golang", Kind: EntityHashtag, Start: 4},
+		{Text: "$20", Kind: EntityMoney, Start: 4},
+		{Text: "Bob", Kind: EntityName, Start: 8},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() = %+v; want %+v", got, want)
+	}
+}
+
+func TestNaiveEntityExtractor_Interface(t *testing.T) {
+	var x EntityExtractor = NewNaiveEntityExtractor()
+	got, _ := x.Extract([]TranscriptEntry{{Text: "welcome back everyone"}})
+	if len(got) != 0 {
+		t.Errorf("Extract() of lowercase text = %+v; want none", got)
+	}
+}
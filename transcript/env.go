@@ -0,0 +1,23 @@
+package transcript
+
+import "os"
+
+// Environment variables read by NewClientFromEnv
+const (
+	EnvProxy     = "YTWORDS_PROXY"
+	EnvUserAgent = "YTWORDS_USER_AGENT"
+)
+
+// NewClientFromEnv creates a client configured from YTWORDS_* environment variables,
+// for containers and CI where passing options in code is awkward.
+// Explicit options are applied afterwards and take precedence.
+func NewClientFromEnv(options ...ClientOption) *Client {
+	var envOptions []ClientOption
+	if proxy := os.Getenv(EnvProxy); proxy != "" {
+		envOptions = append(envOptions, WithProxy(proxy))
+	}
+	if userAgent := os.Getenv(EnvUserAgent); userAgent != "" {
+		envOptions = append(envOptions, WithUserAgent(userAgent))
+	}
+	return NewClient(append(envOptions, options...)...)
+}
@@ -0,0 +1,30 @@
+package transcript
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvProxy, "http://proxy.example:3128")
+	t.Setenv(EnvUserAgent, "env-agent/1.0")
+
+	client := NewClientFromEnv()
+	if client.userAgent != "env-agent/1.0" {
+		t.Errorf("userAgent = %q; want env-agent/1.0", client.userAgent)
+	}
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T; want *http.Transport", client.httpClient.Transport)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://www.youtube.com/", nil)
+	if proxy, err := transport.Proxy(req); err != nil || proxy.Host != "proxy.example:3128" {
+		t.Errorf("Proxy() = %v, %v; want proxy.example:3128", proxy, err)
+	}
+
+	// Explicit options win over the environment
+	if c := NewClientFromEnv(WithUserAgent("explicit")); c.userAgent != "explicit" {
+		t.Errorf("userAgent = %q; want explicit", c.userAgent)
+	}
+}
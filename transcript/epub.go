@@ -0,0 +1,174 @@
+package transcript
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// Book is an EPUB of transcripts, one chapter per video
+type Book struct {
+	Title  string
+	Author string
+	// Language is the BCP 47 code of the text, "en" when empty
+	Language string
+	// Identifier is the book's unique ID, such as a playlist URL; the chapter videos when empty
+	Identifier string
+	// Modified is the time recorded as the last modification, now when zero
+	Modified time.Time
+	Chapters []BookChapter
+}
+
+// BookChapter is a video of a Book
+type BookChapter struct {
+	Metadata VideoMetadata
+	Entries  []TranscriptEntry
+}
+
+// WriteEPUB writes book as an EPUB 3 file. Chapters hold the transcript as timestamped
+// paragraphs, grouped as by SpeakerTurns after stripping annotations, under the video's title,
+// channel and description.
+func WriteEPUB(w io.Writer, book Book) error {
+	if book.Language == "" {
+		book.Language = "en"
+	}
+	if book.Modified.IsZero() {
+		book.Modified = time.Now()
+	}
+	if book.Identifier == "" {
+		ids := make([]string, len(book.Chapters))
+		for i, ch := range book.Chapters {
+			ids[i] = ch.Metadata.VideoID
+		}
+		book.Identifier = "urn:youtube:" + strings.Join(ids, ",")
+	}
+
+	zw := zip.NewWriter(w)
+	// The mimetype entry must come first and be stored uncompressed
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	io.WriteString(mw, "application/epub+zip")
+
+	files := []struct{ name, content string }{
+		{"META-INF/container.xml", epubContainer},
+		{"OEBPS/content.opf", epubPackage(book)},
+		{"OEBPS/nav.xhtml", epubNav(book)},
+	}
+	for i, ch := range book.Chapters {
+		content, err := epubChapter(ch)
+		if err != nil {
+			return err
+		}
+		files = append(files, struct{ name, content string }{fmt.Sprintf("OEBPS/%s", chapterFile(i)), content})
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+// chapterFile names the XHTML file of the i-th chapter
+func chapterFile(i int) string {
+	return fmt.Sprintf("chapter%03d.xhtml", i+1)
+}
+
+// chapterTitle is a chapter's heading, its video ID when the title is unknown
+func chapterTitle(ch BookChapter) string {
+	if ch.Metadata.Title != "" {
+		return ch.Metadata.Title
+	}
+	return ch.Metadata.VideoID
+}
+
+func epubPackage(book Book) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="id">%s</dc:identifier>
+    <dc:title>%s</dc:title>
+    <dc:language>%s</dc:language>
+`, html.EscapeString(book.Identifier), html.EscapeString(book.Title), html.EscapeString(book.Language))
+	if book.Author != "" {
+		fmt.Fprintf(&sb, "    <dc:creator>%s</dc:creator>\n", html.EscapeString(book.Author))
+	}
+	fmt.Fprintf(&sb, "    <meta property=\"dcterms:modified\">%s</meta>\n  </metadata>\n  <manifest>\n", book.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+	sb.WriteString("    <item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	for i := range book.Chapters {
+		fmt.Fprintf(&sb, "    <item id=\"c%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, chapterFile(i))
+	}
+	sb.WriteString("  </manifest>\n  <spine>\n")
+	for i := range book.Chapters {
+		fmt.Fprintf(&sb, "    <itemref idref=\"c%d\"/>\n", i+1)
+	}
+	sb.WriteString("  </spine>\n</package>\n")
+	return sb.String()
+}
+
+// xhtmlPage wraps body in the XHTML document every content file of an EPUB must be
+func xhtmlPage(title, body string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>` + html.EscapeString(title) + `</title></head>
+<body>
+` + body + `</body>
+</html>
+`
+}
+
+func epubNav(book Book) string {
+	var sb strings.Builder
+	sb.WriteString("<nav epub:type=\"toc\"><h1>Contents</h1><ol>\n")
+	for i, ch := range book.Chapters {
+		fmt.Fprintf(&sb, "<li><a href=\"%s\">%s</a></li>\n", chapterFile(i), html.EscapeString(chapterTitle(ch)))
+	}
+	sb.WriteString("</ol></nav>\n")
+	return xhtmlPage(book.Title, sb.String())
+}
+
+func epubChapter(ch BookChapter) (string, error) {
+	md := ch.Metadata
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(chapterTitle(ch)))
+	var about []string
+	if md.Author != "" {
+		about = append(about, html.EscapeString(md.Author))
+	}
+	if md.PublishDate != "" {
+		about = append(about, md.PublishDate)
+	}
+	about = append(about, fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(DeepLink(md.VideoID, 0)), html.EscapeString(DeepLink(md.VideoID, 0))))
+	fmt.Fprintf(&sb, "<p><em>%s</em></p>\n", strings.Join(about, " · "))
+	if md.Description != "" {
+		fmt.Fprintf(&sb, "<blockquote><p>%s</p></blockquote>\n", strings.ReplaceAll(html.EscapeString(strings.TrimSpace(md.Description)), "\n", "<br/>"))
+	}
+
+	paragraphs, err := NewSpeakerTurns().Process(DedupOverlap(StripAnnotations(ch.Entries)))
+	if err != nil {
+		return "", err
+	}
+	for _, p := range paragraphs {
+		fmt.Fprintf(&sb, "<p><a href=\"%s\">%s</a> %s</p>\n", html.EscapeString(DeepLink(md.VideoID, p.Start)), FormatClock(p.Start), html.EscapeString(p.Text))
+	}
+	return xhtmlPage(chapterTitle(ch), sb.String()), nil
+}
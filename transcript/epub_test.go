@@ -0,0 +1,57 @@
+package transcript
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteEPUB(t *testing.T) {
+	book := Book{
+		Title:    "Lectures & Notes",
+		Modified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Chapters: []BookChapter{
+			{Metadata: VideoMetadata{VideoID: "abcdefghijk", Title: "Part <1>", Author: "Prof"}, Entries: []TranscriptEntry{{Text: "Hello.", Start: 65, Duration: 2}}},
+			{Metadata: VideoMetadata{VideoID: "bcdefghijkl"}, Entries: []TranscriptEntry{{Text: "Again.", Start: 0, Duration: 2}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, book); err != nil {
+		t.Fatalf("WriteEPUB() error = %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("opening %s: %v", f.Name, err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	if zr.File[0].Name != "mimetype" || zr.File[0].Method != zip.Store || files["mimetype"] != "application/epub+zip" {
+		t.Errorf("first entry = %s (method %d); want an uncompressed mimetype", zr.File[0].Name, zr.File[0].Method)
+	}
+
+	checks := map[string][]string{
+		"OEBPS/content.opf":      {"<dc:title>Lectures &amp; Notes</dc:title>", "urn:youtube:abcdefghijk,bcdefghijkl", "2024-01-02T03:04:05Z", `<itemref idref="c2"/>`},
+		"OEBPS/nav.xhtml":        {`<a href="chapter001.xhtml">Part &lt;1&gt;</a>`, `<a href="chapter002.xhtml">bcdefghijkl</a>`},
+		"OEBPS/chapter001.xhtml": {"<h1>Part &lt;1&gt;</h1>", "Prof", `<a href="https://youtu.be/abcdefghijk?t=65">1:05</a> Hello.`},
+	}
+	for name, wants := range checks {
+		for _, want := range wants {
+			if !strings.Contains(files[name], want) {
+				t.Errorf("%s = %s; want it to contain %q", name, files[name], want)
+			}
+		}
+	}
+}
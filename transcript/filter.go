@@ -0,0 +1,38 @@
+package transcript
+
+import "regexp"
+
+// Predicate reports whether an entry should be kept by FilterEntries
+type Predicate func(TranscriptEntry) bool
+
+// FilterEntries returns the entries keep reports true for, in order
+func FilterEntries(entries []TranscriptEntry, keep func(TranscriptEntry) bool) []TranscriptEntry {
+	out := make([]TranscriptEntry, 0, len(entries))
+	for _, e := range entries {
+		if keep(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// ByTimeRange keeps the entries overlapping the span from start to end, in seconds
+func ByTimeRange(start, end float64) Predicate {
+	return func(e TranscriptEntry) bool {
+		return e.Start < end && e.Start+e.Duration > start
+	}
+}
+
+// ByRegexp keeps the entries whose text matches re
+func ByRegexp(re *regexp.Regexp) Predicate {
+	return func(e TranscriptEntry) bool {
+		return re.MatchString(e.Text)
+	}
+}
+
+// MinDuration keeps the entries lasting at least seconds
+func MinDuration(seconds float64) Predicate {
+	return func(e TranscriptEntry) bool {
+		return e.Duration >= seconds
+	}
+}
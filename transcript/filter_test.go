@@ -0,0 +1,47 @@
+package transcript
+
+import (
+	"regexp"
+	"testing"
+)
+
+var filterEntries = []TranscriptEntry{
+	{Text: "welcome back", Start: 0, Duration: 2},
+	{Text: "um", Start: 2, Duration: 0.3},
+	{Text: "today we talk about Go", Start: 2.3, Duration: 3},
+	{Text: "and about Rust", Start: 10, Duration: 2},
+}
+
+func filteredTexts(entries []TranscriptEntry) []string {
+	texts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		texts = append(texts, e.Text)
+	}
+	return texts
+}
+
+func TestFilterEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		keep Predicate
+		want []string
+	}{
+		{"time range", ByTimeRange(1, 5), []string{"welcome back", "um", "today we talk about Go"}},
+		{"time range excludes touching entries", ByTimeRange(5.3, 10), []string{}},
+		{"regexp", ByRegexp(regexp.MustCompile(`(?i)about (go|rust)`)), []string{"today we talk about Go", "and about Rust"}},
+		{"min duration", MinDuration(1), []string{"welcome back", "today we talk about Go", "and about Rust"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filteredTexts(FilterEntries(filterEntries, tt.keep))
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterEntries() = %q; want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FilterEntries() = %q; want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package transcript
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Fingerprint returns a content hash of a transcript that changes exactly when its text or
+// timing does, such as "sha256:9f86d0…". Times are compared to the millisecond and text
+// without surrounding whitespace, so re-fetching unchanged captions gives the same value.
+func Fingerprint(entries []TranscriptEntry) string {
+	h := sha256.New()
+	for _, e := range entries {
+		fmt.Fprintf(h, "%d\t%d\t%s\n", int64(math.Round(e.Start*1000)), int64(math.Round(e.Duration*1000)), strings.TrimSpace(e.Text))
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
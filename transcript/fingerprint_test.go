@@ -0,0 +1,25 @@
+package transcript
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	entries := []TranscriptEntry{{Text: "Hello", Start: 1.0001, Duration: 2}, {Text: "world", Start: 3, Duration: 1}}
+	same := []TranscriptEntry{{Text: " Hello ", Start: 1, Duration: 2}, {Text: "world", Start: 3, Duration: 1}}
+	edited := []TranscriptEntry{{Text: "Hello", Start: 1, Duration: 2}, {Text: "World", Start: 3, Duration: 1}}
+	shifted := []TranscriptEntry{{Text: "Hello", Start: 1.5, Duration: 2}, {Text: "world", Start: 3, Duration: 1}}
+
+	fp := Fingerprint(entries)
+	if len(fp) != len("sha256:")+64 {
+		t.Errorf("Fingerprint() = %q; want sha256: and 64 hex digits", fp)
+	}
+	if got := Fingerprint(same); got != fp {
+		t.Errorf("Fingerprint(same) = %q; want %q", got, fp)
+	}
+	if Fingerprint(edited) == fp || Fingerprint(shifted) == fp {
+		t.Errorf("Fingerprint() of edited or shifted captions = %q; want it to differ", fp)
+	}
+	// Text moving between entries changes the hash too
+	if Fingerprint([]TranscriptEntry{{Text: "a\tb"}}) == Fingerprint([]TranscriptEntry{{Text: "a"}, {Text: "b"}}) {
+		t.Errorf("Fingerprint() ignores entry boundaries")
+	}
+}
@@ -0,0 +1,444 @@
+package transcript
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format identifies an output representation of a transcript
+type Format string
+
+// Supported output formats
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+	FormatSRT  Format = "srt"
+	FormatVTT  Format = "vtt"
+	FormatCSV  Format = "csv"
+	FormatMD   Format = "md"
+	// FormatProse is article-style text: annotations stripped and cues joined into paragraphs
+	FormatProse Format = "prose"
+	// FormatAudacity is an Audacity label track: start, end and text separated by tabs
+	FormatAudacity Format = "audacity"
+)
+
+// Formats lists every supported output format
+var Formats = []Format{FormatText, FormatJSON, FormatSRT, FormatVTT, FormatCSV, FormatMD, FormatProse, FormatAudacity}
+
+// Field is a column of a transcript entry that JSON and CSV output can be restricted to
+type Field string
+
+// Selectable fields; FieldEnd is derived as start + duration
+const (
+	FieldText     Field = "text"
+	FieldStart    Field = "start"
+	FieldDuration Field = "duration"
+	FieldEnd      Field = "end"
+)
+
+// defaultFields are the columns written when no selection is made, in order
+var defaultFields = []Field{FieldText, FieldStart, FieldDuration}
+
+// ParseFields parses a comma-separated field list such as "start,text"
+func ParseFields(list string) ([]Field, error) {
+	var fields []Field
+	for _, name := range strings.Split(list, ",") {
+		f := Field(strings.ToLower(strings.TrimSpace(name)))
+		switch f {
+		case "":
+			continue
+		case FieldText, FieldStart, FieldDuration, FieldEnd:
+			fields = append(fields, f)
+		default:
+			return nil, fmt.Errorf("unknown field: %s", name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields selected")
+	}
+	return fields, nil
+}
+
+// value returns the field of an entry as a string or float64
+func (f Field) value(e TranscriptEntry) interface{} {
+	switch f {
+	case FieldStart:
+		return e.Start
+	case FieldDuration:
+		return e.Duration
+	case FieldEnd:
+		return e.Start + e.Duration
+	default:
+		return e.Text
+	}
+}
+
+// ParseFormat validates a format name, case-insensitively
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(name)))
+	for _, known := range Formats {
+		if f == known {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format: %s", name)
+}
+
+// ContentType returns the MIME type for the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	case FormatSRT:
+		return "application/x-subrip; charset=utf-8"
+	case FormatVTT:
+		return "text/vtt; charset=utf-8"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatMD:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Extension returns the conventional file extension for the format, without the dot
+func (f Format) Extension() string {
+	if f == FormatText || f == FormatProse || f == FormatAudacity {
+		return "txt"
+	}
+	return string(f)
+}
+
+// WriteFormat writes entries to w in the given format
+func WriteFormat(w io.Writer, format Format, entries []TranscriptEntry) error {
+	return WriteFields(w, format, entries, nil)
+}
+
+// WriteFields writes only the given fields of each entry, in order. Field selection applies
+// to FormatJSON and FormatCSV; nil fields writes the format's default columns.
+func WriteFields(w io.Writer, format Format, entries []TranscriptEntry, fields []Field) error {
+	enc, err := newEntryEncoder(w, format, fields)
+	if err != nil {
+		return err
+	}
+	defer enc.release()
+	for _, e := range entries {
+		if err := enc.encode(e); err != nil {
+			return err
+		}
+	}
+	return enc.close()
+}
+
+// FormatEntries renders entries as a string in the given format
+func FormatEntries(format Format, entries []TranscriptEntry) (string, error) {
+	var sb strings.Builder
+	if err := WriteFormat(&sb, format, entries); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// entryEncoder writes entries one at a time so formats never need the whole transcript in memory
+type entryEncoder interface {
+	encode(e TranscriptEntry) error
+	close() error
+}
+
+// newEntryEncoder returns the encoder of format writing to w through a pooled buffered writer
+func newEntryEncoder(w io.Writer, format Format, fields []Field) (*pooledEncoder, error) {
+	bw := outputWriters.Get().(*bufio.Writer)
+	bw.Reset(w)
+	enc, err := newFormatEncoder(bw, format, fields)
+	if err != nil {
+		bw.Reset(nil)
+		outputWriters.Put(bw)
+		return nil, err
+	}
+	return &pooledEncoder{entryEncoder: enc, bw: bw}, nil
+}
+
+// newFormatEncoder returns the encoder of format writing to bw
+func newFormatEncoder(bw *bufio.Writer, format Format, fields []Field) (entryEncoder, error) {
+	if fields != nil && format != FormatJSON && format != FormatCSV {
+		return nil, fmt.Errorf("field selection is only supported for json and csv, not %s", format)
+	}
+
+	switch format {
+	case FormatText:
+		return &textEncoder{w: bw}, nil
+	case FormatJSON:
+		return &jsonEncoder{w: bw, fields: fields}, nil
+	case FormatSRT:
+		return &srtEncoder{w: bw}, nil
+	case FormatVTT:
+		return &vttEncoder{w: bw}, nil
+	case FormatCSV:
+		if fields == nil {
+			fields = defaultFields
+		}
+		return &csvEncoder{w: bw, csv: csv.NewWriter(bw), fields: fields}, nil
+	case FormatMD:
+		return &mdEncoder{w: bw}, nil
+	case FormatProse:
+		return &proseEncoder{w: bw}, nil
+	case FormatAudacity:
+		return &audacityEncoder{w: bw}, nil
+	}
+	return nil, fmt.Errorf("unknown format: %s", format)
+}
+
+// textEncoder matches ConcatenateTranscript: one entry per line, no trailing newline
+type textEncoder struct {
+	w *bufio.Writer
+	n int
+}
+
+func (e *textEncoder) encode(entry TranscriptEntry) error {
+	if e.n > 0 {
+		e.w.WriteByte('\n')
+	}
+	e.n++
+	_, err := e.w.WriteString(entry.Text)
+	return err
+}
+
+func (e *textEncoder) close() error {
+	return e.w.Flush()
+}
+
+// jsonEntry is the JSON representation of a TranscriptEntry
+type jsonEntry struct {
+	Text     string  `json:"text"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
+}
+
+type jsonEncoder struct {
+	w      *bufio.Writer
+	n      int
+	fields []Field
+}
+
+func (e *jsonEncoder) encode(entry TranscriptEntry) error {
+	if e.n == 0 {
+		e.w.WriteByte('[')
+	} else {
+		e.w.WriteByte(',')
+	}
+	e.n++
+	if e.fields == nil {
+		return writeJSONValue(e.w, jsonEntry{Text: entry.Text, Start: entry.Start, Duration: entry.Duration})
+	}
+
+	// Write the object by hand to keep the requested key order
+	e.w.WriteByte('{')
+	for i, f := range e.fields {
+		if i > 0 {
+			e.w.WriteByte(',')
+		}
+		fmt.Fprintf(e.w, "%q:", f)
+		if err := writeJSONValue(e.w, f.value(entry)); err != nil {
+			return err
+		}
+	}
+	return e.w.WriteByte('}')
+}
+
+func (e *jsonEncoder) close() error {
+	if e.n == 0 {
+		e.w.WriteByte('[')
+	}
+	e.w.WriteString("]\n")
+	return e.w.Flush()
+}
+
+type srtEncoder struct {
+	w *bufio.Writer
+	n int
+}
+
+func (e *srtEncoder) encode(entry TranscriptEntry) error {
+	e.n++
+	_, err := fmt.Fprintf(e.w, "%d\n%s --> %s\n%s\n\n", e.n,
+		FormatTimestamp(entry.Start, ','), FormatTimestamp(entry.Start+entry.Duration, ','), entry.Text)
+	return err
+}
+
+func (e *srtEncoder) close() error {
+	return e.w.Flush()
+}
+
+type vttEncoder struct {
+	w       *bufio.Writer
+	started bool
+}
+
+func (e *vttEncoder) header() {
+	if !e.started {
+		e.w.WriteString("WEBVTT\n\n")
+		e.started = true
+	}
+}
+
+func (e *vttEncoder) encode(entry TranscriptEntry) error {
+	e.header()
+	_, err := fmt.Fprintf(e.w, "%s --> %s\n%s\n\n",
+		FormatTimestamp(entry.Start, '.'), FormatTimestamp(entry.Start+entry.Duration, '.'), entry.Text)
+	return err
+}
+
+func (e *vttEncoder) close() error {
+	e.header()
+	return e.w.Flush()
+}
+
+// csvEncoder writes a header row followed by one row per entry
+type csvEncoder struct {
+	w       *bufio.Writer
+	csv     *csv.Writer
+	fields  []Field
+	started bool
+}
+
+func (e *csvEncoder) header() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	row := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		row[i] = string(f)
+	}
+	return e.csv.Write(row)
+}
+
+func (e *csvEncoder) encode(entry TranscriptEntry) error {
+	if err := e.header(); err != nil {
+		return err
+	}
+	row := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		switch v := f.value(entry).(type) {
+		case float64:
+			row[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			row[i] = v
+		}
+	}
+	return e.csv.Write(row)
+}
+
+func (e *csvEncoder) close() error {
+	if err := e.header(); err != nil {
+		return err
+	}
+	e.csv.Flush()
+	if err := e.csv.Error(); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// mdEncoder writes a Markdown list item per entry, prefixed with its start time
+type mdEncoder struct {
+	w *bufio.Writer
+}
+
+// mdEscaper keeps caption text from being read as Markdown markup
+var mdEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`)
+
+func (e *mdEncoder) encode(entry TranscriptEntry) error {
+	_, err := fmt.Fprintf(e.w, "- **%s** %s\n", FormatClock(entry.Start), mdEscaper.Replace(entry.Text))
+	return err
+}
+
+func (e *mdEncoder) close() error {
+	return e.w.Flush()
+}
+
+// proseEncoder collects the entries, since paragraphs depend on the cues around them, and
+// writes them on close as paragraphs separated by blank lines
+type proseEncoder struct {
+	w       *bufio.Writer
+	entries []TranscriptEntry
+}
+
+func (e *proseEncoder) encode(entry TranscriptEntry) error {
+	e.entries = append(e.entries, entry)
+	return nil
+}
+
+func (e *proseEncoder) close() error {
+	turns := NewSpeakerTurns()
+	turns.SpeakerPrefix = ""
+	paragraphs, err := turns.Process(DedupOverlap(StripAnnotations(e.entries)))
+	if err != nil {
+		return err
+	}
+	for i, p := range paragraphs {
+		if i > 0 {
+			e.w.WriteString("\n")
+		}
+		fmt.Fprintf(e.w, "%s\n", p.Text)
+	}
+	return e.w.Flush()
+}
+
+// audacityEncoder writes a label per entry in the tab-separated format of Audacity's
+// Import Labels, times in seconds
+type audacityEncoder struct {
+	w *bufio.Writer
+}
+
+// labelText keeps caption text on one line of a label track
+var labelText = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+func (e *audacityEncoder) encode(entry TranscriptEntry) error {
+	_, err := fmt.Fprintf(e.w, "%.6f\t%.6f\t%s\n", entry.Start, entry.Start+entry.Duration, labelText.Replace(entry.Text))
+	return err
+}
+
+func (e *audacityEncoder) close() error {
+	return e.w.Flush()
+}
+
+// writeJSONValue writes v as compact JSON without HTML escaping or a trailing newline
+func writeJSONValue(w *bufio.Writer, v interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return err
+}
+
+// FormatTimestamp renders seconds as HH:MM:SS<sep>mmm, the cue times of SRT (sep ,) and WebVTT (sep .)
+func FormatTimestamp(seconds float64, sep byte) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	ms := int64(seconds*1000 + 0.5)
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// FormatClock renders seconds as M:SS, or H:MM:SS from one hour on, as YouTube shows times
+func FormatClock(seconds float64) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	s := int64(seconds)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
@@ -0,0 +1,157 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+var sampleEntries = []TranscriptEntry{
+	{Text: "Hello & welcome", Start: 0.5, Duration: 1.5},
+	{Text: "to the show", Start: 3661.25, Duration: 2},
+}
+
+func TestFormatEntries(t *testing.T) {
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{
+			format:   FormatText,
+			expected: "Hello & welcome\nto the show",
+		},
+		{
+			format:   FormatJSON,
+			expected: `[{"text":"Hello & welcome","start":0.5,"duration":1.5},{"text":"to the show","start":3661.25,"duration":2}]` + "\n",
+		},
+		{
+			format: FormatSRT,
+			expected: "1\n00:00:00,500 --> 00:00:02,000\nHello & welcome\n\n" +
+				"2\n01:01:01,250 --> 01:01:03,250\nto the show\n\n",
+		},
+		{
+			format: FormatVTT,
+			expected: "WEBVTT\n\n00:00:00.500 --> 00:00:02.000\nHello & welcome\n\n" +
+				"01:01:01.250 --> 01:01:03.250\nto the show\n\n",
+		},
+		{
+			format:   FormatCSV,
+			expected: "text,start,duration\nHello & welcome,0.5,1.5\nto the show,3661.25,2\n",
+		},
+		{
+			format:   FormatMD,
+			expected: "- **0:00** Hello & welcome\n- **1:01:01** to the show\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			result, err := FormatEntries(tt.format, sampleEntries)
+			if err != nil {
+				t.Fatalf("FormatEntries() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("FormatEntries(%s) = %q; want %q", tt.format, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatEntries_Empty(t *testing.T) {
+	if result, _ := FormatEntries(FormatJSON, nil); result != "[]\n" {
+		t.Errorf("FormatEntries(json, nil) = %q; want %q", result, "[]\n")
+	}
+	if result, _ := FormatEntries(FormatVTT, nil); result != "WEBVTT\n\n" {
+		t.Errorf("FormatEntries(vtt, nil) = %q; want %q", result, "WEBVTT\n\n")
+	}
+}
+
+func TestFormatEntries_Prose(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "[Music]", Start: 0, Duration: 2},
+		{Text: "Welcome back to the", Start: 2, Duration: 1},
+		{Text: "show.", Start: 3, Duration: 1},
+		{Text: "Today is special.", Start: 10, Duration: 1},
+		{Text: ">> Thanks for having me.", Start: 11, Duration: 1},
+	}
+	want := "Welcome back to the show.\n\nToday is special.\n\nThanks for having me.\n"
+	if result, _ := FormatEntries(FormatProse, entries); result != want {
+		t.Errorf("FormatEntries(prose) = %q; want %q", result, want)
+	}
+}
+
+func TestFormatEntries_Audacity(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "Hello\tthere", Start: 1.5, Duration: 2},
+		{Text: "two\nlines", Start: 4, Duration: 1.25},
+	}
+	want := "1.500000\t3.500000\tHello there\n4.000000\t5.250000\ttwo lines\n"
+	if result, _ := FormatEntries(FormatAudacity, entries); result != want {
+		t.Errorf("FormatEntries(audacity) = %q; want %q", result, want)
+	}
+}
+
+func TestFormatEntries_MarkdownEscaping(t *testing.T) {
+	entries := []TranscriptEntry{{Text: "[Music] *clap* my_var", Start: 65}}
+	want := "- **1:05** \\[Music\\] \\*clap\\* my\\_var\n"
+	if result, _ := FormatEntries(FormatMD, entries); result != want {
+		t.Errorf("FormatEntries(md) = %q; want %q", result, want)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(" SRT "); err != nil || f != FormatSRT {
+		t.Errorf("ParseFormat(SRT) = %v, %v; want srt", f, err)
+	}
+	if _, err := ParseFormat("docx"); err == nil {
+		t.Error("ParseFormat(docx) expected error")
+	}
+}
+
+func TestWriteFields(t *testing.T) {
+	tests := []struct {
+		format   Format
+		fields   string
+		expected string
+	}{
+		{
+			format:   FormatJSON,
+			fields:   "start,text",
+			expected: `[{"start":0.5,"text":"Hello & welcome"},{"start":3661.25,"text":"to the show"}]` + "\n",
+		},
+		{
+			format:   FormatCSV,
+			fields:   "text",
+			expected: "text\nHello & welcome\nto the show\n",
+		},
+		{
+			format:   FormatCSV,
+			fields:   "start, end",
+			expected: "start,end\n0.5,2\n3661.25,3663.25\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format)+" "+tt.fields, func(t *testing.T) {
+			fields, err := ParseFields(tt.fields)
+			if err != nil {
+				t.Fatalf("ParseFields() error = %v", err)
+			}
+			var sb strings.Builder
+			if err := WriteFields(&sb, tt.format, sampleEntries, fields); err != nil {
+				t.Fatalf("WriteFields() error = %v", err)
+			}
+			if sb.String() != tt.expected {
+				t.Errorf("WriteFields() = %q; want %q", sb.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteFields_Errors(t *testing.T) {
+	if _, err := ParseFields("start,speaker"); err == nil {
+		t.Error("ParseFields(speaker) expected error")
+	}
+	if err := WriteFields(&strings.Builder{}, FormatSRT, sampleEntries, []Field{FieldText}); err == nil {
+		t.Error("WriteFields(srt) expected error")
+	}
+}
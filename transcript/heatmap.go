@@ -0,0 +1,152 @@
+package transcript
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// HeatMarker is one segment of the "most replayed" graph above the player's progress bar.
+// Intensity is normalized so the most replayed segment of the video has 1.
+type HeatMarker struct {
+	Start     float64 `json:"start"`
+	Duration  float64 `json:"duration"`
+	Intensity float64 `json:"intensity"`
+}
+
+// Highlight is a run of highly replayed segments with the captions spoken during it
+type Highlight struct {
+	Start     float64 `json:"start"`
+	End       float64 `json:"end"`
+	Intensity float64 `json:"intensity"` // of the most replayed segment in the run
+	Text      string  `json:"text"`
+}
+
+// heatMarkerPatterns match the markers of the current and the older watch page layouts
+var heatMarkerPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`"startMillis":"(\d+)","durationMillis":"(\d+)","intensityScoreNormalized":([0-9.eE+-]+)`),
+	regexp.MustCompile(`"timeRangeStartMillis":(\d+),"markerDurationMillis":(\d+),"heatMarkerIntensityScoreNormalized":([0-9.eE+-]+)`),
+}
+
+// ParseHeatmap extracts the most replayed markers from the HTML of a watch page, in order.
+// Videos with too few views have none.
+func ParseHeatmap(watchPage string) []HeatMarker {
+	for _, pattern := range heatMarkerPatterns {
+		matches := pattern.FindAllStringSubmatch(watchPage, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		markers := make([]HeatMarker, 0, len(matches))
+		seen := make(map[float64]bool)
+		for _, m := range matches {
+			start, _ := strconv.ParseFloat(m[1], 64)
+			duration, _ := strconv.ParseFloat(m[2], 64)
+			intensity, _ := strconv.ParseFloat(m[3], 64)
+			// Pages repeat the graph for the player and the mobile layout
+			if seen[start] {
+				continue
+			}
+			seen[start] = true
+			markers = append(markers, HeatMarker{Start: start / 1000, Duration: duration / 1000, Intensity: intensity})
+		}
+		sort.Slice(markers, func(i, j int) bool { return markers[i].Start < markers[j].Start })
+		return markers
+	}
+	return []HeatMarker{}
+}
+
+// GetHeatmap fetches the most replayed markers of a video
+func (c *Client) GetHeatmap(videoID string) ([]HeatMarker, error) {
+	videoInfo, err := c.fetchVideoInfo(videoID)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHeatmap(videoInfo), nil
+}
+
+// Highlights picks the n most replayed markers, joins adjacent ones into runs, and returns
+// the runs in order with the text of the entries overlapping them. An n of 0 or less
+// returns none.
+func Highlights(entries []TranscriptEntry, markers []HeatMarker, n int) []Highlight {
+	if n <= 0 {
+		return []Highlight{}
+	}
+	top := append([]HeatMarker(nil), markers...)
+	sort.SliceStable(top, func(i, j int) bool { return top[i].Intensity > top[j].Intensity })
+	if len(top) > n {
+		top = top[:n]
+	}
+	sort.Slice(top, func(i, j int) bool { return top[i].Start < top[j].Start })
+
+	highlights := []Highlight{}
+	for _, m := range top {
+		if last := len(highlights) - 1; last >= 0 && m.Start <= highlights[last].End+0.001 {
+			highlights[last].End = m.Start + m.Duration
+			highlights[last].Intensity = max(highlights[last].Intensity, m.Intensity)
+			continue
+		}
+		highlights = append(highlights, Highlight{Start: m.Start, End: m.Start + m.Duration, Intensity: m.Intensity})
+	}
+
+	for i := range highlights {
+		h := &highlights[i]
+		for _, e := range entries {
+			if e.Start < h.End && e.Start+e.Duration > h.Start {
+				if h.Text != "" {
+					h.Text += " "
+				}
+				h.Text += e.Text
+			}
+		}
+	}
+	return highlights
+}
+
+// GetHighlights fetches a video's transcript, chosen as in FindTranscript, and most replayed
+// markers, and returns the text of its n most replayed segments
+func (c *Client) GetHighlights(videoID string, languageCode string, n int) ([]Highlight, error) {
+	videoInfo, err := c.fetchVideoInfo(videoID)
+	if err != nil {
+		return nil, err
+	}
+	markers := ParseHeatmap(videoInfo)
+	if len(markers) == 0 {
+		return []Highlight{}, nil
+	}
+
+	// The watch page already lists the tracks, unless another backend provides them
+	var t Transcript
+	if c.backend != nil {
+		t, err = c.FindTranscript(videoID, languageCode)
+	} else {
+		t, err = chooseFromPage(videoID, videoInfo, languageCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries, err := c.fetchTranscript(t)
+	if err != nil {
+		return nil, err
+	}
+	return Highlights(entries, markers, n), nil
+}
+
+// chooseFromPage selects a track of a fetched watch page as FindTranscript does
+func chooseFromPage(videoID, videoInfo, languageCode string) (Transcript, error) {
+	if err := checkPlayability(videoID, videoInfo); err != nil {
+		return Transcript{}, err
+	}
+	transcripts, err := extractTranscriptData(videoID, videoInfo)
+	if err != nil {
+		return Transcript{}, err
+	}
+	var sel LanguageSelection
+	if languageCode != "" {
+		sel.Languages = []string{languageCode}
+	}
+	t, ok := sel.Choose(transcripts)
+	if !ok {
+		return Transcript{}, ErrNoTranscriptFound{VideoID: videoID, Language: languageCode}
+	}
+	return t, nil
+}
@@ -0,0 +1,81 @@
+package transcript
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleHeatmap = `"markers":[` +
+	`{"startMillis":"0","durationMillis":"10000","intensityScoreNormalized":0.2},` +
+	`{"startMillis":"10000","durationMillis":"10000","intensityScoreNormalized":1},` +
+	`{"startMillis":"20000","durationMillis":"10000","intensityScoreNormalized":0.9},` +
+	`{"startMillis":"3650000","durationMillis":"10000","intensityScoreNormalized":0.8}]`
+
+func TestParseHeatmap(t *testing.T) {
+	markers := ParseHeatmap("<html>" + sampleHeatmap + sampleHeatmap + "</html>")
+	if len(markers) != 4 {
+		t.Fatalf("ParseHeatmap() = %d markers; want 4 without repeats", len(markers))
+	}
+	if markers[1] != (HeatMarker{Start: 10, Duration: 10, Intensity: 1}) {
+		t.Errorf("markers[1] = %+v", markers[1])
+	}
+
+	old := `"heatMarkerRenderer":{"timeRangeStartMillis":5000,"markerDurationMillis":5000,"heatMarkerIntensityScoreNormalized":0.5}`
+	if got := ParseHeatmap(old); len(got) != 1 || got[0] != (HeatMarker{Start: 5, Duration: 5, Intensity: 0.5}) {
+		t.Errorf("ParseHeatmap() of the older layout = %+v", got)
+	}
+	if got := ParseHeatmap(sampleWatchPage); len(got) != 0 {
+		t.Errorf("ParseHeatmap() without markers = %+v", got)
+	}
+}
+
+func TestHighlights(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "intro", Start: 0, Duration: 9},
+		{Text: "the best part", Start: 12, Duration: 5},
+		{Text: "continues here", Start: 21, Duration: 5},
+		{Text: "finale", Start: 3655, Duration: 2},
+	}
+	got := Highlights(entries, ParseHeatmap(sampleHeatmap), 3)
+	want := []Highlight{
+		{Start: 10, End: 30, Intensity: 1, Text: "the best part continues here"},
+		{Start: 3650, End: 3660, Intensity: 0.8, Text: "finale"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Highlights() = %+v; want %+v", got, want)
+	}
+}
+
+func TestHighlights_NonPositiveN(t *testing.T) {
+	markers := ParseHeatmap(sampleHeatmap)
+	for _, n := range []int{0, -1} {
+		if got := Highlights(nil, markers, n); len(got) != 0 {
+			t.Errorf("Highlights(n=%d) = %+v; want none", n, got)
+		}
+	}
+}
+
+func TestGetHighlights(t *testing.T) {
+	fake := fakeYouTube(t)
+	watchPages := 0
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/watch" {
+			watchPages++
+			return textResponse(r, strings.Replace(sampleWatchPage, "</script>", ";var ytInitialData = {"+sampleHeatmap+"};</script>", 1)), nil
+		}
+		return fake(r)
+	})))
+
+	highlights, err := client.GetHighlights("abcdefghijk", "de", 1)
+	if err != nil {
+		t.Fatalf("GetHighlights() error = %v", err)
+	}
+	if len(highlights) != 1 || highlights[0].Start != 10 {
+		t.Errorf("GetHighlights() = %+v; want the segment at 10s", highlights)
+	}
+	if watchPages != 1 {
+		t.Errorf("fetched %d watch pages; want 1", watchPages)
+	}
+}
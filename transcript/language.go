@@ -0,0 +1,76 @@
+package transcript
+
+import "strings"
+
+// LanguageSelection describes which caption track to pick among those available for a video
+type LanguageSelection struct {
+	// Languages lists language code prefixes in priority order ("en" matches "en-US").
+	// When empty, English is preferred and any other track is accepted as a fallback.
+	Languages []string
+	// PreferManual picks an uploader-provided track over an auto-generated one in the same language
+	PreferManual bool
+	// GeneratedOnly only considers auto-generated (ASR) tracks
+	GeneratedOnly bool
+}
+
+// Choose returns the best track for the selection, or false if none qualifies
+func (sel LanguageSelection) Choose(transcripts []Transcript) (Transcript, bool) {
+	var candidates []Transcript
+	for _, t := range transcripts {
+		if sel.GeneratedOnly && !t.IsGenerated {
+			continue
+		}
+		candidates = append(candidates, t)
+	}
+	if len(candidates) == 0 {
+		return Transcript{}, false
+	}
+
+	languages := sel.Languages
+	if len(languages) == 0 {
+		languages = []string{"en"}
+	}
+
+	for _, lang := range languages {
+		if t, ok := sel.best(candidates, lang); ok {
+			return t, true
+		}
+	}
+
+	// Without an explicit language list, fall back to the first available track
+	if len(sel.Languages) == 0 {
+		return sel.best(candidates, "")
+	}
+	return Transcript{}, false
+}
+
+// best returns the first track whose language code starts with prefix, honoring PreferManual
+func (sel LanguageSelection) best(candidates []Transcript, prefix string) (Transcript, bool) {
+	var (
+		first Transcript
+		found bool
+	)
+	for _, t := range candidates {
+		if !strings.HasPrefix(t.LanguageCode, prefix) {
+			continue
+		}
+		if !sel.PreferManual || !t.IsGenerated {
+			return t, true
+		}
+		if !found {
+			first, found = t, true
+		}
+	}
+	return first, found
+}
+
+// ParseLanguageList splits a comma-separated priority list such as "de,en-GB,en"
+func ParseLanguageList(list string) []string {
+	var languages []string
+	for _, lang := range strings.Split(list, ",") {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			languages = append(languages, lang)
+		}
+	}
+	return languages
+}
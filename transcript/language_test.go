@@ -0,0 +1,52 @@
+package transcript
+
+import "testing"
+
+func TestLanguageSelection_Choose(t *testing.T) {
+	tracks := []Transcript{
+		{LanguageCode: "de", IsGenerated: false},
+		{LanguageCode: "en", IsGenerated: true},
+		{LanguageCode: "en-GB", IsGenerated: false},
+		{LanguageCode: "fr", IsGenerated: true},
+	}
+
+	tests := []struct {
+		name     string
+		sel      LanguageSelection
+		expected string
+		ok       bool
+	}{
+		{name: "Default prefers English", sel: LanguageSelection{}, expected: "en", ok: true},
+		{name: "Prefer manual English", sel: LanguageSelection{PreferManual: true}, expected: "en-GB", ok: true},
+		{name: "Priority list", sel: LanguageSelection{Languages: []string{"es", "fr", "de"}}, expected: "fr", ok: true},
+		{name: "Generated only", sel: LanguageSelection{Languages: []string{"de", "fr"}, GeneratedOnly: true}, expected: "fr", ok: true},
+		{name: "No match", sel: LanguageSelection{Languages: []string{"ja"}}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.sel.Choose(tracks)
+			if ok != tt.ok || got.LanguageCode != tt.expected {
+				t.Errorf("Choose() = %q, %v; want %q, %v", got.LanguageCode, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLanguageSelection_Fallback(t *testing.T) {
+	tracks := []Transcript{{LanguageCode: "ja", IsGenerated: true}, {LanguageCode: "ko"}}
+
+	if got, _ := (LanguageSelection{}).Choose(tracks); got.LanguageCode != "ja" {
+		t.Errorf("Choose() = %q; want first track", got.LanguageCode)
+	}
+	if got, _ := (LanguageSelection{PreferManual: true}).Choose(tracks); got.LanguageCode != "ko" {
+		t.Errorf("Choose(PreferManual) = %q; want first manual track", got.LanguageCode)
+	}
+}
+
+func TestParseLanguageList(t *testing.T) {
+	got := ParseLanguageList(" de, en-GB,,en ")
+	if len(got) != 3 || got[0] != "de" || got[1] != "en-GB" || got[2] != "en" {
+		t.Errorf("ParseLanguageList() = %q", got)
+	}
+}
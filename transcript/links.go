@@ -0,0 +1,11 @@
+package transcript
+
+import "fmt"
+
+// DeepLink returns a short watch URL that starts playback at the given second
+func DeepLink(videoID string, seconds float64) string {
+	if seconds < 1 {
+		return fmt.Sprintf("https://youtu.be/%s", videoID)
+	}
+	return fmt.Sprintf("https://youtu.be/%s?t=%d", videoID, int(seconds))
+}
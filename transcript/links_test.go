@@ -0,0 +1,19 @@
+package transcript
+
+import "testing"
+
+func TestDeepLink(t *testing.T) {
+	tests := []struct {
+		seconds  float64
+		expected string
+	}{
+		{0, "https://youtu.be/abcdefghijk"},
+		{83.9, "https://youtu.be/abcdefghijk?t=83"},
+	}
+
+	for _, tt := range tests {
+		if got := DeepLink("abcdefghijk", tt.seconds); got != tt.expected {
+			t.Errorf("DeepLink(%v) = %q; want %q", tt.seconds, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package transcript
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// livePollInterval is how long FollowLiveTranscript waits for YouTube to publish the next segment
+var livePollInterval = 2 * time.Second
+
+// liveCheckPolls is the number of empty polls after which the stream is checked to still be live
+const liveCheckPolls = 15
+
+// LiveTranscript follows the captions of an ongoing live stream
+type LiveTranscript struct {
+	// Entries receives the captions in order and is closed when the stream ends,
+	// the context is cancelled or fetching fails
+	Entries <-chan TranscriptEntry
+
+	done chan struct{}
+	err  error
+}
+
+// Err returns the error that stopped the stream, or nil if it ended or the context was
+// cancelled. It blocks until Entries is closed.
+func (l *LiveTranscript) Err() error {
+	<-l.done
+	return l.err
+}
+
+// FollowLiveTranscript follows the live captions of an ongoing live stream. YouTube publishes
+// them as timedtext segments with increasing sequence numbers, which are polled in turn and
+// passed on as they appear. Language selection follows FindTranscript.
+func (c *Client) FollowLiveTranscript(ctx context.Context, videoID string, languageCode string) (*LiveTranscript, error) {
+	videoInfo, err := c.fetchVideoInfoContext(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if !isLive(videoInfo) {
+		return nil, fmt.Errorf("video %s is not a live stream in progress", videoID)
+	}
+	// The track list comes from the watch page already fetched, rather than another request
+	if err := checkPlayability(videoID, videoInfo); err != nil {
+		return nil, err
+	}
+	transcripts, err := extractTranscriptData(videoID, videoInfo)
+	if err != nil {
+		return nil, err
+	}
+	var sel LanguageSelection
+	if languageCode != "" {
+		sel.Languages = []string{languageCode}
+	}
+	t, err := chooseTranscript(videoID, transcripts, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(chan TranscriptEntry)
+	l := &LiveTranscript{Entries: entries, done: make(chan struct{})}
+	go func() {
+		defer close(l.done)
+		defer close(entries)
+		l.err = c.followLive(ctx, videoID, t, entries)
+	}()
+	return l, nil
+}
+
+// followLive polls the segments of t until the stream ends or ctx is done
+func (c *Client) followLive(ctx context.Context, videoID string, t Transcript, entries chan<- TranscriptEntry) error {
+	last := -1.0
+	empty := 0
+	for seq := 0; ; {
+		segment, published, err := c.liveSegment(ctx, t, seq)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !published {
+			empty++
+			if empty%liveCheckPolls == 0 {
+				videoInfo, err := c.fetchVideoInfoContext(ctx, videoID)
+				if ctx.Err() != nil {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if !isLive(videoInfo) {
+					return nil
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(livePollInterval):
+			}
+			continue
+		}
+
+		empty = 0
+		seq++
+		for _, e := range segment {
+			// Consecutive segments may repeat the captions at their boundary
+			if e.Start <= last {
+				continue
+			}
+			last = e.Start
+			select {
+			case entries <- e:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// liveSegment fetches segment seq of a live track. It reports whether the segment is published
+// yet; a published segment may hold no captions, such as during a pause in speech.
+func (c *Client) liveSegment(ctx context.Context, t Transcript, seq int) ([]TranscriptEntry, bool, error) {
+	sep := "?"
+	if strings.Contains(t.BaseURL, "?") {
+		sep = "&"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.BaseURL+sep+"sq="+strconv.Itoa(seq), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil, false, nil
+	case http.StatusTooManyRequests:
+		return nil, false, ErrTooManyRequests{VideoID: videoIDFromURL(t.BaseURL)}
+	default:
+		return nil, false, fmt.Errorf("error fetching live captions: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	// An empty body stands for a segment still being written
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, false, nil
+	}
+	segment, err := ParseTranscriptXML(bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	return segment, true, nil
+}
+
+// isLive reports whether a watch page belongs to a live stream in progress
+func isLive(watchPage string) bool {
+	return strings.Contains(watchPage, `"isLive":true`)
+}
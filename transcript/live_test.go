@@ -0,0 +1,154 @@
+package transcript
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFollowLiveTranscript(t *testing.T) {
+	livePollInterval = time.Millisecond
+	defer func() { livePollInterval = 2 * time.Second }()
+
+	livePage := strings.Replace(sampleWatchPage, `{"captions"`, `{"videoDetails":{"isLive":true},"captions"`, 1)
+	segments := map[string]string{
+		"0": `<transcript><text start="0" dur="2">first</text><text start="2" dur="2">second</text></transcript>`,
+		// A published segment without captions, e.g. a pause in speech, must not stall the stream
+		"1": `<transcript></transcript>`,
+		"2": `<transcript><text start="2" dur="2">second</text><text start="4" dur="2">third</text></transcript>`,
+	}
+	watchPolls := 0
+	watchPollsBeforeSegments := -1
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/watch" {
+			watchPolls++
+			// The stream ends at the first liveness check after the last segment
+			if watchPolls > 1 {
+				return textResponse(r, sampleWatchPage), nil
+			}
+			return textResponse(r, livePage), nil
+		}
+		if watchPollsBeforeSegments < 0 {
+			watchPollsBeforeSegments = watchPolls
+		}
+		if body, ok := segments[r.URL.Query().Get("sq")]; ok {
+			return textResponse(r, body), nil
+		}
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	})))
+
+	live, err := client.FollowLiveTranscript(context.Background(), "abcdefghijk", "en")
+	if err != nil {
+		t.Fatalf("FollowLiveTranscript() error = %v", err)
+	}
+	var texts []string
+	for e := range live.Entries {
+		texts = append(texts, e.Text)
+	}
+	if err := live.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+	if got := strings.Join(texts, ","); got != "first,second,third" {
+		t.Errorf("entries = %s; want first,second,third", got)
+	}
+	if watchPollsBeforeSegments != 1 {
+		t.Errorf("watch page fetched %d times before the first segment; want 1", watchPollsBeforeSegments)
+	}
+}
+
+func TestFollowLiveTranscript_NotLive(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+	if _, err := client.FollowLiveTranscript(context.Background(), "abcdefghijk", ""); err == nil {
+		t.Errorf("FollowLiveTranscript() of a regular video error = nil; want an error")
+	}
+}
+
+func TestFollowLiveTranscript_Cancel(t *testing.T) {
+	livePollInterval = time.Millisecond
+	defer func() { livePollInterval = 2 * time.Second }()
+
+	livePage := strings.Replace(sampleWatchPage, `{"captions"`, `{"videoDetails":{"isLive":true},"captions"`, 1)
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/watch" {
+			return textResponse(r, livePage), nil
+		}
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	live, err := client.FollowLiveTranscript(ctx, "abcdefghijk", "")
+	if err != nil {
+		t.Fatalf("FollowLiveTranscript() error = %v", err)
+	}
+	cancel()
+	for range live.Entries {
+	}
+	if err := live.Err(); err != nil {
+		t.Errorf("Err() after cancel = %v; want nil", err)
+	}
+}
+
+func TestFollowLiveTranscript_CancelDuringPoll(t *testing.T) {
+	livePage := strings.Replace(sampleWatchPage, `{"captions"`, `{"videoDetails":{"isLive":true},"captions"`, 1)
+	polling := make(chan struct{})
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/watch" {
+			return textResponse(r, livePage), nil
+		}
+		// The segment request hangs until it is cancelled
+		close(polling)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	live, err := client.FollowLiveTranscript(ctx, "abcdefghijk", "")
+	if err != nil {
+		t.Fatalf("FollowLiveTranscript() error = %v", err)
+	}
+	<-polling
+	cancel()
+	for range live.Entries {
+	}
+	if err := live.Err(); err != nil {
+		t.Errorf("Err() after cancel = %v; want nil", err)
+	}
+}
+
+func TestFollowLiveTranscript_CancelDuringLivenessCheck(t *testing.T) {
+	livePollInterval = time.Millisecond
+	defer func() { livePollInterval = 2 * time.Second }()
+
+	livePage := strings.Replace(sampleWatchPage, `{"captions"`, `{"videoDetails":{"isLive":true},"captions"`, 1)
+	checking := make(chan struct{})
+	watchPolls := 0
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/watch" {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+		}
+		watchPolls++
+		if watchPolls == 1 {
+			return textResponse(r, livePage), nil
+		}
+		// The liveness check hangs until it is cancelled
+		close(checking)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	live, err := client.FollowLiveTranscript(ctx, "abcdefghijk", "")
+	if err != nil {
+		t.Fatalf("FollowLiveTranscript() error = %v", err)
+	}
+	<-checking
+	cancel()
+	for range live.Entries {
+	}
+	if err := live.Err(); err != nil {
+		t.Errorf("Err() after cancel = %v; want nil", err)
+	}
+}
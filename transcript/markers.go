@@ -0,0 +1,99 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// DefaultMarkerFPS is the frame rate of marker timecodes when none is given
+const DefaultMarkerFPS = 30
+
+// Marker is a named span of a video for the timeline of a video editor
+type Marker struct {
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
+	Name     string  `json:"name"`
+	Note     string  `json:"note,omitempty"`
+}
+
+// ChapterMarkers returns a marker per chapter lasting until the next one, the last one until
+// the end of the video given by length
+func ChapterMarkers(chapters []Chapter, length float64) []Marker {
+	markers := make([]Marker, len(chapters))
+	for i, ch := range chapters {
+		end := length
+		if i+1 < len(chapters) {
+			end = chapters[i+1].Start
+		}
+		markers[i] = Marker{Start: ch.Start, Duration: max(end-ch.Start, 0), Name: ch.Title}
+	}
+	return markers
+}
+
+// MatchMarkers returns a marker per entry matching re, named by the match and noting the
+// whole entry
+func MatchMarkers(entries []TranscriptEntry, re *regexp.Regexp) []Marker {
+	markers := []Marker{}
+	for _, e := range entries {
+		if m := re.FindString(e.Text); m != "" {
+			markers = append(markers, Marker{Start: e.Start, Duration: e.Duration, Name: m, Note: e.Text})
+		}
+	}
+	return markers
+}
+
+// markerText keeps names on one line and clear of the "|" separating EDL marker fields
+var markerText = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "|", "/")
+
+// WriteEDL writes markers as a CMX3600 EDL with a one-frame event per marker, carrying the
+// marker comments DaVinci Resolve imports as timeline markers. Timecodes start at 00:00:00:00,
+// non-drop-frame at fps frames per second.
+func WriteEDL(w io.Writer, title string, markers []Marker, fps int) error {
+	if fps <= 0 {
+		fps = DefaultMarkerFPS
+	}
+	bw := bufio.NewWriter(w)
+	if title == "" {
+		title = "Markers"
+	}
+	fmt.Fprintf(bw, "TITLE: %s\nFCM: NON-DROP FRAME\n\n", markerText.Replace(title))
+	for i, m := range markers {
+		in := frames(m.Start, fps)
+		in1, out := timecode(in, fps), timecode(in+1, fps)
+		fmt.Fprintf(bw, "%03d  001      V     C        %s %s %s %s\n", i+1, in1, out, in1, out)
+		fmt.Fprintf(bw, " |C:ResolveColorBlue |M:%s |D:%d\n\n", markerText.Replace(m.Name), max(frames(m.Duration, fps), 1))
+	}
+	return bw.Flush()
+}
+
+// WriteMarkerCSV writes markers as CSV with name, start, end and duration timecodes and the
+// note, for spreadsheets and the marker import scripts of editors
+func WriteMarkerCSV(w io.Writer, markers []Marker, fps int) error {
+	if fps <= 0 {
+		fps = DefaultMarkerFPS
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Name", "Start", "End", "Duration", "Note"})
+	for _, m := range markers {
+		in, n := frames(m.Start, fps), frames(m.Duration, fps)
+		cw.Write([]string{m.Name, timecode(in, fps), timecode(in+n, fps), timecode(n, fps), m.Note})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// frames converts seconds to a whole number of frames
+func frames(seconds float64, fps int) int {
+	return int(math.Round(max(seconds, 0) * float64(fps)))
+}
+
+// timecode renders a frame count as HH:MM:SS:FF
+func timecode(frames, fps int) string {
+	s := frames / fps
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", s/3600, s/60%60, s%60, frames%fps)
+}
@@ -0,0 +1,54 @@
+package transcript
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestChapterMarkers(t *testing.T) {
+	chapters := []Chapter{{Title: "Intro", Start: 0}, {Title: "Main", Start: 30}}
+	markers := ChapterMarkers(chapters, 90)
+	want := []Marker{{Start: 0, Duration: 30, Name: "Intro"}, {Start: 30, Duration: 60, Name: "Main"}}
+	if len(markers) != len(want) {
+		t.Fatalf("ChapterMarkers() = %+v; want %+v", markers, want)
+	}
+	for i := range want {
+		if markers[i] != want[i] {
+			t.Errorf("markers[%d] = %+v; want %+v", i, markers[i], want[i])
+		}
+	}
+}
+
+func TestWriteEDL(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "nothing here", Start: 0, Duration: 2},
+		{Text: "the big reveal", Start: 3661.5, Duration: 2},
+	}
+	markers := MatchMarkers(entries, regexp.MustCompile(`(?i)reveal`))
+	if len(markers) != 1 || markers[0].Name != "reveal" || markers[0].Note != "the big reveal" {
+		t.Fatalf("MatchMarkers() = %+v; want one marker named reveal", markers)
+	}
+
+	var sb strings.Builder
+	if err := WriteEDL(&sb, "Talk", markers, 30); err != nil {
+		t.Fatalf("WriteEDL() error = %v", err)
+	}
+	want := "TITLE: Talk\nFCM: NON-DROP FRAME\n\n" +
+		"001  001      V     C        01:01:01:15 01:01:01:16 01:01:01:15 01:01:01:16\n" +
+		" |C:ResolveColorBlue |M:reveal |D:60\n\n"
+	if sb.String() != want {
+		t.Errorf("WriteEDL() = %q; want %q", sb.String(), want)
+	}
+}
+
+func TestWriteMarkerCSV(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteMarkerCSV(&sb, []Marker{{Start: 1, Duration: 0.5, Name: "Intro, part 1"}}, 24); err != nil {
+		t.Fatalf("WriteMarkerCSV() error = %v", err)
+	}
+	want := "Name,Start,End,Duration,Note\n\"Intro, part 1\",00:00:01:00,00:00:01:12,00:00:00:12,\n"
+	if sb.String() != want {
+		t.Errorf("WriteMarkerCSV() = %q; want %q", sb.String(), want)
+	}
+}
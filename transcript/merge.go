@@ -0,0 +1,65 @@
+package transcript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// MergedVideo is one video of a merged document
+type MergedVideo struct {
+	Metadata VideoMetadata
+	Entries  []TranscriptEntry
+}
+
+// WriteMergedMarkdown writes many transcripts as one Markdown document: a header per video
+// with its title, channel and link, then its entries with their own timestamps, linked to the
+// video. With globalTime every entry also shows its position in the whole series, each video
+// starting where the previous one ended.
+func WriteMergedMarkdown(w io.Writer, videos []MergedVideo, globalTime bool) error {
+	bw := bufio.NewWriter(w)
+	offset := 0.0
+	for i, v := range videos {
+		md := v.Metadata
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		title := md.Title
+		if title == "" {
+			title = md.VideoID
+		}
+		fmt.Fprintf(bw, "## %d. %s\n\n", i+1, mdEscaper.Replace(title))
+		about := DeepLink(md.VideoID, 0)
+		if md.Author != "" {
+			about = mdEscaper.Replace(md.Author) + " · " + about
+		}
+		if md.PublishDate != "" {
+			about += " · " + md.PublishDate
+		}
+		if globalTime {
+			about += " · starts at " + FormatClock(offset)
+		}
+		fmt.Fprintf(bw, "%s\n\n", about)
+
+		for _, e := range v.Entries {
+			stamp := timestampLink(md.VideoID, e.Start)
+			if globalTime {
+				stamp += " (" + FormatClock(offset+e.Start) + ")"
+			}
+			fmt.Fprintf(bw, "- **%s** %s\n", stamp, mdEscaper.Replace(e.Text))
+		}
+		offset += videoLength(v)
+	}
+	return bw.Flush()
+}
+
+// videoLength is a video's length from its metadata, else the end of its last entry
+func videoLength(v MergedVideo) float64 {
+	if v.Metadata.LengthSeconds > 0 {
+		return float64(v.Metadata.LengthSeconds)
+	}
+	if n := len(v.Entries); n > 0 {
+		return v.Entries[n-1].Start + v.Entries[n-1].Duration
+	}
+	return 0
+}
@@ -0,0 +1,25 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteMergedMarkdown(t *testing.T) {
+	videos := []MergedVideo{
+		{Metadata: VideoMetadata{VideoID: "abcdefghijk", Title: "One", Author: "Chan", LengthSeconds: 600}, Entries: []TranscriptEntry{{Text: "first", Start: 5, Duration: 2}}},
+		{Metadata: VideoMetadata{VideoID: "bcdefghijkl"}, Entries: []TranscriptEntry{{Text: "second", Start: 65, Duration: 2}}},
+	}
+
+	var sb strings.Builder
+	if err := WriteMergedMarkdown(&sb, videos, true); err != nil {
+		t.Fatalf("WriteMergedMarkdown() error = %v", err)
+	}
+	want := "## 1. One\n\nChan · https://youtu.be/abcdefghijk · starts at 0:00\n\n" +
+		"- **[0:05](https://youtu.be/abcdefghijk?t=5) (0:05)** first\n" +
+		"\n## 2. bcdefghijkl\n\nhttps://youtu.be/bcdefghijkl · starts at 10:00\n\n" +
+		"- **[1:05](https://youtu.be/bcdefghijkl?t=65) (11:05)** second\n"
+	if sb.String() != want {
+		t.Errorf("WriteMergedMarkdown() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
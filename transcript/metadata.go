@@ -0,0 +1,103 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VideoMetadata holds the descriptive fields YouTube embeds in a watch page
+type VideoMetadata struct {
+	VideoID       string
+	Title         string
+	Author        string
+	ChannelID     string
+	Description   string
+	Keywords      []string
+	LengthSeconds int
+	ViewCount     int64
+	IsLive        bool
+	// PublishDate is the upload date as YYYY-MM-DD, empty when the page does not include it
+	PublishDate string
+}
+
+// GetVideoMetadata fetches the title, channel and other details of a video
+func (c *Client) GetVideoMetadata(videoID string) (VideoMetadata, error) {
+	videoInfo, err := c.fetchVideoInfo(videoID)
+	if err != nil {
+		return VideoMetadata{}, err
+	}
+
+	return ParseVideoMetadata(videoInfo)
+}
+
+// ParseVideoMetadata extracts the "videoDetails" object from the HTML of a watch page
+func ParseVideoMetadata(watchPage string) (VideoMetadata, error) {
+	startIndex := strings.Index(watchPage, "\"videoDetails\":")
+	if startIndex == -1 {
+		return VideoMetadata{}, &ErrVideoUnavailable{VideoID: ""}
+	}
+
+	detailsJSON, err := extractJSONObject(watchPage, startIndex)
+	if err != nil {
+		return VideoMetadata{}, err
+	}
+
+	// YouTube encodes the numeric fields as strings
+	var details struct {
+		VideoID          string   `json:"videoId"`
+		Title            string   `json:"title"`
+		Author           string   `json:"author"`
+		ChannelID        string   `json:"channelId"`
+		ShortDescription string   `json:"shortDescription"`
+		Keywords         []string `json:"keywords"`
+		LengthSeconds    string   `json:"lengthSeconds"`
+		ViewCount        string   `json:"viewCount"`
+		IsLiveContent    bool     `json:"isLiveContent"`
+	}
+	if err := json.Unmarshal([]byte(detailsJSON), &details); err != nil {
+		return VideoMetadata{}, fmt.Errorf("error parsing videoDetails JSON: %v", err)
+	}
+
+	length, _ := strconv.Atoi(details.LengthSeconds)
+	views, _ := strconv.ParseInt(details.ViewCount, 10, 64)
+	return VideoMetadata{
+		VideoID:       details.VideoID,
+		Title:         details.Title,
+		Author:        details.Author,
+		ChannelID:     details.ChannelID,
+		Description:   details.ShortDescription,
+		Keywords:      details.Keywords,
+		LengthSeconds: length,
+		ViewCount:     views,
+		IsLive:        details.IsLiveContent,
+		PublishDate:   parsePublishDate(watchPage),
+	}, nil
+}
+
+// parsePublishDate reads the upload date from the "playerMicroformatRenderer" object, if present
+func parsePublishDate(watchPage string) string {
+	startIndex := strings.Index(watchPage, "\"playerMicroformatRenderer\":")
+	if startIndex == -1 {
+		return ""
+	}
+
+	microformatJSON, err := extractJSONObject(watchPage, startIndex)
+	if err != nil {
+		return ""
+	}
+
+	var microformat struct {
+		PublishDate string `json:"publishDate"`
+	}
+	if err := json.Unmarshal([]byte(microformatJSON), &microformat); err != nil {
+		return ""
+	}
+
+	// Newer pages use a full RFC 3339 timestamp; keep the date part
+	if len(microformat.PublishDate) > 10 {
+		return microformat.PublishDate[:10]
+	}
+	return microformat.PublishDate
+}
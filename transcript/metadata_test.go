@@ -0,0 +1,58 @@
+package transcript
+
+import "testing"
+
+func TestParseVideoMetadata(t *testing.T) {
+	page := `<script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"abcdefghijk","title":"Braces {in} \"titles\"",` +
+		`"lengthSeconds":"754","channelId":"UC123","shortDescription":"desc","viewCount":"1234567","author":"Someone",` +
+		`"isLiveContent":false,"keywords":["go","yt"]}};</script>`
+
+	md, err := ParseVideoMetadata(page)
+	if err != nil {
+		t.Fatalf("ParseVideoMetadata() error = %v", err)
+	}
+
+	want := VideoMetadata{
+		VideoID:       "abcdefghijk",
+		Title:         `Braces {in} "titles"`,
+		Author:        "Someone",
+		ChannelID:     "UC123",
+		Description:   "desc",
+		LengthSeconds: 754,
+		ViewCount:     1234567,
+	}
+	if md.VideoID != want.VideoID || md.Title != want.Title || md.Author != want.Author || md.ChannelID != want.ChannelID ||
+		md.Description != want.Description || md.LengthSeconds != want.LengthSeconds || md.ViewCount != want.ViewCount {
+		t.Errorf("ParseVideoMetadata() = %+v; want %+v", md, want)
+	}
+	if len(md.Keywords) != 2 {
+		t.Errorf("Keywords = %v; want 2 keywords", md.Keywords)
+	}
+}
+
+func TestParseVideoMetadata_Missing(t *testing.T) {
+	if _, err := ParseVideoMetadata("<html></html>"); err == nil {
+		t.Error("ParseVideoMetadata() expected error for page without videoDetails")
+	}
+}
+
+func TestParseVideoMetadata_PublishDate(t *testing.T) {
+	tests := []struct {
+		page     string
+		expected string
+	}{
+		{`{"videoDetails":{"videoId":"x"},"microformat":{"playerMicroformatRenderer":{"publishDate":"2023-04-05"}}}`, "2023-04-05"},
+		{`{"videoDetails":{"videoId":"x"},"microformat":{"playerMicroformatRenderer":{"publishDate":"2024-01-02T03:04:05-07:00"}}}`, "2024-01-02"},
+		{`{"videoDetails":{"videoId":"x"}}`, ""},
+	}
+
+	for _, tt := range tests {
+		md, err := ParseVideoMetadata(tt.page)
+		if err != nil {
+			t.Fatalf("ParseVideoMetadata() error = %v", err)
+		}
+		if md.PublishDate != tt.expected {
+			t.Errorf("PublishDate = %q; want %q", md.PublishDate, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// GetTranscripts fetches the tracks of videoID in several languages, reading the watch page
+// once and downloading the tracks concurrently, all bound to ctx. The result is keyed by the requested
+// language codes, which match as in FindTranscript. Languages without a track, or whose
+// download failed, are left out of the map and reported together in the error.
+func (c *Client) GetTranscripts(ctx context.Context, videoID string, langs []string) (map[string][]TranscriptEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	transcripts, err := c.ListAvailableTranscriptsContext(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make(map[string]Transcript, len(langs))
+	var errs []error
+	for _, lang := range langs {
+		t, ok := LanguageSelection{Languages: []string{lang}}.Choose(transcripts)
+		if !ok {
+			errs = append(errs, ErrNoTranscriptFound{VideoID: videoID, Language: lang})
+			continue
+		}
+		tracks[lang] = t
+	}
+
+	results, err := c.fetchTracks(ctx, tracks)
+	return results, errors.Join(append(errs, err)...)
+}
+
+// GetAllTranscripts fetches every track of videoID, keyed by language code, reading the
+// watch page once. Where a language has both an uploader-provided and an auto-generated
+// track, the uploader's is kept. Tracks that fail to download are left out of the map and
+// reported together in the error.
+func (c *Client) GetAllTranscripts(ctx context.Context, videoID string) (map[string][]TranscriptEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	transcripts, err := c.ListAvailableTranscriptsContext(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(transcripts) == 0 {
+		return nil, ErrNoTranscriptFound{VideoID: videoID}
+	}
+
+	tracks := make(map[string]Transcript, len(transcripts))
+	for _, t := range transcripts {
+		if prev, ok := tracks[t.LanguageCode]; ok && !prev.IsGenerated {
+			continue
+		}
+		tracks[t.LanguageCode] = t
+	}
+	return c.fetchTracks(ctx, tracks)
+}
+
+// fetchTracks downloads tracks concurrently, keeping the keys of the map
+func (c *Client) fetchTracks(ctx context.Context, tracks map[string]Transcript) (map[string][]TranscriptEntry, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string][]TranscriptEntry, len(tracks))
+		errs    []error
+	)
+	for key, t := range tracks {
+		wg.Add(1)
+		go func(key string, t Transcript) {
+			defer wg.Done()
+			entries, err := c.fetchTranscriptContext(ctx, t)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			results[key] = entries
+		}(key, t)
+	}
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
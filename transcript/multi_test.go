@@ -0,0 +1,95 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// countingYouTube wraps fakeYouTube, counting the requests for each path
+func countingYouTube(t *testing.T) (roundTripFunc, map[string]int) {
+	fake := fakeYouTube(t)
+	var mu sync.Mutex
+	counts := make(map[string]int)
+	return func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		counts[r.URL.Path]++
+		mu.Unlock()
+		return fake(r)
+	}, counts
+}
+
+func TestGetTranscripts(t *testing.T) {
+	transport, counts := countingYouTube(t)
+	client := NewClient(WithTransport(transport))
+
+	results, err := client.GetTranscripts(context.Background(), "abcdefghijk", []string{"de", "en"})
+	if err != nil {
+		t.Fatalf("GetTranscripts() error = %v", err)
+	}
+	if len(results) != 2 || len(results["de"]) == 0 || len(results["en"]) == 0 {
+		t.Errorf("GetTranscripts() = %v; want entries for de and en", results)
+	}
+	if counts["/watch"] != 1 || counts["/api/timedtext"] != 2 {
+		t.Errorf("requests = %v; want one watch page and two tracks", counts)
+	}
+}
+
+func TestGetTranscripts_MissingLanguage(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+
+	results, err := client.GetTranscripts(context.Background(), "abcdefghijk", []string{"de", "fr"})
+	var notFound ErrNoTranscriptFound
+	if !errors.As(err, &notFound) || notFound.Language != "fr" {
+		t.Errorf("GetTranscripts() error = %v; want ErrNoTranscriptFound for fr", err)
+	}
+	if len(results) != 1 || len(results["de"]) == 0 {
+		t.Errorf("GetTranscripts() = %v; want the de entries", results)
+	}
+}
+
+func TestGetTranscripts_Cancelled(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.GetTranscripts(ctx, "abcdefghijk", []string{"de"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetTranscripts() error = %v; want context.Canceled", err)
+	}
+}
+
+func TestGetAllTranscripts(t *testing.T) {
+	transport, counts := countingYouTube(t)
+	client := NewClient(WithTransport(transport))
+
+	results, err := client.GetAllTranscripts(context.Background(), "abcdefghijk")
+	if err != nil {
+		t.Fatalf("GetAllTranscripts() error = %v", err)
+	}
+	if len(results) != 2 || len(results["de"]) == 0 || len(results["en"]) == 0 {
+		t.Errorf("GetAllTranscripts() = %v; want entries for de and en", results)
+	}
+	if counts["/watch"] != 1 {
+		t.Errorf("fetched %d watch pages; want 1", counts["/watch"])
+	}
+}
+
+func TestGetAllTranscripts_CancelDuringWatchPage(t *testing.T) {
+	fetching := make(chan struct{})
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		close(fetching)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-fetching
+		cancel()
+	}()
+
+	if _, err := client.GetAllTranscripts(ctx, "abcdefghijk"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAllTranscripts() error = %v; want context.Canceled", err)
+	}
+}
@@ -0,0 +1,172 @@
+package transcript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ShowNotes describes the Markdown show notes of a video
+type ShowNotes struct {
+	VideoID  string
+	Title    string
+	Chapters []Chapter
+	Entries  []TranscriptEntry
+	// TOC adds a linked table of contents after the title. Without chapters, the sections
+	// of TableOfContents also become the headings of the notes.
+	TOC bool
+}
+
+// WriteChapterMarkdown writes show notes: the title as an H1, then an H2 per chapter holding
+// its transcript as timestamped paragraphs, grouped as by SpeakerTurns after stripping
+// annotations. With a videoID the timestamps link to their moment of the video. Without
+// chapters the paragraphs follow the title directly.
+func WriteChapterMarkdown(w io.Writer, videoID, title string, chapters []Chapter, entries []TranscriptEntry) error {
+	return ShowNotes{VideoID: videoID, Title: title, Chapters: chapters, Entries: entries}.WriteMarkdown(w)
+}
+
+// WriteMarkdown writes the notes as described for WriteChapterMarkdown
+func (n ShowNotes) WriteMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if n.Title != "" {
+		fmt.Fprintf(bw, "# %s\n\n", mdEscaper.Replace(n.Title))
+	}
+
+	entries := DedupOverlap(StripAnnotations(n.Entries))
+	sections := n.Chapters
+	if n.TOC {
+		sections = TableOfContents(entries, n.Chapters)
+		writeTOC(bw, n.VideoID, sections)
+	}
+	if len(sections) == 0 {
+		sections = []Chapter{{Start: 0}}
+	}
+
+	next := 0
+	for i, ch := range sections {
+		end := len(entries)
+		if i+1 < len(sections) {
+			end = next
+			for end < len(entries) && entries[end].Start < sections[i+1].Start {
+				end++
+			}
+		}
+		if ch.Title != "" {
+			fmt.Fprintf(bw, "## %s\n\n", mdEscaper.Replace(ch.Title))
+		}
+
+		paragraphs, err := NewSpeakerTurns().Process(entries[next:end])
+		if err != nil {
+			return err
+		}
+		for _, p := range paragraphs {
+			fmt.Fprintf(bw, "**%s** %s\n\n", timestampLink(n.VideoID, p.Start), mdEscaper.Replace(p.Text))
+		}
+		next = end
+	}
+	return bw.Flush()
+}
+
+// writeTOC writes a "Contents" list linking every section
+func writeTOC(bw *bufio.Writer, videoID string, sections []Chapter) {
+	if len(sections) == 0 {
+		return
+	}
+	bw.WriteString("## Contents\n\n")
+	for _, s := range sections {
+		fmt.Fprintf(bw, "- %s %s\n", timestampLink(videoID, s.Start), mdEscaper.Replace(s.Title))
+	}
+	bw.WriteString("\n")
+}
+
+// timestampLink formats a start time, as a Markdown link to that moment when videoID is known
+func timestampLink(videoID string, start float64) string {
+	stamp := FormatClock(start)
+	if videoID == "" {
+		return stamp
+	}
+	return fmt.Sprintf("[%s](%s)", stamp, DeepLink(videoID, start))
+}
+
+// Heuristics of TableOfContents for videos without chapters
+const (
+	tocSectionSeconds = 300.0 // aim for a section every five minutes
+	tocMinSeconds     = 120.0 // and never closer than two minutes
+	tocMinPause       = 2.0
+	tocTitleWords     = 3
+)
+
+// TableOfContents returns the chapters when there are any. Otherwise it splits the
+// transcript at its longest pauses, about one section per five minutes, and titles each
+// section with its most frequent keywords.
+func TableOfContents(entries []TranscriptEntry, chapters []Chapter) []Chapter {
+	if len(chapters) > 0 {
+		return chapters
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	last := entries[len(entries)-1]
+	want := int((last.Start + last.Duration) / tocSectionSeconds)
+
+	// Take the longest pauses first, skipping those too close to a boundary already taken
+	gaps := FindGaps(entries, tocMinPause)
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].Duration > gaps[j].Duration })
+	bounds := []float64{entries[0].Start}
+	for _, g := range gaps {
+		if len(bounds) > want {
+			break
+		}
+		near := false
+		for _, b := range bounds {
+			if math.Abs(g.End-b) < tocMinSeconds {
+				near = true
+				break
+			}
+		}
+		if !near {
+			bounds = append(bounds, g.End)
+		}
+	}
+	sort.Float64s(bounds)
+
+	toc := make([]Chapter, 0, len(bounds))
+	for i, start := range bounds {
+		end := last.Start + last.Duration + 1
+		if i+1 < len(bounds) {
+			end = bounds[i+1]
+		}
+		var section []TranscriptEntry
+		for _, e := range entries {
+			if e.Start >= start && e.Start < end {
+				section = append(section, e)
+			}
+		}
+		toc = append(toc, Chapter{Title: sectionTitle(section), Start: start})
+	}
+	return toc
+}
+
+// sectionTitle names a section by its top keywords, or its first words if it has none
+func sectionTitle(section []TranscriptEntry) string {
+	var words []string
+	for _, kw := range ComputeStats(section).TopKeywords {
+		if len(words) == tocTitleWords {
+			break
+		}
+		words = append(words, kw.Word)
+	}
+	if len(words) == 0 && len(section) > 0 {
+		words = strings.Fields(section[0].Text)
+		words = words[:min(len(words), 6)]
+	}
+	title := []rune(strings.Join(words, ", "))
+	if len(title) > 0 {
+		title[0] = unicode.ToUpper(title[0])
+	}
+	return string(title)
+}
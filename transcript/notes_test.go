@@ -0,0 +1,80 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteChapterMarkdown(t *testing.T) {
+	chapters := []Chapter{{Title: "Intro", Start: 0}, {Title: "Q&A", Start: 60}, {Title: "Wrap-up", Start: 120}}
+	entries := []TranscriptEntry{
+		{Text: "[Music]", Start: 0, Duration: 3},
+		{Text: "Welcome to the show.", Start: 3, Duration: 2},
+		{Text: "Let's take questions.", Start: 61, Duration: 2},
+		{Text: ">> What is *Go*?", Start: 64, Duration: 2},
+		{Text: "Thanks, bye.", Start: 125, Duration: 2},
+	}
+
+	var sb strings.Builder
+	if err := WriteChapterMarkdown(&sb, "abcdefghijk", "My Talk", chapters, entries); err != nil {
+		t.Fatalf("WriteChapterMarkdown() error = %v", err)
+	}
+	want := "# My Talk\n\n" +
+		"## Intro\n\n**[0:03](https://youtu.be/abcdefghijk?t=3)** Welcome to the show.\n\n" +
+		"## Q&A\n\n**[1:01](https://youtu.be/abcdefghijk?t=61)** Let's take questions.\n\n" +
+		"**[1:04](https://youtu.be/abcdefghijk?t=64)** - What is \\*Go\\*?\n\n" +
+		"## Wrap-up\n\n**[2:05](https://youtu.be/abcdefghijk?t=125)** Thanks, bye.\n\n"
+	if sb.String() != want {
+		t.Errorf("WriteChapterMarkdown() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
+
+func TestWriteChapterMarkdown_NoChapters(t *testing.T) {
+	var sb strings.Builder
+	WriteChapterMarkdown(&sb, "", "", nil, []TranscriptEntry{{Text: "Hello.", Start: 0, Duration: 1}})
+	if want := "**0:00** Hello.\n\n"; sb.String() != want {
+		t.Errorf("WriteChapterMarkdown() = %q; want %q", sb.String(), want)
+	}
+}
+
+func TestTableOfContents(t *testing.T) {
+	chapters := []Chapter{{Title: "Intro", Start: 0}}
+	if toc := TableOfContents(nil, chapters); len(toc) != 1 || toc[0].Title != "Intro" {
+		t.Errorf("TableOfContents() with chapters = %+v; want the chapters", toc)
+	}
+
+	var entries []TranscriptEntry
+	for i := 0; i < 60; i++ {
+		text := "compilers parse source code"
+		start := float64(i) * 10
+		if i >= 30 {
+			text = "garbage collection frees memory"
+			start += 20 // a long pause before the second topic
+		}
+		entries = append(entries, TranscriptEntry{Text: text, Start: start, Duration: 9})
+	}
+	toc := TableOfContents(entries, nil)
+	if len(toc) != 2 {
+		t.Fatalf("TableOfContents() = %+v; want 2 sections", toc)
+	}
+	if toc[1].Start != 320 || !strings.Contains(strings.ToLower(toc[1].Title), "garbage") {
+		t.Errorf("second section = %+v; want the garbage collection part at 320s", toc[1])
+	}
+}
+
+func TestShowNotes_TOC(t *testing.T) {
+	var sb strings.Builder
+	notes := ShowNotes{
+		VideoID:  "abcdefghijk",
+		Chapters: []Chapter{{Title: "Intro", Start: 0}, {Title: "Outro", Start: 60}},
+		Entries:  []TranscriptEntry{{Text: "Hi.", Start: 1, Duration: 1}, {Text: "Bye.", Start: 61, Duration: 1}},
+		TOC:      true,
+	}
+	if err := notes.WriteMarkdown(&sb); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	wantTOC := "## Contents\n\n- [0:00](https://youtu.be/abcdefghijk) Intro\n- [1:00](https://youtu.be/abcdefghijk?t=60) Outro\n\n## Intro\n\n"
+	if !strings.HasPrefix(sb.String(), wantTOC) {
+		t.Errorf("WriteMarkdown() =\n%s\nwant it to start with\n%s", sb.String(), wantTOC)
+	}
+}
@@ -0,0 +1,124 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies OAuth 2.0 access tokens. Token is called before every Data API
+// request, so implementations should cache tokens until they expire.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// clientTokenSource is a TokenSource that sends its requests through the client it is used with
+type clientTokenSource interface {
+	TokenSource
+	withClient(c *Client) TokenSource
+}
+
+// staticToken always returns the same access token
+type staticToken string
+
+// StaticToken returns a TokenSource for an access token obtained elsewhere, e.g. with
+// gcloud auth print-access-token. Google access tokens expire after an hour.
+func StaticToken(accessToken string) TokenSource {
+	return staticToken(accessToken)
+}
+
+func (t staticToken) Token() (string, error) {
+	return string(t), nil
+}
+
+// googleTokenURL is Google's OAuth 2.0 token endpoint
+const googleTokenURL = "https://oauth2.googleapis.com/token"
+
+// tokenExpiryMargin renews access tokens this long before they expire
+const tokenExpiryMargin = time.Minute
+
+// refreshTokenSource exchanges a refresh token for access tokens as they expire
+type refreshTokenSource struct {
+	clientID     string
+	clientSecret string
+	refreshToken string
+	tokenURL     string
+	c            *Client
+
+	mu      *sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// RefreshTokenSource returns a TokenSource that exchanges the refresh token of an OAuth
+// client for access tokens, renewing them before they expire. The refresh token must grant
+// the https://www.googleapis.com/auth/youtube.force-ssl scope to download captions.
+func RefreshTokenSource(clientID, clientSecret, refreshToken string) TokenSource {
+	return &refreshTokenSource{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		refreshToken: refreshToken,
+		tokenURL:     googleTokenURL,
+		mu:           &sync.Mutex{},
+	}
+}
+
+func (s *refreshTokenSource) withClient(c *Client) TokenSource {
+	bound := *s
+	bound.c = c
+	bound.mu = &sync.Mutex{}
+	return &bound
+}
+
+func (s *refreshTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Now().Before(s.expires) {
+		return s.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+		"refresh_token": {s.refreshToken},
+	}
+	req, err := http.NewRequest(http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c := s.c
+	if c == nil {
+		c = NewClient()
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("error parsing token response: HTTP %d: %v", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		if body.ErrorDescription != "" {
+			return "", fmt.Errorf("token refresh failed: %s: %s", body.Error, body.ErrorDescription)
+		}
+		return "", fmt.Errorf("token refresh failed: HTTP %d %s", resp.StatusCode, body.Error)
+	}
+
+	s.token = body.AccessToken
+	s.expires = time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return s.token, nil
+}
@@ -0,0 +1,58 @@
+package transcript
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRefreshTokenSource(t *testing.T) {
+	refreshes := 0
+	var auth []string
+	client := NewClient(
+		WithBackend(DataAPIBackendWithTokens("", RefreshTokenSource("id", "secret", "refresh"))),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Host == "oauth2.googleapis.com" {
+				refreshes++
+				b, _ := io.ReadAll(r.Body)
+				if !strings.Contains(string(b), "grant_type=refresh_token") || !strings.Contains(string(b), "refresh_token=refresh") {
+					t.Errorf("token request body = %s", b)
+				}
+				return textResponse(r, `{"access_token":"ya29.fresh","expires_in":3599,"token_type":"Bearer"}`), nil
+			}
+			auth = append(auth, r.Header.Get("Authorization"))
+			return textResponse(r, `{"items":[]}`), nil
+		})),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListAvailableTranscripts("abcdefghijk"); err != nil {
+			t.Fatalf("ListAvailableTranscripts() error = %v", err)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("got %d token refreshes; want 1", refreshes)
+	}
+	for _, a := range auth {
+		if a != "Bearer ya29.fresh" {
+			t.Errorf("Authorization = %q; want Bearer ya29.fresh", a)
+		}
+	}
+}
+
+func TestRefreshTokenSource_Error(t *testing.T) {
+	client := NewClient(
+		WithBackend(DataAPIBackendWithTokens("", RefreshTokenSource("id", "secret", "revoked"))),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			resp := textResponse(r, `{"error":"invalid_grant","error_description":"Token has been expired or revoked."}`)
+			resp.StatusCode = http.StatusBadRequest
+			return resp, nil
+		})),
+	)
+
+	_, err := client.ListAvailableTranscripts("abcdefghijk")
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("ListAvailableTranscripts() error = %v; want invalid_grant", err)
+	}
+}
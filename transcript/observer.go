@@ -0,0 +1,154 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestKind tells what an HTTP request of the client was for
+type RequestKind string
+
+const (
+	RequestWatchPage RequestKind = "watch_page"
+	RequestCaption   RequestKind = "caption"
+	RequestPlaylist  RequestKind = "playlist"
+	RequestChannel   RequestKind = "channel"
+	RequestInnertube RequestKind = "innertube"
+	RequestDataAPI   RequestKind = "data_api"
+	RequestOAuth     RequestKind = "oauth"
+	RequestOther     RequestKind = "other"
+)
+
+// FetchEvent describes one HTTP request of the client; each retry is an event of its own
+type FetchEvent struct {
+	Kind   RequestKind
+	Method string
+	// URL is the request URL with credentials, such as the Data API key, masked
+	URL string
+	// Attempt is 0 for the first try and counts up with every retry
+	Attempt int
+	// Status is the HTTP status code, or 0 if no response arrived
+	Status int
+	// Duration runs from sending the request until its body is closed
+	Duration time.Duration
+	// Bytes is the size of the body as read off the wire, before decompression
+	Bytes int64
+	// ErrorClass is empty for a successful request, otherwise one of "network", "timeout",
+	// "rate_limited", "forbidden", "not_found", "client_error" or "server_error"
+	ErrorClass string
+	Err        error
+}
+
+// WithObserver calls observe after every HTTP request with its kind, duration, size, status
+// and error class, for dashboards and logs of the integrator's choice. Events of a response
+// are reported once its body is closed; observe may be called from several goroutines.
+func WithObserver(observe func(FetchEvent)) ClientOption {
+	return func(c *Client) {
+		c.observer = observe
+	}
+}
+
+// observe reports the outcome of one attempt of req, once the body of resp has been closed
+func (c *Client) observe(req *http.Request, attempt int, start time.Time, resp *http.Response, err error) {
+	if c.observer == nil {
+		return
+	}
+	event := FetchEvent{
+		Kind:    requestKind(req.URL),
+		Method:  req.Method,
+		URL:     redactedURL(req.URL),
+		Attempt: attempt,
+		Err:     err,
+	}
+	if err != nil || resp.Body == nil {
+		event.Duration = time.Since(start)
+		event.ErrorClass = errorClass(resp, err)
+		if resp != nil {
+			event.Status = resp.StatusCode
+		}
+		c.observer(event)
+		return
+	}
+
+	event.Status = resp.StatusCode
+	event.ErrorClass = errorClass(resp, nil)
+	resp.Body = &observedBody{ReadCloser: resp.Body, done: func(n int64) {
+		event.Bytes = n
+		event.Duration = time.Since(start)
+		c.observer(event)
+	}}
+}
+
+// observedBody counts the bytes read from a response body and reports them on Close
+type observedBody struct {
+	io.ReadCloser
+	n    int64
+	once sync.Once
+	done func(n int64)
+}
+
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *observedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.done(b.n) })
+	return err
+}
+
+// requestKind classifies a request URL of the client
+func requestKind(u *url.URL) RequestKind {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "oauth2.googleapis.com":
+		return RequestOAuth
+	case host == "www.googleapis.com" || strings.HasPrefix(u.Path, "/youtube/v3/"):
+		return RequestDataAPI
+	case u.Path == "/watch":
+		return RequestWatchPage
+	case strings.HasPrefix(u.Path, "/api/timedtext"):
+		return RequestCaption
+	case u.Path == "/playlist":
+		return RequestPlaylist
+	case strings.HasPrefix(u.Path, "/youtubei/"):
+		return RequestInnertube
+	case strings.HasPrefix(u.Path, "/feeds/"), strings.HasPrefix(u.Path, "/channel/"),
+		strings.HasPrefix(u.Path, "/@"), strings.HasPrefix(u.Path, "/c/"), strings.HasPrefix(u.Path, "/user/"):
+		return RequestChannel
+	}
+	return RequestOther
+}
+
+// errorClass sums up why a request failed, or returns "" if it succeeded
+func errorClass(resp *http.Response, err error) string {
+	if err != nil {
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return "timeout"
+		}
+		return "network"
+	}
+	switch status := resp.StatusCode; {
+	case status == http.StatusTooManyRequests:
+		return "rate_limited"
+	case status == http.StatusForbidden:
+		return "forbidden"
+	case status == http.StatusNotFound:
+		return "not_found"
+	case status >= http.StatusInternalServerError:
+		return "server_error"
+	case status >= http.StatusBadRequest:
+		return "client_error"
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package transcript
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithObserver(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []FetchEvent
+	)
+	client := NewClient(
+		WithObserver(func(e FetchEvent) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		}),
+		WithTransport(fakeYouTube(t)),
+	)
+	if _, err := client.GetTranscript("abcdefghijk"); err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events; want 2", len(events))
+	}
+	watch, caption := events[0], events[1]
+	if watch.Kind != RequestWatchPage || watch.Status != http.StatusOK || watch.ErrorClass != "" {
+		t.Errorf("watch page event = %+v", watch)
+	}
+	if watch.Bytes != int64(len(sampleWatchPage)) {
+		t.Errorf("watch page Bytes = %d; want %d", watch.Bytes, len(sampleWatchPage))
+	}
+	if caption.Kind != RequestCaption || caption.Bytes != int64(len(sampleTranscriptXML)) {
+		t.Errorf("caption event = %+v", caption)
+	}
+}
+
+func TestWithObserver_Retries(t *testing.T) {
+	var events []FetchEvent
+	attempts := 0
+	client := NewClient(
+		WithRetries(1, time.Millisecond),
+		WithObserver(func(e FetchEvent) { events = append(events, e) }),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+			}
+			return textResponse(r, "ok"), nil
+		})),
+	)
+	resp, err := client.get("https://www.youtube.com/watch?v=abcdefghijk")
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events; want 2", len(events))
+	}
+	if events[0].ErrorClass != "rate_limited" || events[0].Attempt != 0 {
+		t.Errorf("first event = %+v; want a rate_limited first attempt", events[0])
+	}
+	if events[1].ErrorClass != "" || events[1].Attempt != 1 || events[1].Bytes != 2 {
+		t.Errorf("second event = %+v; want a successful retry of 2 bytes", events[1])
+	}
+}
+
+func TestRequestKind(t *testing.T) {
+	tests := map[string]RequestKind{
+		"https://www.youtube.com/watch?v=abcdefghijk":         RequestWatchPage,
+		"https://www.youtube.com/api/timedtext?v=abcdefghijk": RequestCaption,
+		"https://www.youtube.com/playlist?list=PL1":           RequestPlaylist,
+		"https://www.youtube.com/youtubei/v1/browse":          RequestInnertube,
+		"https://www.youtube.com/feeds/videos.xml":            RequestChannel,
+		"https://www.youtube.com/@handle":                     RequestChannel,
+		"https://www.googleapis.com/youtube/v3/captions":      RequestDataAPI,
+		"https://oauth2.googleapis.com/token":                 RequestOAuth,
+		"https://example.com/":                                RequestOther,
+	}
+	for rawURL, want := range tests {
+		u, _ := url.Parse(rawURL)
+		if got := requestKind(u); got != want {
+			t.Errorf("requestKind(%s) = %s; want %s", rawURL, got, want)
+		}
+	}
+}
+
+func TestWithObserver_RedactsAPIKey(t *testing.T) {
+	var event FetchEvent
+	client := NewClient(
+		WithObserver(func(e FetchEvent) { event = e }),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return textResponse(r, "{}"), nil
+		})),
+	)
+	resp, err := client.get("https://www.googleapis.com/youtube/v3/captions?videoId=abcdefghijk&key=s3cret")
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if strings.Contains(event.URL, "s3cret") || !strings.Contains(event.URL, "key=REDACTED") {
+		t.Errorf("event URL = %q; want the key masked", event.URL)
+	}
+}
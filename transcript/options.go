@@ -0,0 +1,85 @@
+package transcript
+
+import (
+	"context"
+	"net/url"
+)
+
+// FetchOptions describes a fetch of GetTranscriptWithOptions
+type FetchOptions struct {
+	// Languages lists language code prefixes in priority order, as in LanguageSelection
+	Languages    []string
+	PreferManual bool
+	// TranslateTo asks YouTube to machine-translate the chosen track into this language
+	TranslateTo string
+	// Format renders the processed entries into FetchResult.Text when set
+	Format Format
+	// Processors run in order on the entries, after any translation
+	Processors []Processor
+}
+
+// FetchResult is the outcome of GetTranscriptWithOptions
+type FetchResult struct {
+	// Track is the caption track fetched, with the language of TranslateTo if given
+	Track   Transcript
+	Entries []TranscriptEntry
+	// Text holds Entries in the requested Format; it is empty without one
+	Text string
+}
+
+// GetTranscriptWithOptions fetches a transcript as described by opts, with the watch page
+// fetch and the caption download bound to ctx. GetTranscript and GetTranscriptWithLanguage
+// are shorthands for it.
+func (c *Client) GetTranscriptWithOptions(ctx context.Context, videoID string, opts FetchOptions) (FetchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return FetchResult{}, err
+	}
+	t, err := c.findTranscriptMatchingContext(ctx, videoID, LanguageSelection{Languages: opts.Languages, PreferManual: opts.PreferManual})
+	if err != nil {
+		return FetchResult{}, err
+	}
+	if opts.TranslateTo != "" {
+		if t, err = t.Translated(opts.TranslateTo); err != nil {
+			return FetchResult{}, err
+		}
+	}
+
+	entries, err := c.fetchTranscriptContext(ctx, t)
+	if err != nil {
+		return FetchResult{}, err
+	}
+	if len(opts.Processors) > 0 {
+		if entries, err = Pipeline(opts.Processors).Process(entries); err != nil {
+			return FetchResult{}, err
+		}
+	}
+
+	result := FetchResult{Track: t, Entries: entries}
+	if opts.Format != "" {
+		if result.Text, err = FormatEntries(opts.Format, entries); err != nil {
+			return FetchResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// Translated returns the track machine-translated by YouTube into languageCode. Tracks that
+// are not IsTranslatable yield ErrNoTranscriptFound.
+func (t Transcript) Translated(languageCode string) (Transcript, error) {
+	if !t.IsTranslatable {
+		return Transcript{}, ErrNoTranscriptFound{VideoID: videoIDFromURL(t.BaseURL), Language: languageCode}
+	}
+	u, err := url.Parse(t.BaseURL)
+	if err != nil {
+		return Transcript{}, err
+	}
+	q := u.Query()
+	q.Set("tlang", languageCode)
+	u.RawQuery = q.Encode()
+
+	t.BaseURL = u.String()
+	t.LanguageCode = languageCode
+	t.Language = languageCode
+	t.IsGenerated = true
+	return t, nil
+}
@@ -0,0 +1,82 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetTranscriptWithOptions(t *testing.T) {
+	var requested []string
+	fake := fakeYouTube(t)
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.String())
+		return fake(r)
+	})))
+
+	upper := ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		for i := range entries {
+			entries[i].Text = strings.ToUpper(entries[i].Text)
+		}
+		return entries, nil
+	})
+	result, err := client.GetTranscriptWithOptions(context.Background(), "abcdefghijk", FetchOptions{
+		Languages:   []string{"en"},
+		TranslateTo: "fr",
+		Format:      FormatText,
+		Processors:  []Processor{upper},
+	})
+	if err != nil {
+		t.Fatalf("GetTranscriptWithOptions() error = %v", err)
+	}
+	if result.Track.LanguageCode != "fr" || len(result.Entries) != 2 {
+		t.Errorf("GetTranscriptWithOptions() = %+v; want two fr entries", result)
+	}
+	if !strings.Contains(result.Text, "HELLO & WELCOME") {
+		t.Errorf("Text = %q; want the processed entries as text", result.Text)
+	}
+	if last := requested[len(requested)-1]; !strings.Contains(last, "lang=en") || !strings.Contains(last, "tlang=fr") {
+		t.Errorf("fetched %s; want the en track translated to fr", last)
+	}
+}
+
+func TestGetTranscriptWithOptions_NotTranslatable(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+
+	_, err := client.GetTranscriptWithOptions(context.Background(), "abcdefghijk", FetchOptions{Languages: []string{"de"}, TranslateTo: "fr"})
+	var notFound ErrNoTranscriptFound
+	if !errors.As(err, &notFound) || notFound.Language != "fr" {
+		t.Errorf("GetTranscriptWithOptions() error = %v; want ErrNoTranscriptFound for fr", err)
+	}
+}
+
+func TestGetTranscriptWithOptions_Cancelled(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.GetTranscriptWithOptions(ctx, "abcdefghijk", FetchOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetTranscriptWithOptions() error = %v; want context.Canceled", err)
+	}
+}
+
+func TestGetTranscriptWithOptions_CancelDuringWatchPage(t *testing.T) {
+	fetching := make(chan struct{})
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		// The watch page request hangs until it is cancelled
+		close(fetching)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-fetching
+		cancel()
+	}()
+
+	if _, err := client.GetTranscriptWithOptions(ctx, "abcdefghijk", FetchOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetTranscriptWithOptions() error = %v; want context.Canceled", err)
+	}
+}
@@ -0,0 +1,77 @@
+package transcript
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// DefaultPaceWindow is the window of SpeakingRate when none is given, in seconds
+const DefaultPaceWindow = 60.0
+
+// PacePoint is the speaking rate during one window of a video
+type PacePoint struct {
+	Start          float64 `json:"start"`
+	End            float64 `json:"end"`
+	Words          float64 `json:"words"`
+	WordsPerMinute float64 `json:"wordsPerMinute"`
+}
+
+// SpeakingRate splits a transcript into consecutive windows of the given length in seconds
+// and measures the words per minute in each, showing how pacing changes across a talk. The
+// words of an entry are spread evenly over its duration, so entries crossing a window
+// boundary count towards both sides. A window of zero or less uses DefaultPaceWindow.
+func SpeakingRate(entries []TranscriptEntry, window float64) []PacePoint {
+	if window <= 0 {
+		window = DefaultPaceWindow
+	}
+	end := 0.0
+	for _, e := range entries {
+		end = max(end, e.Start+e.Duration)
+	}
+	if end == 0 {
+		return []PacePoint{}
+	}
+
+	points := make([]PacePoint, int((end+window-1e-9)/window))
+	for i := range points {
+		points[i].Start = float64(i) * window
+		points[i].End = min(points[i].Start+window, end)
+	}
+	for _, e := range entries {
+		words := float64(len(tokenize(e.Text)))
+		if words == 0 {
+			continue
+		}
+		if e.Duration <= 0 {
+			points[min(int(e.Start/window), len(points)-1)].Words += words
+			continue
+		}
+		for i := int(e.Start / window); i < len(points) && points[i].Start < e.Start+e.Duration; i++ {
+			overlap := min(points[i].End, e.Start+e.Duration) - max(points[i].Start, e.Start)
+			points[i].Words += words * max(overlap, 0) / e.Duration
+		}
+	}
+	for i := range points {
+		if length := points[i].End - points[i].Start; length > 0 {
+			points[i].WordsPerMinute = points[i].Words / (length / 60)
+		}
+	}
+	return points
+}
+
+// WritePaceCSV writes points as start,end,words,wpm rows with a header
+func WritePaceCSV(w io.Writer, points []PacePoint) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"start", "end", "words", "wpm"})
+	for _, p := range points {
+		cw.Write([]string{
+			strconv.FormatFloat(p.Start, 'f', -1, 64),
+			strconv.FormatFloat(p.End, 'f', -1, 64),
+			strconv.FormatFloat(p.Words, 'f', 1, 64),
+			strconv.FormatFloat(p.WordsPerMinute, 'f', 1, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
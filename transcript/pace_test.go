@@ -0,0 +1,39 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpeakingRate(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "one two three four", Start: 0, Duration: 10},
+		{Text: "five six", Start: 25, Duration: 10},
+		{Text: "seven", Start: 40, Duration: 5},
+	}
+
+	points := SpeakingRate(entries, 30)
+	if len(points) != 2 {
+		t.Fatalf("SpeakingRate() = %d windows; want 2", len(points))
+	}
+	if points[0].Words != 5 || points[0].WordsPerMinute != 10 {
+		t.Errorf("first window = %+v; want 5 words at 10 wpm", points[0])
+	}
+	if points[1].Start != 30 || points[1].End != 45 || points[1].Words != 2 || points[1].WordsPerMinute != 8 {
+		t.Errorf("second window = %+v; want 2 words over 15s at 8 wpm", points[1])
+	}
+
+	if len(SpeakingRate(nil, 0)) != 0 {
+		t.Error("SpeakingRate(nil) returned windows")
+	}
+}
+
+func TestWritePaceCSV(t *testing.T) {
+	var sb strings.Builder
+	if err := WritePaceCSV(&sb, []PacePoint{{Start: 0, End: 60, Words: 150, WordsPerMinute: 150}}); err != nil {
+		t.Fatalf("WritePaceCSV() error = %v", err)
+	}
+	if want := "start,end,words,wpm\n0,60,150.0,150.0\n"; sb.String() != want {
+		t.Errorf("WritePaceCSV() = %q; want %q", sb.String(), want)
+	}
+}
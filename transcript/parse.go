@@ -0,0 +1,122 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ReadFormat parses a transcript previously written in format; FormatJSON, FormatSRT and
+// FormatVTT can be read back
+func ReadFormat(r io.Reader, format Format) ([]TranscriptEntry, error) {
+	switch format {
+	case FormatJSON:
+		var raw []jsonEntry
+		if err := json.NewDecoder(r).Decode(&raw); err != nil {
+			return nil, fmt.Errorf("error parsing JSON transcript: %v", err)
+		}
+		entries := make([]TranscriptEntry, 0, len(raw))
+		for _, e := range raw {
+			entries = append(entries, TranscriptEntry{Text: e.Text, Start: e.Start, Duration: e.Duration})
+		}
+		return entries, nil
+	case FormatSRT, FormatVTT:
+		return readCues(r, format)
+	}
+	return nil, fmt.Errorf("reading %s transcripts is not supported", format)
+}
+
+// cueTag matches WebVTT inline markup such as <c>, <00:00:01.000> and <v Speaker>
+var cueTag = regexp.MustCompile(`<[^>]*>`)
+
+// readCues parses the blank-line separated cue blocks shared by SRT and WebVTT
+func readCues(r io.Reader, format Format) ([]TranscriptEntry, error) {
+	var (
+		entries []TranscriptEntry
+		block   []string
+		lineNo  int
+	)
+
+	flush := func() error {
+		defer func() { block = block[:0] }()
+		for i, line := range block {
+			if !strings.Contains(line, "-->") {
+				continue
+			}
+			start, end, err := parseCueTiming(line)
+			if err != nil {
+				return fmt.Errorf("line %d: %v", lineNo-len(block)+i, err)
+			}
+			text := strings.Join(block[i+1:], " ")
+			if format == FormatVTT {
+				text = cueTag.ReplaceAllString(text, "")
+			}
+			entries = append(entries, TranscriptEntry{Text: strings.TrimSpace(text), Start: start, Duration: end - start})
+			return nil
+		}
+		// Blocks without timing are SRT noise or WebVTT headers, NOTE and STYLE blocks
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	lineNo++
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// parseCueTiming parses "00:00:01,000 --> 00:00:02,500", ignoring WebVTT cue settings
+func parseCueTiming(line string) (float64, float64, error) {
+	parts := strings.SplitN(line, "-->", 2)
+	start, err := parseCueTimestamp(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	endFields := strings.Fields(parts[1])
+	if len(endFields) == 0 {
+		return 0, 0, fmt.Errorf("missing end time in %q", line)
+	}
+	end, err := parseCueTimestamp(endFields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// parseCueTimestamp parses HH:MM:SS,mmm or the WebVTT forms HH:MM:SS.mmm and MM:SS.mmm
+func parseCueTimestamp(s string) (float64, error) {
+	s = strings.Replace(strings.TrimSpace(s), ",", ".", 1)
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+
+	var seconds float64
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil || v < 0 || (i < len(parts)-1 && strings.Contains(p, ".")) {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		seconds = seconds*60 + v
+	}
+	return seconds, nil
+}
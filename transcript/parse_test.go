@@ -0,0 +1,47 @@
+package transcript
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadFormat_RoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatSRT, FormatVTT} {
+		t.Run(string(format), func(t *testing.T) {
+			text, err := FormatEntries(format, sampleEntries)
+			if err != nil {
+				t.Fatalf("FormatEntries() error = %v", err)
+			}
+			got, err := ReadFormat(strings.NewReader(text), format)
+			if err != nil {
+				t.Fatalf("ReadFormat() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, sampleEntries) {
+				t.Errorf("ReadFormat() = %+v; want %+v", got, sampleEntries)
+			}
+		})
+	}
+}
+
+func TestReadFormat_VTT(t *testing.T) {
+	vtt := "\ufeffWEBVTT\nKind: captions\n\nNOTE written by hand\n\n" +
+		"intro\n00:01.500 --> 00:03.000 align:start position:0%\n<v Host>Hello <c>there</c>\nfriends\n"
+	got, err := ReadFormat(strings.NewReader(vtt), FormatVTT)
+	if err != nil {
+		t.Fatalf("ReadFormat() error = %v", err)
+	}
+	want := []TranscriptEntry{{Text: "Hello there friends", Start: 1.5, Duration: 1.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadFormat() = %+v; want %+v", got, want)
+	}
+}
+
+func TestReadFormat_Errors(t *testing.T) {
+	if _, err := ReadFormat(strings.NewReader("1\n00:00:xx,000 --> 00:00:01,000\nhi\n"), FormatSRT); err == nil {
+		t.Error("ReadFormat() with a bad timestamp expected error")
+	}
+	if _, err := ReadFormat(strings.NewReader("text"), FormatCSV); err == nil {
+		t.Error("ReadFormat(csv) expected error")
+	}
+}
@@ -0,0 +1,95 @@
+package transcript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Processor is one post-processing step over the entries of a transcript
+type Processor interface {
+	Process(entries []TranscriptEntry) ([]TranscriptEntry, error)
+}
+
+// ProcessorFunc adapts a function to a Processor
+type ProcessorFunc func(entries []TranscriptEntry) ([]TranscriptEntry, error)
+
+func (f ProcessorFunc) Process(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+	return f(entries)
+}
+
+// Pipeline runs its processors in order, each on the output of the one before. A Pipeline
+// is itself a Processor, so pipelines nest.
+type Pipeline []Processor
+
+// Process runs the pipeline, stopping at the first processor that fails
+func (p Pipeline) Process(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+	for _, step := range p {
+		var err error
+		if entries, err = step.Process(entries); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// processors are the steps ParsePipeline knows by name
+var processors = map[string]Processor{
+	"strip": ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		return StripAnnotations(entries), nil
+	}),
+	"dedup": ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		return DedupOverlap(entries), nil
+	}),
+	"reflow": ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		return ReflowSentences(entries, DefaultReflowChars), nil
+	}),
+	"mask-profanity": ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		return NewProfanityFilter("").Process(entries)
+	}),
+	"remove-profanity": ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		f := NewProfanityFilter("")
+		f.Remove = true
+		return f.Process(entries)
+	}),
+	"turns": ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		return NewSpeakerTurns().Process(entries)
+	}),
+	"redact-pii": ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		return NewRedactor().Process(entries)
+	}),
+}
+
+// RegisterProcessor makes p available to ParsePipeline under name, replacing any step of
+// that name. It is meant to be called from init functions.
+func RegisterProcessor(name string, p Processor) {
+	processors[strings.ToLower(name)] = p
+}
+
+// ProcessorNames lists the steps ParsePipeline accepts, sorted
+func ProcessorNames() []string {
+	names := make([]string, 0, len(processors))
+	for name := range processors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParsePipeline builds a pipeline from a comma-separated list of step names such as
+// "strip,dedup,reflow"
+func ParsePipeline(list string) (Pipeline, error) {
+	var p Pipeline
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		step, ok := processors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown pipeline step %q (expected one of %s)", name, strings.Join(ProcessorNames(), ", "))
+		}
+		p = append(p, step)
+	}
+	return p, nil
+}
@@ -0,0 +1,59 @@
+package transcript
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePipeline(t *testing.T) {
+	p, err := ParsePipeline("strip, dedup,reflow")
+	if err != nil {
+		t.Fatalf("ParsePipeline() error = %v", err)
+	}
+	entries := []TranscriptEntry{
+		{Text: "[Music] hello there", Start: 0, Duration: 1},
+		{Text: "hello there my friend.", Start: 1, Duration: 1},
+	}
+
+	got, err := p.Process(entries)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if want := Clean(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("Process() = %+v; want the same as Clean: %+v", got, want)
+	}
+}
+
+func TestParsePipeline_UnknownStep(t *testing.T) {
+	if _, err := ParsePipeline("strip,shout"); err == nil || !strings.Contains(err.Error(), "shout") {
+		t.Errorf("ParsePipeline() error = %v; want an unknown step error", err)
+	}
+}
+
+func TestPipeline_CustomSteps(t *testing.T) {
+	upper := ProcessorFunc(func(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+		for i := range entries {
+			entries[i].Text = strings.ToUpper(entries[i].Text)
+		}
+		return entries, nil
+	})
+	RegisterProcessor("upper", upper)
+	defer delete(processors, "upper")
+
+	p, err := ParsePipeline("upper")
+	if err != nil {
+		t.Fatalf("ParsePipeline() error = %v", err)
+	}
+	got, _ := p.Process([]TranscriptEntry{{Text: "hi"}})
+	if got[0].Text != "HI" {
+		t.Errorf("Process() = %q; want HI", got[0].Text)
+	}
+
+	stop := errors.New("stop")
+	failing := Pipeline{upper, ProcessorFunc(func([]TranscriptEntry) ([]TranscriptEntry, error) { return nil, stop })}
+	if _, err := failing.Process([]TranscriptEntry{{Text: "hi"}}); err != stop {
+		t.Errorf("Process() error = %v; want %v", err, stop)
+	}
+}
@@ -0,0 +1,112 @@
+package transcript
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// playability is the playabilityStatus of a watch page's player response
+type playability struct {
+	Status      string `json:"status"`
+	Reason      string `json:"reason"`
+	ErrorScreen struct {
+		Renderer struct {
+			Reason struct {
+				SimpleText string `json:"simpleText"`
+			} `json:"reason"`
+		} `json:"playerErrorMessageRenderer"`
+	} `json:"errorScreen"`
+	LiveStreamability struct {
+		Renderer struct {
+			OfflineSlate struct {
+				Renderer struct {
+					ScheduledStartTime string `json:"scheduledStartTime"`
+				} `json:"liveStreamOfflineSlateRenderer"`
+			} `json:"offlineSlate"`
+		} `json:"liveStreamabilityRenderer"`
+	} `json:"liveStreamability"`
+
+	// raw is the undecoded object, searched for markers with no stable field
+	raw string
+}
+
+// parsePlayability extracts the playabilityStatus of a watch page; ok is false if it has none
+func parsePlayability(watchPage string) (p playability, ok bool) {
+	i := strings.Index(watchPage, `"playabilityStatus":`)
+	if i == -1 {
+		return p, false
+	}
+	raw, err := extractJSONObject(watchPage, i)
+	if err != nil || json.Unmarshal([]byte(raw), &p) != nil {
+		return p, false
+	}
+	p.raw = raw
+	return p, true
+}
+
+// details returns the status and reason attached to errors
+func (p playability) details() Playability {
+	reason := p.Reason
+	if reason == "" {
+		reason = p.ErrorScreen.Renderer.Reason.SimpleText
+	}
+	return Playability{Status: p.Status, Reason: reason}
+}
+
+// scheduledStart returns the announced start of an upcoming stream or premiere
+func (p playability) scheduledStart() time.Time {
+	sec, err := strconv.ParseInt(p.LiveStreamability.Renderer.OfflineSlate.Renderer.ScheduledStartTime, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// checkPlayability returns a typed error for watch pages of videos that cannot be watched yet
+// or by this client, and nil otherwise
+func checkPlayability(videoID, watchPage string) error {
+	p, ok := parsePlayability(watchPage)
+	if !ok || p.Status == "OK" {
+		return nil
+	}
+
+	details := p.details()
+	switch {
+	case strings.Contains(p.raw, `"offerId":"sponsors_only_video"`), strings.Contains(p.raw, "members-only content"):
+		return ErrMembersOnly{VideoID: videoID, Playability: details}
+	case p.Status == "LIVE_STREAM_OFFLINE", strings.Contains(watchPage, `"isUpcoming":true`):
+		return ErrNotYetAvailable{VideoID: videoID, ScheduledStart: p.scheduledStart(), Playability: details}
+	case strings.Contains(p.raw, "available in your country"):
+		return ErrRegionBlocked{VideoID: videoID, Region: clientRegion(watchPage), AvailableCountries: availableCountries(watchPage), Playability: details}
+	}
+	return &ErrVideoUnavailable{VideoID: videoID, Playability: details}
+}
+
+var (
+	clientRegionPattern       = regexp.MustCompile(`"(?:GL|INNERTUBE_CONTEXT_GL)":"([A-Z]{2})"`)
+	availableCountriesPattern = regexp.MustCompile(`"availableCountries":\[([^\]]*)\]`)
+)
+
+// clientRegion returns the country YouTube served a watch page for
+func clientRegion(watchPage string) string {
+	if m := clientRegionPattern.FindStringSubmatch(watchPage); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// availableCountries returns the countries a watch page's microformat lists the video as available in
+func availableCountries(watchPage string) []string {
+	m := availableCountriesPattern.FindStringSubmatch(watchPage)
+	if m == nil {
+		return nil
+	}
+	var countries []string
+	if json.Unmarshal([]byte("["+m[1]+"]"), &countries) != nil {
+		return nil
+	}
+	return countries
+}
@@ -0,0 +1,104 @@
+package transcript
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotYetAvailable(t *testing.T) {
+	page := `<script>var ytInitialPlayerResponse = {"playabilityStatus":{"status":"LIVE_STREAM_OFFLINE",` +
+		`"reason":"Premieres in 2 hours","liveStreamability":{"liveStreamabilityRenderer":{"offlineSlate":` +
+		`{"liveStreamOfflineSlateRenderer":{"scheduledStartTime":"1893456000"}}}}},` +
+		`"videoDetails":{"videoId":"abcdefghijk","isUpcoming":true}};</script>`
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return textResponse(r, page), nil
+	})))
+
+	_, err := client.GetTranscript("abcdefghijk")
+	var notYet ErrNotYetAvailable
+	if !errors.As(err, &notYet) {
+		t.Fatalf("GetTranscript() error = %v; want ErrNotYetAvailable", err)
+	}
+	if want := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC); !notYet.ScheduledStart.Equal(want) {
+		t.Errorf("ScheduledStart = %v; want %v", notYet.ScheduledStart, want)
+	}
+}
+
+func TestCheckPlayability_OK(t *testing.T) {
+	if err := checkPlayability("abcdefghijk", `{"playabilityStatus":{"status":"OK"}}`); err != nil {
+		t.Errorf("checkPlayability() = %v; want nil", err)
+	}
+	if err := checkPlayability("abcdefghijk", sampleWatchPage); err != nil {
+		t.Errorf("checkPlayability() without playabilityStatus = %v; want nil", err)
+	}
+}
+
+func TestTranscriptsDisabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		page  string
+		check func(error) bool
+	}{
+		{"playable without captions", `{"playabilityStatus":{"status":"OK"},"videoDetails":{"videoId":"abcdefghijk"}}`, func(err error) bool {
+			return errors.As(err, new(ErrTranscriptsDisabled))
+		}},
+		{"captions without tracks", `{"playabilityStatus":{"status":"OK"},"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[]}}}`, func(err error) bool {
+			return errors.As(err, new(ErrNoTranscriptFound))
+		}},
+		{"unplayable", `{"playabilityStatus":{"status":"ERROR","reason":"Video unavailable"}}`, func(err error) bool {
+			return errors.As(err, new(*ErrVideoUnavailable))
+		}},
+	}
+
+	for _, tt := range tests {
+		page := tt.page
+		client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return textResponse(r, page), nil
+		})))
+		_, err := client.ListAvailableTranscripts("abcdefghijk")
+		if !tt.check(err) {
+			t.Errorf("%s: ListAvailableTranscripts() error = %v", tt.name, err)
+		}
+	}
+}
+
+func TestRegionBlocked(t *testing.T) {
+	page := `<script>ytcfg.set({"INNERTUBE_CONTEXT_GL":"DE"});var ytInitialPlayerResponse = {"playabilityStatus":{"status":"UNPLAYABLE",` +
+		`"reason":"The uploader has not made this video available in your country"},` +
+		`"microformat":{"playerMicroformatRenderer":{"availableCountries":["US","CA"]}}};</script>`
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return textResponse(r, page), nil
+	})))
+
+	_, err := client.GetTranscript("abcdefghijk")
+	var blocked ErrRegionBlocked
+	if !errors.As(err, &blocked) {
+		t.Fatalf("GetTranscript() error = %v; want ErrRegionBlocked", err)
+	}
+	if blocked.Region != "DE" || strings.Join(blocked.AvailableCountries, ",") != "US,CA" {
+		t.Errorf("ErrRegionBlocked = %+v; want region DE, available in US,CA", blocked)
+	}
+}
+
+func TestPlayabilityError(t *testing.T) {
+	page := `{"playabilityStatus":{"status":"LOGIN_REQUIRED","errorScreen":{"playerErrorMessageRenderer":` +
+		`{"reason":{"simpleText":"Sign in to confirm your age"}}}}}`
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return textResponse(r, page), nil
+	})))
+
+	_, err := client.GetTranscript("abcdefghijk")
+	var pe PlayabilityError
+	if !errors.As(err, &pe) {
+		t.Fatalf("GetTranscript() error = %v; want a PlayabilityError", err)
+	}
+	if pe.PlayabilityStatus() != "LOGIN_REQUIRED" || pe.PlayabilityReason() != "Sign in to confirm your age" {
+		t.Errorf("playability = %q, %q; want LOGIN_REQUIRED, Sign in to confirm your age", pe.PlayabilityStatus(), pe.PlayabilityReason())
+	}
+	if !errors.As(err, new(*ErrVideoUnavailable)) {
+		t.Errorf("GetTranscript() error = %T; want *ErrVideoUnavailable", err)
+	}
+}
@@ -0,0 +1,226 @@
+package transcript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PlaylistVideo is a video listed in a playlist, in playlist order
+type PlaylistVideo struct {
+	VideoID string
+	Title   string
+	Index   int
+}
+
+// maxPlaylistPages bounds the continuation requests made for one playlist (100 videos each)
+const maxPlaylistPages = 200
+
+var (
+	playlistIDPattern      = regexp.MustCompile(`^(?:PL|UU|LL|FL|OL|RD)[A-Za-z0-9_-]{10,}$`)
+	innertubeKeyPattern    = regexp.MustCompile(`"INNERTUBE_API_KEY":"([^"]+)"`)
+	innertubeClientPattern = regexp.MustCompile(`"INNERTUBE_CLIENT_VERSION":"([^"]+)"`)
+)
+
+// ExtractPlaylistID returns the playlist ID of a playlist URL, a watch URL with a list
+// parameter, or a bare playlist ID. It returns "" when input names no playlist.
+func ExtractPlaylistID(input string) string {
+	input = strings.TrimSpace(input)
+	if playlistIDPattern.MatchString(input) {
+		return input
+	}
+	if !strings.Contains(input, "youtube.com/") {
+		return ""
+	}
+	u, err := url.Parse(input)
+	if err != nil {
+		return ""
+	}
+	if id := u.Query().Get("list"); playlistIDPattern.MatchString(id) {
+		return id
+	}
+	return ""
+}
+
+// GetPlaylistVideos returns every video of a playlist in order, following YouTube's
+// continuation pages
+func (c *Client) GetPlaylistVideos(playlistID string) ([]PlaylistVideo, error) {
+	return c.GetPlaylistVideosContext(context.Background(), playlistID)
+}
+
+// GetPlaylistVideosContext is GetPlaylistVideos with every page request bound to ctx
+func (c *Client) GetPlaylistVideosContext(ctx context.Context, playlistID string) ([]PlaylistVideo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	resp, err := c.getContext(ctx, "https://www.youtube.com/playlist?list="+url.QueryEscape(playlistID))
+	if err != nil {
+		return nil, err
+	}
+	page, err := readPage(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, ErrTooManyRequests{}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("playlist %s not found: HTTP %d", playlistID, resp.StatusCode)
+	}
+
+	videos, token, err := ParsePlaylistPage(page)
+	if err != nil {
+		return nil, fmt.Errorf("error reading playlist %s: %v", playlistID, err)
+	}
+
+	key := innertubeKeyPattern.FindStringSubmatch(page)
+	version := innertubeClientPattern.FindStringSubmatch(page)
+	for pages := 0; token != "" && key != nil && version != nil && pages < maxPlaylistPages; pages++ {
+		var more []PlaylistVideo
+		more, token, err = c.playlistContinuation(ctx, key[1], version[1], token)
+		if err != nil {
+			return nil, fmt.Errorf("error reading playlist %s: %v", playlistID, err)
+		}
+		videos = append(videos, more...)
+	}
+	return videos, nil
+}
+
+// playlistContinuation fetches the next page of a playlist from the InnerTube browse API
+func (c *Client) playlistContinuation(ctx context.Context, apiKey, clientVersion, token string) ([]PlaylistVideo, string, error) {
+	client := map[string]string{"clientName": "WEB", "clientVersion": clientVersion}
+	if c.hl != "" {
+		client["hl"] = c.hl
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"context":      map[string]interface{}{"client": client},
+		"continuation": token,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://www.youtube.com/youtubei/v1/browse?key="+url.QueryEscape(apiKey), bytes.NewReader(payload))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, "", ErrTooManyRequests{}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("continuation request failed: HTTP %d", resp.StatusCode)
+	}
+
+	var data interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, "", fmt.Errorf("error parsing continuation response: %v", err)
+	}
+	videos, next := collectPlaylistItems(data)
+	return videos, next, nil
+}
+
+// ParsePlaylistPage extracts the first page of videos and the continuation token, if any,
+// from the HTML of a playlist page
+func ParsePlaylistPage(page string) ([]PlaylistVideo, string, error) {
+	start := strings.Index(page, "ytInitialData")
+	if start == -1 {
+		return nil, "", fmt.Errorf("ytInitialData not found; the playlist may be private")
+	}
+	raw, err := extractJSONObject(page, start)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, "", fmt.Errorf("error parsing ytInitialData: %v", err)
+	}
+	videos, token := collectPlaylistItems(data)
+	if len(videos) == 0 {
+		return nil, "", fmt.Errorf("no videos found; the playlist may be empty or private")
+	}
+	return videos, token, nil
+}
+
+// collectPlaylistItems walks decoded InnerTube JSON for playlistVideoRenderer objects and
+// the token of the continuationItemRenderer that loads the next page
+func collectPlaylistItems(v interface{}) ([]PlaylistVideo, string) {
+	var (
+		videos []PlaylistVideo
+		token  string
+		walk   func(v interface{})
+	)
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if r, ok := v["playlistVideoRenderer"].(map[string]interface{}); ok {
+				if video, ok := playlistVideo(r); ok {
+					videos = append(videos, video)
+				}
+				return
+			}
+			if r, ok := v["continuationItemRenderer"].(map[string]interface{}); ok && token == "" {
+				token = continuationToken(r)
+				return
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(v)
+	return videos, token
+}
+
+func playlistVideo(r map[string]interface{}) (PlaylistVideo, bool) {
+	id, _ := r["videoId"].(string)
+	if id == "" {
+		return PlaylistVideo{}, false
+	}
+	video := PlaylistVideo{VideoID: id, Title: runsText(r["title"])}
+	if index, ok := r["index"].(map[string]interface{}); ok {
+		text, _ := index["simpleText"].(string)
+		video.Index, _ = strconv.Atoi(text)
+	}
+	return video, true
+}
+
+// runsText returns the text of an InnerTube text object, either simpleText or joined runs
+func runsText(v interface{}) string {
+	m, _ := v.(map[string]interface{})
+	if s, ok := m["simpleText"].(string); ok {
+		return s
+	}
+	runs, _ := m["runs"].([]interface{})
+	var sb strings.Builder
+	for _, run := range runs {
+		r, _ := run.(map[string]interface{})
+		text, _ := r["text"].(string)
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+func continuationToken(r map[string]interface{}) string {
+	endpoint, _ := r["continuationEndpoint"].(map[string]interface{})
+	command, _ := endpoint["continuationCommand"].(map[string]interface{})
+	token, _ := command["token"].(string)
+	return token
+}
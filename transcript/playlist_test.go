@@ -0,0 +1,105 @@
+package transcript
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const samplePlaylistPage = `<html><script>var ytInitialData = {"contents":{"playlistVideoListRenderer":{"contents":[
+{"playlistVideoRenderer":{"videoId":"abcdefghijk","index":{"simpleText":"1"},"title":{"runs":[{"text":"First "},{"text":"video"}]}}},
+{"playlistVideoRenderer":{"videoId":"bcdefghijkl","index":{"simpleText":"2"},"title":{"simpleText":"Second video"}}},
+{"continuationItemRenderer":{"continuationEndpoint":{"continuationCommand":{"token":"page2"}}}}
+]}}};</script>
+<script>ytcfg.set({"INNERTUBE_API_KEY":"apikey","INNERTUBE_CLIENT_VERSION":"2.20240101"});</script></html>`
+
+const samplePlaylistContinuation = `{"onResponseReceivedActions":[{"appendContinuationItemsAction":{"continuationItems":[
+{"playlistVideoRenderer":{"videoId":"cdefghijklm","index":{"simpleText":"3"},"title":{"runs":[{"text":"Third video"}]}}}
+]}}]}`
+
+func TestExtractPlaylistID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"PLabcdefghijklmnop", "PLabcdefghijklmnop"},
+		{"https://www.youtube.com/playlist?list=PLabcdefghijklmnop", "PLabcdefghijklmnop"},
+		{"https://www.youtube.com/watch?v=abcdefghijk&list=PLabcdefghijklmnop", "PLabcdefghijklmnop"},
+		{"https://www.youtube.com/watch?v=abcdefghijk", ""},
+		{"abcdefghijk", ""},
+	}
+	for _, tt := range tests {
+		if got := ExtractPlaylistID(tt.input); got != tt.expected {
+			t.Errorf("ExtractPlaylistID(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestGetPlaylistVideos(t *testing.T) {
+	var continuation map[string]interface{}
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/playlist":
+			if r.URL.Query().Get("list") != "PLabcdefghijklmnop" {
+				t.Errorf("requested playlist %q", r.URL.Query().Get("list"))
+			}
+			return textResponse(r, samplePlaylistPage), nil
+		case "/youtubei/v1/browse":
+			if r.Method != http.MethodPost || r.URL.Query().Get("key") != "apikey" {
+				t.Errorf("continuation request %s %s", r.Method, r.URL)
+			}
+			json.NewDecoder(r.Body).Decode(&continuation)
+			return textResponse(r, samplePlaylistContinuation), nil
+		}
+		t.Fatalf("unexpected request %s", r.URL)
+		return nil, nil
+	})))
+
+	videos, err := client.GetPlaylistVideos("PLabcdefghijklmnop")
+	if err != nil {
+		t.Fatalf("GetPlaylistVideos() error = %v", err)
+	}
+
+	want := []PlaylistVideo{
+		{VideoID: "abcdefghijk", Title: "First video", Index: 1},
+		{VideoID: "bcdefghijkl", Title: "Second video", Index: 2},
+		{VideoID: "cdefghijklm", Title: "Third video", Index: 3},
+	}
+	if len(videos) != len(want) {
+		t.Fatalf("got %d videos; want %d", len(videos), len(want))
+	}
+	for i := range want {
+		if videos[i] != want[i] {
+			t.Errorf("videos[%d] = %+v; want %+v", i, videos[i], want[i])
+		}
+	}
+	if continuation["continuation"] != "page2" {
+		t.Errorf("continuation request body = %v; want token page2", continuation)
+	}
+}
+
+func TestGetPlaylistVideos_Context(t *testing.T) {
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/playlist" {
+			return textResponse(r, samplePlaylistPage), nil
+		}
+		// The continuation hangs until the request is cancelled
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetPlaylistVideosContext(ctx, "PLabcdefghijklmnop"); err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("GetPlaylistVideosContext() past the deadline error = %v; want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestParsePlaylistPage_Private(t *testing.T) {
+	if _, _, err := ParsePlaylistPage(`<html>This playlist is private</html>`); err == nil {
+		t.Error("ParsePlaylistPage() expected error for a page without ytInitialData")
+	}
+}
@@ -0,0 +1,62 @@
+package transcript
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer is the largest buffer returned to the pool; bigger ones are left to the GC
+// so a single huge page does not pin its memory for the life of the process
+const maxPooledBuffer = 4 << 20
+
+// pageBuffers recycles the buffers watch and playlist pages are read into, which would
+// otherwise be regrown from scratch for every page of a batch
+var pageBuffers = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// readPage reads r to the end through a pooled buffer and returns its content
+func readPage(r io.Reader) (string, error) {
+	buf := pageBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			pageBuffers.Put(buf)
+		}
+	}()
+	if _, err := buf.ReadFrom(r); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// outputWriters recycles the buffered writers of the formatters
+var outputWriters = sync.Pool{
+	New: func() interface{} { return bufio.NewWriter(nil) },
+}
+
+// pooledEncoder returns its buffered writer to outputWriters once closed
+type pooledEncoder struct {
+	entryEncoder
+	bw *bufio.Writer
+}
+
+func (e *pooledEncoder) close() error {
+	err := e.entryEncoder.close()
+	e.release()
+	return err
+}
+
+// release returns the buffered writer to outputWriters without flushing it, for callers
+// giving up after an error; it is a no-op once the encoder is closed
+func (e *pooledEncoder) release() {
+	if e.bw == nil {
+		return
+	}
+	// Reset also clears an error the writer kept from a failed write
+	e.bw.Reset(nil)
+	outputWriters.Put(e.bw)
+	e.bw = nil
+}
@@ -0,0 +1,51 @@
+package transcript
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadPage_ReusesBuffers(t *testing.T) {
+	for _, page := range []string{strings.Repeat("long page ", 1000), "short"} {
+		got, err := readPage(strings.NewReader(page))
+		if err != nil {
+			t.Fatalf("readPage() error = %v", err)
+		}
+		if got != page {
+			t.Errorf("readPage() = %d bytes; want %d", len(got), len(page))
+		}
+	}
+}
+
+func TestWriteFormat_PooledWriters(t *testing.T) {
+	entries := []TranscriptEntry{{Text: "Hello", Start: 1, Duration: 2}}
+	first, err := FormatEntries(FormatSRT, entries)
+	if err != nil {
+		t.Fatalf("FormatEntries() error = %v", err)
+	}
+	if _, err := FormatEntries(FormatText, []TranscriptEntry{{Text: "other"}}); err != nil {
+		t.Fatalf("FormatEntries() error = %v", err)
+	}
+	if again, _ := FormatEntries(FormatSRT, entries); again != first {
+		t.Errorf("FormatEntries() with a recycled writer = %q; want %q", again, first)
+	}
+}
+
+// failingWriter rejects every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteFormat_RecyclesWritersAfterErrors(t *testing.T) {
+	entries := []TranscriptEntry{{Text: strings.Repeat("long cue ", 1000)}}
+	if err := WriteFormat(failingWriter{}, FormatText, entries); err == nil {
+		t.Fatal("WriteFormat() to a failing writer error = nil; want an error")
+	}
+	// A writer recycled after the failure must not keep its error
+	if got, err := FormatEntries(FormatText, []TranscriptEntry{{Text: "fine"}}); err != nil || got != "fine" {
+		t.Errorf("FormatEntries() = %q, %v; want fine", got, err)
+	}
+}
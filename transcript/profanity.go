@@ -0,0 +1,95 @@
+package transcript
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ProfanityLists holds the words NewProfanityFilter filters for each language code. Entries
+// ending in "*" also match every word starting with them. Add to it, or to the Words of a
+// ProfanityFilter, to extend the defaults.
+var ProfanityLists = map[string][]string{
+	"en": {"fuck*", "motherfuck*", "shit*", "bullshit", "bitch*", "asshole*", "bastard*", "cunt*", "dick", "dickhead*", "wanker*", "twat*"},
+	"de": {"scheiße", "scheisse", "scheiß*", "scheiss*", "arschloch*", "fick*", "wichser*", "fotze*", "hure*"},
+	"es": {"mierda", "puta*", "puto*", "joder", "coño", "cabrón", "cabron", "gilipollas"},
+	"fr": {"merde", "putain", "connard*", "connasse*", "salope*", "enculé*", "encule*"},
+}
+
+// ProfanityFilter masks or removes profane words. It is a Processor.
+type ProfanityFilter struct {
+	// Words lists the words to filter, compared case-insensitively; a trailing "*" matches prefixes
+	Words []string
+	// Remove drops the words instead of masking all but their first letter, and drops entries
+	// left empty
+	Remove bool
+}
+
+// NewProfanityFilter returns a masking filter with the words of ProfanityLists for language,
+// matched by its primary subtag ("en-GB" uses "en"). An empty language combines every list.
+func NewProfanityFilter(language string) ProfanityFilter {
+	if language == "" {
+		var words []string
+		for _, list := range ProfanityLists {
+			words = append(words, list...)
+		}
+		sort.Strings(words)
+		return ProfanityFilter{Words: words}
+	}
+	primary, _, _ := strings.Cut(strings.ToLower(language), "-")
+	return ProfanityFilter{Words: append([]string(nil), ProfanityLists[primary]...)}
+}
+
+// wordPattern matches the words of a caption line, keeping inner apostrophes
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’][\p{L}\p{N}]+)*`)
+
+// spaceBeforePunct matches the space a removed word leaves before punctuation
+var spaceBeforePunct = regexp.MustCompile(`\s+([,.!?;:])`)
+
+// Process masks or removes the filtered words in every entry
+func (f ProfanityFilter) Process(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+	exact := make(map[string]bool)
+	var prefixes []string
+	for _, w := range f.Words {
+		w = strings.ToLower(w)
+		if p, ok := strings.CutSuffix(w, "*"); ok {
+			prefixes = append(prefixes, p)
+		} else {
+			exact[w] = true
+		}
+	}
+	profane := func(word string) bool {
+		word = strings.ToLower(word)
+		if exact[word] {
+			return true
+		}
+		for _, p := range prefixes {
+			if strings.HasPrefix(word, p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	out := make([]TranscriptEntry, 0, len(entries))
+	for _, e := range entries {
+		e.Text = wordPattern.ReplaceAllStringFunc(e.Text, func(word string) string {
+			switch {
+			case !profane(word):
+				return word
+			case f.Remove:
+				return ""
+			}
+			runes := []rune(word)
+			return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+		})
+		if f.Remove {
+			e.Text = spaceBeforePunct.ReplaceAllString(strings.Join(strings.Fields(e.Text), " "), "$1")
+			if e.Text == "" {
+				continue
+			}
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
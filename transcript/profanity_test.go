@@ -0,0 +1,43 @@
+package transcript
+
+import "testing"
+
+func TestProfanityFilter(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "What the fuck, that's SHIT.", Start: 0},
+		{Text: "Shitake mushrooms are fine", Start: 1},
+		{Text: "fucking", Start: 2},
+	}
+
+	masked, _ := ProfanityFilter{Words: []string{"fuck*", "shit"}}.Process(entries)
+	want := []string{"What the f***, that's S***.", "Shitake mushrooms are fine", "f******"}
+	for i, e := range masked {
+		if e.Text != want[i] {
+			t.Errorf("masked entry %d = %q; want %q", i, e.Text, want[i])
+		}
+	}
+	if entries[0].Text != "What the fuck, that's SHIT." {
+		t.Errorf("Process() modified its input: %q", entries[0].Text)
+	}
+
+	removed, _ := ProfanityFilter{Words: []string{"fuck*", "shit"}, Remove: true}.Process(entries)
+	if len(removed) != 2 || removed[0].Text != "What the, that's." {
+		t.Errorf("removed = %+v; want the last entry dropped", removed)
+	}
+}
+
+func TestNewProfanityFilter(t *testing.T) {
+	got, _ := NewProfanityFilter("de-AT").Process([]TranscriptEntry{{Text: "So eine Scheiße!"}})
+	if got[0].Text != "So eine S******!" {
+		t.Errorf("NewProfanityFilter(de-AT) masked %q", got[0].Text)
+	}
+
+	p, err := ParsePipeline("mask-profanity")
+	if err != nil {
+		t.Fatalf("ParsePipeline() error = %v", err)
+	}
+	got, _ = p.Process([]TranscriptEntry{{Text: "merde alors"}})
+	if got[0].Text != "m**** alors" {
+		t.Errorf("mask-profanity step = %q", got[0].Text)
+	}
+}
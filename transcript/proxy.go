@@ -0,0 +1,53 @@
+package transcript
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+// WithProxies rotates requests round-robin across several proxies, spreading load
+// and rate limits over many exit addresses. Invalid URLs are skipped and make the client
+// fail as described in NewClient.
+func WithProxies(proxyURLs []string) ClientOption {
+	return func(c *Client) {
+		var proxies []*url.URL
+		for _, raw := range proxyURLs {
+			parsedURL, err := parseProxyURL(raw)
+			if err != nil {
+				c.optionError(err)
+				continue
+			}
+			proxies = append(proxies, parsedURL)
+		}
+		if len(proxies) == 0 {
+			return
+		}
+
+		var next uint32
+		c.httpClient.Transport = &http.Transport{
+			Proxy: func(*http.Request) (*url.URL, error) {
+				i := atomic.AddUint32(&next, 1) - 1
+				return proxies[int(i)%len(proxies)], nil
+			},
+		}
+	}
+}
+
+// parseProxyURL parses a proxy URL, rejecting those net/http could not connect through
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %v", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", raw)
+	}
+	return u, nil
+}
@@ -0,0 +1,84 @@
+package transcript
+
+import "strings"
+
+// QuoteMatch is where a quote was found in a transcript
+type QuoteMatch struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	// Text joins the entries the passage spans
+	Text string `json:"text"`
+	// Score is the share of the quote's words matched, 1 for an exact match
+	Score float64 `json:"score"`
+}
+
+// minQuoteScore is the lowest score LocateQuote accepts as a match
+const minQuoteScore = 0.6
+
+// LocateQuote finds the passage of entries closest to quote, which may span several entries
+// and be remembered with a few words missing, added or changed. Words are compared as
+// ComputeStats counts them, ignoring case and punctuation, and words of four letters or
+// more also match their inflections ("ultimate" and "ultimately"). It returns false when no passage
+// matches at least 60% of the quote's words.
+func LocateQuote(entries []TranscriptEntry, quote string) (QuoteMatch, bool) {
+	q := tokenize(quote)
+	if len(q) == 0 {
+		return QuoteMatch{}, false
+	}
+
+	type word struct {
+		text  string
+		entry int
+	}
+	var words []word
+	for i, e := range entries {
+		for _, w := range tokenize(e.Text) {
+			words = append(words, word{w, i})
+		}
+	}
+	texts := make([]string, len(words))
+	for i, w := range words {
+		texts[i] = w.text
+	}
+
+	// Windows a little shorter or longer than the quote allow for missing or added words
+	slack := max(len(q)/4, 1)
+	best, bestFrom, bestTo := -1.0, 0, 0
+	for from := range texts {
+		// A passage starting with a word of the quote beats a shifted copy of it
+		if texts[from] != q[0] && from+1 < len(texts) && texts[from+1] == q[0] {
+			continue
+		}
+		for n := max(len(q)-slack, 1); n <= len(q)+slack && from+n <= len(texts); n++ {
+			sub, del, ins := alignWords(q, texts[from:from+n], similarWords)
+			score := 1 - float64(sub+del+ins)/float64(len(q))
+			if score > best {
+				best, bestFrom, bestTo = score, from, from+n
+			}
+		}
+	}
+	if best < minQuoteScore {
+		return QuoteMatch{}, false
+	}
+
+	first, last := entries[words[bestFrom].entry], entries[words[bestTo-1].entry]
+	m := QuoteMatch{Start: first.Start, End: last.Start + last.Duration, Score: best}
+	for i := words[bestFrom].entry; i <= words[bestTo-1].entry; i++ {
+		if m.Text != "" {
+			m.Text += " "
+		}
+		m.Text += entries[i].Text
+	}
+	return m, true
+}
+
+// similarWords reports whether two words are equal, or one extends the other by a suffix
+func similarWords(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) >= 4 && strings.HasPrefix(b, a)
+}
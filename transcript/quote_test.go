@@ -0,0 +1,41 @@
+package transcript
+
+import "testing"
+
+func TestLocateQuote(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "Welcome back to the channel.", Start: 0, Duration: 3},
+		{Text: "Today I want to talk about why", Start: 3, Duration: 3},
+		{Text: "simplicity is the ultimate", Start: 6, Duration: 2},
+		{Text: "sophistication in software.", Start: 8, Duration: 2},
+		{Text: "Let's get started.", Start: 10, Duration: 2},
+	}
+
+	tests := []struct {
+		quote      string
+		start, end float64
+	}{
+		{"simplicity is the ultimate sophistication", 6, 10},
+		{"Simplicity is the ultimate sophistication!", 6, 10},
+		{"simplicity is ultimately sophistication", 6, 10},
+		{"talk about why simplicity", 3, 8},
+		{"let's get started", 10, 12},
+	}
+	for _, tt := range tests {
+		m, ok := LocateQuote(entries, tt.quote)
+		if !ok {
+			t.Errorf("LocateQuote(%q) found nothing", tt.quote)
+			continue
+		}
+		if m.Start != tt.start || m.End != tt.end {
+			t.Errorf("LocateQuote(%q) = %v-%v; want %v-%v", tt.quote, m.Start, m.End, tt.start, tt.end)
+		}
+	}
+
+	if m, _ := LocateQuote(entries, "simplicity is the ultimate sophistication"); m.Score != 1 || m.Text != "simplicity is the ultimate sophistication in software." {
+		t.Errorf("LocateQuote() exact match = %+v", m)
+	}
+	if m, ok := LocateQuote(entries, "the weather is lovely in spring"); ok {
+		t.Errorf("LocateQuote() of an absent quote = %+v; want no match", m)
+	}
+}
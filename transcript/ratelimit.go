@@ -0,0 +1,57 @@
+package transcript
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRequestRate caps the client at perSecond HTTP requests per second, shared across
+// goroutines, so large batches stay under YouTube's throttling thresholds. Zero disables the cap.
+func WithRequestRate(perSecond float64) ClientOption {
+	return func(c *Client) {
+		if perSecond <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = &requestLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+	}
+}
+
+// requestLimiter spaces requests at least interval apart
+type requestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the caller may send its request, or until ctx is done. A cancelled
+// caller gives its slot back unless later callers have already queued behind it.
+func (l *requestLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	reserved := l.next
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		if l.next.Equal(reserved) {
+			l.next = l.next.Add(-l.interval)
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
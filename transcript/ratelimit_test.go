@@ -0,0 +1,53 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	l := &requestLimiter{interval: 20 * time.Millisecond}
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+	// The first request goes out immediately, the next three are spaced by interval
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("4 requests took %s; want at least 60ms", elapsed)
+	}
+}
+
+func TestRequestLimiter_Cancel(t *testing.T) {
+	l := &requestLimiter{interval: time.Hour}
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("first wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait() error = %v; want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancelled wait() took %s", elapsed)
+	}
+	// The cancelled caller gave its slot back
+	if wait := time.Until(l.next); wait > time.Hour {
+		t.Errorf("next slot in %s; want at most an hour", wait)
+	}
+}
+
+func TestWithRequestRate(t *testing.T) {
+	if c := NewClient(WithRequestRate(0)); c.limiter != nil {
+		t.Error("WithRequestRate(0) set a limiter; want none")
+	}
+	if c := NewClient(WithRequestRate(4)); c.limiter == nil || c.limiter.interval != 250*time.Millisecond {
+		t.Errorf("WithRequestRate(4) limiter = %+v; want 250ms interval", c.limiter)
+	}
+}
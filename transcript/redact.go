@@ -0,0 +1,53 @@
+package transcript
+
+import "regexp"
+
+// RedactRule replaces every match of Pattern with Replacement
+type RedactRule struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// DefaultRedactRules find email addresses, URLs and phone numbers, both written out and in
+// the spoken form auto-generated captions use ("jane at example dot com")
+var DefaultRedactRules = []RedactRule{
+	{
+		Name:        "email",
+		Pattern:     regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b|\b[a-z0-9._-]+ at [a-z0-9-]+(?: dot [a-z0-9-]+)* dot (?:com|org|net|edu|gov|io|co|de|uk|fr)\b`),
+		Replacement: "[email]",
+	},
+	{
+		Name:        "url",
+		Pattern:     regexp.MustCompile(`(?i)\bhttps?://[^\s]+|\bwww\.[^\s]+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|org|net|io|dev|co|de|uk|fr)(?:/[^\s]*)?\b|\b[a-z0-9-]+ dot (?:com|org|net|io|dev|co)\b`),
+		Replacement: "[url]",
+	},
+	{
+		Name:        "phone",
+		Pattern:     regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{2,4}\)[\s.-]?)?\d{2,4}(?:[\s.-]\d{2,4}){2,4}\b`),
+		Replacement: "[phone]",
+	},
+}
+
+// Redactor replaces personal data in transcript text according to its rules. It is a Processor.
+type Redactor struct {
+	Rules []RedactRule
+}
+
+// NewRedactor returns a Redactor with a copy of DefaultRedactRules followed by rules, so
+// callers can add patterns such as account or ticket numbers
+func NewRedactor(rules ...RedactRule) Redactor {
+	return Redactor{Rules: append(append([]RedactRule(nil), DefaultRedactRules...), rules...)}
+}
+
+// Process applies the rules in order to every entry
+func (r Redactor) Process(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+	out := make([]TranscriptEntry, len(entries))
+	for i, e := range entries {
+		for _, rule := range r.Rules {
+			e.Text = rule.Pattern.ReplaceAllString(e.Text, rule.Replacement)
+		}
+		out[i] = e
+	}
+	return out, nil
+}
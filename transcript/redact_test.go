@@ -0,0 +1,38 @@
+package transcript
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	tests := map[string]string{
+		"write to jane.doe@example.com today":    "write to [email] today",
+		"that's jane at example dot com":         "that's [email]",
+		"see https://example.com/a?b=c for more": "see [url] for more",
+		"go to example dot com and sign up":      "go to [url] and sign up",
+		"visit shop.example.org/deals":           "visit [url]",
+		"call 555 123 4567 now":                  "call [phone] now",
+		"or +44 20 7946 0958":                    "or [phone]",
+		"it was 1999 and we had 3 kids":          "it was 1999 and we had 3 kids",
+		"the talk starts at 10.30 in room 12":    "the talk starts at 10.30 in room 12",
+	}
+	r := NewRedactor()
+	for in, want := range tests {
+		got, _ := r.Process([]TranscriptEntry{{Text: in}})
+		if got[0].Text != want {
+			t.Errorf("Process(%q) = %q; want %q", in, got[0].Text, want)
+		}
+	}
+}
+
+func TestNewRedactor_CustomRules(t *testing.T) {
+	r := NewRedactor(RedactRule{Name: "ticket", Pattern: regexp.MustCompile(`\bTICKET-\d+\b`), Replacement: "[ticket]"})
+	got, _ := r.Process([]TranscriptEntry{{Text: "see TICKET-1234"}})
+	if got[0].Text != "see [ticket]" {
+		t.Errorf("Process() = %q; want the custom rule applied", got[0].Text)
+	}
+	if len(DefaultRedactRules) != 3 {
+		t.Errorf("NewRedactor() changed DefaultRedactRules")
+	}
+}
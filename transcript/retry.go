@@ -0,0 +1,149 @@
+package transcript
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxRetryAfter bounds the waits a Retry-After header can ask for, unless WithMaxRetryAfter changes it
+const DefaultMaxRetryAfter = time.Minute
+
+// WithTimeout limits the total time of each HTTP request, including reading the body
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithRetries retries requests failing with a network error, HTTP 429 or a 5xx status
+// up to retries times. The wait starts at delay and doubles after every attempt.
+func WithRetries(retries int, delay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryDelay = delay
+	}
+}
+
+// WithMaxRetryAfter bounds the wait before a retry when a 429 or 503 response carries a
+// Retry-After header, which otherwise replaces the WithRetries backoff. Zero ignores the header.
+func WithMaxRetryAfter(max time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRetryAfter = max
+	}
+}
+
+// do sends req, retrying transient failures according to WithRetries, unless the
+// circuit breaker is open
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.doWithRetries(req)
+	}
+	probe, err := c.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doWithRetries(req)
+	c.breaker.record(probe, resp, err)
+	return resp, err
+}
+
+func (c *Client) doWithRetries(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		// The URL of a network error would carry the API key of Data API requests
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = redactedURL(req.URL)
+		}
+		c.observe(req, attempt, start, resp, err)
+		if err != nil {
+			c.logger.Printf("%s %s failed: %v", req.Method, redactedURL(req.URL), err)
+		} else {
+			c.logger.Printf("%s %s: %s in %s", req.Method, redactedURL(req.URL), resp.Status, time.Since(start).Round(time.Millisecond))
+		}
+
+		if attempt >= c.retries || !isTransient(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		delay := c.retryDelay << attempt
+		if after, ok := retryAfter(resp); ok && c.maxRetryAfter > 0 {
+			delay = min(after, c.maxRetryAfter)
+		}
+		c.logger.Printf("Retrying %s in %s (retry %d of %d)", redactedURL(req.URL), delay, attempt+1, c.retries)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		// Requests with a body can only be resent from a fresh copy of it
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// secretParams are the query parameters that carry credentials, such as the Data API key
+var secretParams = []string{"key", "access_token", "client_secret", "refresh_token"}
+
+// redactedURL returns u for logs, with the values of secretParams masked
+func redactedURL(u *url.URL) string {
+	q := u.Query()
+	redacted := false
+	for _, name := range secretParams {
+		if q.Has(name) {
+			q.Set(name, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	masked := *u
+	masked.RawQuery = q.Encode()
+	return masked.String()
+}
+
+// retryAfter returns the wait a 429 or 503 response asks for in its Retry-After header,
+// given either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// isTransient reports whether a request outcome is worth retrying
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,166 @@
+package transcript
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		retries  int
+		failures int
+		wantErr  bool
+		requests int
+	}{
+		{name: "Recovers", retries: 2, failures: 2, wantErr: false, requests: 3},
+		{name: "Gives up", retries: 1, failures: 2, wantErr: true, requests: 2},
+		{name: "Disabled", retries: 0, failures: 1, wantErr: true, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			fake := fakeYouTube(t)
+			client := NewClient(WithRetries(tt.retries, time.Millisecond), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				if r.URL.Path != "/watch" {
+					return fake(r)
+				}
+				requests++
+				if requests <= tt.failures {
+					return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+				}
+				return fake(r)
+			})))
+
+			_, err := client.GetTranscript("abcdefghijk")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTranscript() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.requests {
+				t.Errorf("made %d watch page requests; want %d", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestWithRetries_CancelDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient(WithRetries(3, time.Hour), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		// Cancel while the client waits for the first retry
+		cancel()
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	})))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.youtube.com/watch?v=abcdefghijk", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.do(req)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("do() error = %v; want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("do() kept waiting for the retry after the context was cancelled")
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusNotFound, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+	}
+
+	for _, tt := range tests {
+		if got := isTransient(&http.Response{StatusCode: tt.status}, nil); got != tt.expected {
+			t.Errorf("isTransient(%d) = %v; want %v", tt.status, got, tt.expected)
+		}
+	}
+	if !isTransient(nil, io.ErrUnexpectedEOF) {
+		t.Error("isTransient(nil, err) = false; want true")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		status int
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{http.StatusTooManyRequests, "3", 3 * time.Second, true},
+		{http.StatusServiceUnavailable, "0", 0, true},
+		{http.StatusTooManyRequests, "Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+		{http.StatusTooManyRequests, "soon", 0, false},
+		{http.StatusTooManyRequests, "", 0, false},
+		{http.StatusBadGateway, "3", 0, false},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Header: http.Header{"Retry-After": {tt.header}}}
+		got, ok := retryAfter(resp)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%d, %q) = %v, %v; want %v, %v", tt.status, tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWithMaxRetryAfter(t *testing.T) {
+	fake := fakeYouTube(t)
+	requests := 0
+	// The backoff delay alone would stall the test; the bounded Retry-After replaces it
+	client := NewClient(WithRetries(1, time.Hour), WithMaxRetryAfter(10*time.Millisecond), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/watch" {
+			requests++
+			if requests == 1 {
+				header := http.Header{"Retry-After": {"120"}}
+				return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+			}
+		}
+		return fake(r)
+	})))
+
+	start := time.Now()
+	if _, err := client.GetTranscript("abcdefghijk"); err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("GetTranscript() took %s; want the 10ms bound", elapsed)
+	}
+}
+
+func TestRetries_RedactAPIKey(t *testing.T) {
+	logger := &recordingLogger{}
+	client := NewClient(WithLogger(logger), WithRetries(1, time.Millisecond), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset")
+	})))
+
+	_, err := client.get("https://www.googleapis.com/youtube/v3/captions?videoId=abcdefghijk&key=s3cret")
+	if err == nil || strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("error = %v; want a failure without the API key", err)
+	}
+	if len(logger.lines) == 0 {
+		t.Fatal("nothing was logged")
+	}
+	for _, line := range logger.lines {
+		if strings.Contains(line, "s3cret") || !strings.Contains(line, "key=REDACTED") {
+			t.Errorf("logged %q; want the key masked", line)
+		}
+	}
+}
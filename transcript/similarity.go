@@ -0,0 +1,143 @@
+package transcript
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// MinHash parameters: word shingles of shingleSize, signatureSize hashes split into
+// signatureBands bands for locality-sensitive hashing
+const (
+	shingleSize    = 5
+	signatureSize  = 128
+	signatureBands = 32
+)
+
+// DefaultDuplicateThreshold is the estimated Jaccard similarity from which FindDuplicates
+// reports two transcripts
+const DefaultDuplicateThreshold = 0.8
+
+// Signature is a MinHash sketch of the word shingles of a transcript. The share of equal
+// positions of two signatures estimates the Jaccard similarity of their shingle sets.
+type Signature []uint64
+
+// NewSignature sketches the words of entries, ignoring case, punctuation and timing, so a
+// reupload with shifted or re-split captions gets a near-identical signature. Entries without
+// words yield an empty signature, which is similar to none, so empty transcripts are never
+// reported as duplicates of each other.
+func NewSignature(entries []TranscriptEntry) Signature {
+	var words []string
+	for _, e := range entries {
+		words = append(words, tokenize(e.Text)...)
+	}
+	if len(words) == 0 {
+		return Signature{}
+	}
+	sig := make(Signature, signatureSize)
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+	n := max(len(words)-shingleSize+1, 1)
+	for i := 0; i < n; i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:min(i+shingleSize, len(words))], " ")))
+		x := h.Sum64()
+		for j := range sig {
+			if v := mix64(x ^ minHashSeeds[j]); v < sig[j] {
+				sig[j] = v
+			}
+		}
+	}
+	return sig
+}
+
+// Similarity estimates the Jaccard similarity of the transcripts behind two signatures
+func (s Signature) Similarity(o Signature) float64 {
+	if len(s) == 0 || len(s) != len(o) {
+		return 0
+	}
+	same := 0
+	for i := range s {
+		if s[i] == o[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(s))
+}
+
+// Duplicate is a pair of near-identical transcripts
+type Duplicate struct {
+	A          string  `json:"a"`
+	B          string  `json:"b"`
+	Similarity float64 `json:"similarity"`
+}
+
+// FindDuplicates returns the pairs of signatures, keyed by video ID, at least threshold
+// similar, most similar first. Only pairs sharing a band of their signatures are compared, so
+// large corpora are not compared pairwise. Empty signatures are skipped.
+func FindDuplicates(signatures map[string]Signature, threshold float64) []Duplicate {
+	ids := make([]string, 0, len(signatures))
+	for id := range signatures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	rows := signatureSize / signatureBands
+	candidates := make(map[[2]string]bool)
+	for band := 0; band < signatureBands; band++ {
+		buckets := make(map[uint64][]string)
+		for _, id := range ids {
+			sig := signatures[id]
+			if len(sig) != signatureSize {
+				continue
+			}
+			h := fnv.New64a()
+			for _, v := range sig[band*rows : (band+1)*rows] {
+				for k := 0; k < 8; k++ {
+					h.Write([]byte{byte(v >> (8 * k))})
+				}
+			}
+			key := h.Sum64()
+			for _, other := range buckets[key] {
+				candidates[[2]string{other, id}] = true
+			}
+			buckets[key] = append(buckets[key], id)
+		}
+	}
+
+	dups := []Duplicate{}
+	for pair := range candidates {
+		if sim := signatures[pair[0]].Similarity(signatures[pair[1]]); sim >= threshold {
+			dups = append(dups, Duplicate{A: pair[0], B: pair[1], Similarity: sim})
+		}
+	}
+	sort.Slice(dups, func(i, j int) bool {
+		if dups[i].Similarity != dups[j].Similarity {
+			return dups[i].Similarity > dups[j].Similarity
+		}
+		if dups[i].A != dups[j].A {
+			return dups[i].A < dups[j].A
+		}
+		return dups[i].B < dups[j].B
+	})
+	return dups
+}
+
+// minHashSeeds give each position of a signature its own hash function
+var minHashSeeds = func() []uint64 {
+	seeds := make([]uint64, signatureSize)
+	x := uint64(0x5eed)
+	for i := range seeds {
+		x += 0x9e3779b97f4a7c15
+		seeds[i] = mix64(x)
+	}
+	return seeds
+}()
+
+// mix64 is the SplitMix64 finalizer, scrambling x into a well-distributed hash
+func mix64(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,57 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// lecture returns entries of n distinct sentences, split into cues of the given size
+func lecture(topic string, n, cue int) []TranscriptEntry {
+	var words []string
+	for i := 0; i < n; i++ {
+		words = append(words, strings.Fields(fmt.Sprintf("point %d about %s is worth remembering", i, topic))...)
+	}
+	var entries []TranscriptEntry
+	for i := 0; i < len(words); i += cue {
+		entries = append(entries, TranscriptEntry{Text: strings.Join(words[i:min(i+cue, len(words))], " "), Start: float64(i)})
+	}
+	return entries
+}
+
+func TestSignature_Similarity(t *testing.T) {
+	original := NewSignature(lecture("compilers", 50, 7))
+	// A reupload with captions split differently and a different intro
+	reupload := NewSignature(append([]TranscriptEntry{{Text: "Hi, mirror channel here!"}}, lecture("compilers", 50, 4)...))
+	other := NewSignature(lecture("gardening", 50, 7))
+
+	if sim := original.Similarity(reupload); sim < 0.8 {
+		t.Errorf("Similarity(reupload) = %.2f; want at least 0.8", sim)
+	}
+	if sim := original.Similarity(other); sim > 0.3 {
+		t.Errorf("Similarity(other) = %.2f; want at most 0.3", sim)
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	signatures := map[string]Signature{
+		"original": NewSignature(lecture("compilers", 50, 7)),
+		"mirror":   NewSignature(lecture("compilers", 50, 5)),
+		"other":    NewSignature(lecture("gardening", 50, 7)),
+	}
+	dups := FindDuplicates(signatures, DefaultDuplicateThreshold)
+	if len(dups) != 1 || dups[0].A != "mirror" || dups[0].B != "original" || dups[0].Similarity != 1 {
+		t.Errorf("FindDuplicates() = %+v; want mirror and original, identical", dups)
+	}
+}
+
+func TestFindDuplicates_SkipsEmpty(t *testing.T) {
+	signatures := map[string]Signature{
+		"silent":  NewSignature(nil),
+		"music":   NewSignature([]TranscriptEntry{{Text: "♪ ♪"}, {Text: " "}}),
+		"lecture": NewSignature(lecture("compilers", 50, 7)),
+	}
+	if dups := FindDuplicates(signatures, DefaultDuplicateThreshold); len(dups) != 0 {
+		t.Errorf("FindDuplicates() = %+v; want empty transcripts not reported", dups)
+	}
+}
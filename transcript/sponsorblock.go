@@ -0,0 +1,104 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// sponsorBlockURL is the segment endpoint of the public SponsorBlock API
+const sponsorBlockURL = "https://sponsor.ajay.app/api/skipSegments"
+
+// DefaultSponsorCategories are the SponsorBlock categories of ad reads and self-promotion
+var DefaultSponsorCategories = []string{"sponsor", "selfpromo"}
+
+// SponsorSegment is a part of a video SponsorBlock users marked, such as an ad read
+type SponsorSegment struct {
+	Start    float64 `json:"start"`
+	End      float64 `json:"end"`
+	Category string  `json:"category"`
+}
+
+// GetSponsorSegments asks the SponsorBlock API for the segments of videoID in the given
+// categories, DefaultSponsorCategories when none are given. Videos nobody submitted
+// segments for have none.
+func (c *Client) GetSponsorSegments(videoID string, categories ...string) ([]SponsorSegment, error) {
+	if len(categories) == 0 {
+		categories = DefaultSponsorCategories
+	}
+	list, _ := json.Marshal(categories)
+	resp, err := c.get(sponsorBlockURL + "?videoID=" + url.QueryEscape(videoID) + "&categories=" + url.QueryEscape(string(list)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return []SponsorSegment{}, nil
+	case http.StatusTooManyRequests:
+		return nil, ErrTooManyRequests{VideoID: videoID}
+	default:
+		return nil, fmt.Errorf("SponsorBlock request failed: HTTP %d", resp.StatusCode)
+	}
+
+	var raw []struct {
+		Segment  [2]float64 `json:"segment"`
+		Category string     `json:"category"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("error parsing SponsorBlock response: %v", err)
+	}
+	segments := make([]SponsorSegment, 0, len(raw))
+	for _, s := range raw {
+		segments = append(segments, SponsorSegment{Start: s.Segment[0], End: s.Segment[1], Category: s.Category})
+	}
+	return segments, nil
+}
+
+// SponsorFilter drops or labels the entries spoken during sponsor segments, judged by the
+// middle of each entry. It is a Processor.
+type SponsorFilter struct {
+	Segments []SponsorSegment
+	// Label keeps the entries, prefixed with their category in brackets, like "[sponsor]"
+	Label bool
+}
+
+// SponsorBlockFilter returns a SponsorFilter with the segments of videoID in the default
+// categories
+func (c *Client) SponsorBlockFilter(videoID string, label bool) (SponsorFilter, error) {
+	segments, err := c.GetSponsorSegments(videoID)
+	if err != nil {
+		return SponsorFilter{}, err
+	}
+	return SponsorFilter{Segments: segments, Label: label}, nil
+}
+
+// Process drops or labels the entries inside the segments
+func (f SponsorFilter) Process(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+	out := make([]TranscriptEntry, 0, len(entries))
+	for _, e := range entries {
+		s, ok := f.segmentAt(e.Start + e.Duration/2)
+		switch {
+		case !ok:
+		case f.Label:
+			e.Text = "[" + s.Category + "] " + e.Text
+		default:
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// segmentAt returns the segment containing the given second
+func (f SponsorFilter) segmentAt(at float64) (SponsorSegment, bool) {
+	for _, s := range f.Segments {
+		if at >= s.Start && at < s.End {
+			return s, true
+		}
+	}
+	return SponsorSegment{}, false
+}
@@ -0,0 +1,54 @@
+package transcript
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSponsorSegments(t *testing.T) {
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Host != "sponsor.ajay.app" || r.URL.Query().Get("videoID") != "abcdefghijk" || r.URL.Query().Get("categories") != `["sponsor","selfpromo"]` {
+			t.Errorf("unexpected request to %s", r.URL)
+		}
+		return textResponse(r, `[{"segment":[30.5,60],"category":"sponsor","UUID":"x"}]`), nil
+	})))
+
+	segments, err := client.GetSponsorSegments("abcdefghijk")
+	if err != nil {
+		t.Fatalf("GetSponsorSegments() error = %v", err)
+	}
+	if len(segments) != 1 || segments[0] != (SponsorSegment{Start: 30.5, End: 60, Category: "sponsor"}) {
+		t.Errorf("GetSponsorSegments() = %+v", segments)
+	}
+}
+
+func TestGetSponsorSegments_None(t *testing.T) {
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("Not Found")), Request: r}, nil
+	})))
+	segments, err := client.GetSponsorSegments("abcdefghijk")
+	if err != nil || len(segments) != 0 {
+		t.Errorf("GetSponsorSegments() = %v, %v; want no segments", segments, err)
+	}
+}
+
+func TestSponsorFilter(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "welcome", Start: 0, Duration: 5},
+		{Text: "this video is sponsored by", Start: 30, Duration: 5},
+		{Text: "back to the topic", Start: 58, Duration: 6},
+	}
+	segments := []SponsorSegment{{Start: 30, End: 60, Category: "sponsor"}}
+
+	stripped, _ := SponsorFilter{Segments: segments}.Process(entries)
+	if len(stripped) != 2 || stripped[1].Text != "back to the topic" {
+		t.Errorf("Process() = %+v; want the sponsor read dropped", stripped)
+	}
+
+	labeled, _ := SponsorFilter{Segments: segments, Label: true}.Process(entries)
+	if len(labeled) != 3 || labeled[1].Text != "[sponsor] this video is sponsored by" {
+		t.Errorf("Process() with Label = %+v", labeled)
+	}
+}
@@ -0,0 +1,124 @@
+package transcript
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Stats summarizes the content and pacing of a transcript
+type Stats struct {
+	Duration       float64     `json:"duration"` // seconds until the end of the last entry
+	Entries        int         `json:"entries"`
+	Words          int         `json:"words"`
+	UniqueWords    int         `json:"uniqueWords"`
+	WordsPerMinute float64     `json:"wordsPerMinute"`
+	LongestSilence Gap         `json:"longestSilence"`
+	TopKeywords    []WordCount `json:"topKeywords"`
+}
+
+// Gap is a stretch of time without captions
+type Gap struct {
+	Start    float64 `json:"start"`
+	End      float64 `json:"end"`
+	Duration float64 `json:"duration"`
+}
+
+// WordCount is the number of occurrences of a word
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// topKeywords is the number of keywords reported by ComputeStats
+const topKeywords = 10
+
+// stopWords are frequent English words that say nothing about a transcript's topic
+var stopWords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`the and that this with have for not you are was were but they his her she him
+		from what there their them then than when which who will would could should can just about into your our out
+		all any been being because very also some more most much many its it's i'm you're we're they're don't doesn't
+		didn't isn't aren't that's there's what's let's here how why where these those over only like well yeah okay
+		gonna going know think really right one two get got has had did does ours music applause`) {
+		stopWords[w] = true
+	}
+}
+
+// ComputeStats counts words and measures pacing and pauses across entries
+func ComputeStats(entries []TranscriptEntry) Stats {
+	s := Stats{Entries: len(entries)}
+	counts := make(map[string]int)
+
+	for i, e := range entries {
+		for _, w := range tokenize(e.Text) {
+			s.Words++
+			counts[w]++
+		}
+		if end := e.Start + e.Duration; end > s.Duration {
+			s.Duration = end
+		}
+		if i > 0 {
+			prev := entries[i-1]
+			gapStart := prev.Start + prev.Duration
+			if gap := e.Start - gapStart; gap > s.LongestSilence.Duration {
+				s.LongestSilence = Gap{Start: gapStart, End: e.Start, Duration: gap}
+			}
+		}
+	}
+
+	s.UniqueWords = len(counts)
+	if s.Duration > 0 {
+		s.WordsPerMinute = float64(s.Words) / (s.Duration / 60)
+	}
+
+	s.TopKeywords = []WordCount{}
+	for w, n := range counts {
+		if len([]rune(w)) >= 3 && !stopWords[w] {
+			s.TopKeywords = append(s.TopKeywords, WordCount{Word: w, Count: n})
+		}
+	}
+	sort.Slice(s.TopKeywords, func(i, j int) bool {
+		a, b := s.TopKeywords[i], s.TopKeywords[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Word < b.Word
+	})
+	if len(s.TopKeywords) > topKeywords {
+		s.TopKeywords = s.TopKeywords[:topKeywords]
+	}
+	return s
+}
+
+// FindGaps returns the silences between consecutive entries lasting at least minGap
+// seconds, in order: demos, music interludes or stretches where captions dropped out
+func FindGaps(entries []TranscriptEntry, minGap float64) []Gap {
+	gaps := []Gap{}
+	end := 0.0
+	for i, e := range entries {
+		if i > 0 && e.Start-end >= minGap && e.Start > end {
+			gaps = append(gaps, Gap{Start: end, End: e.Start, Duration: e.Start - end})
+		}
+		// Overlapping entries extend the speech rather than ending it early
+		end = max(end, e.Start+e.Duration)
+	}
+	return gaps
+}
+
+// tokenize splits text into lowercase words, keeping inner apostrophes ("don't")
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\'' && r != '’'
+	})
+
+	words := fields[:0]
+	for _, f := range fields {
+		f = strings.Trim(strings.ReplaceAll(f, "’", "'"), "'")
+		if f != "" {
+			words = append(words, f)
+		}
+	}
+	return words
+}
@@ -0,0 +1,65 @@
+package transcript
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "Go channels are great.", Start: 0, Duration: 10},
+		{Text: "Channels, channels: don't block!", Start: 12, Duration: 8},
+		{Text: "[Music]", Start: 35, Duration: 25},
+	}
+
+	s := ComputeStats(entries)
+	if s.Duration != 60 || s.Entries != 3 {
+		t.Errorf("Duration, Entries = %v, %d; want 60, 3", s.Duration, s.Entries)
+	}
+	if s.Words != 9 || s.UniqueWords != 7 {
+		t.Errorf("Words, UniqueWords = %d, %d; want 9, 7", s.Words, s.UniqueWords)
+	}
+	if math.Abs(s.WordsPerMinute-9) > 1e-9 {
+		t.Errorf("WordsPerMinute = %v; want 9", s.WordsPerMinute)
+	}
+	if s.LongestSilence != (Gap{Start: 20, End: 35, Duration: 15}) {
+		t.Errorf("LongestSilence = %+v; want 15s at 20", s.LongestSilence)
+	}
+	if len(s.TopKeywords) == 0 || s.TopKeywords[0] != (WordCount{Word: "channels", Count: 3}) {
+		t.Errorf("TopKeywords = %+v; want channels first", s.TopKeywords)
+	}
+	for _, kw := range s.TopKeywords {
+		if kw.Word == "are" || kw.Word == "music" || kw.Word == "go" {
+			t.Errorf("TopKeywords contains stop word or short word %q", kw.Word)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("Don’t stop — it's 2024, 'quoted'")
+	want := []string{"don't", "stop", "it's", "2024", "quoted"}
+	if len(got) != len(want) {
+		t.Fatalf("tokenize() = %q; want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("tokenize()[%d] = %q; want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFindGaps(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "a", Start: 0, Duration: 5},
+		{Text: "b", Start: 3, Duration: 10},
+		{Text: "c", Start: 14, Duration: 1},
+		{Text: "d", Start: 45, Duration: 2},
+	}
+	gaps := FindGaps(entries, 5)
+	if len(gaps) != 1 || gaps[0] != (Gap{Start: 15, End: 45, Duration: 30}) {
+		t.Errorf("FindGaps() = %+v; want the 30s gap at 15", gaps)
+	}
+	if gaps := FindGaps(entries, 0.5); len(gaps) != 2 {
+		t.Errorf("FindGaps(0.5) = %+v; want 2 gaps", gaps)
+	}
+}
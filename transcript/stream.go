@@ -0,0 +1,146 @@
+package transcript
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+)
+
+// StreamTranscript fetches a transcript and calls fn for each entry as soon as it is decoded,
+// without buffering the whole transcript. Language selection follows GetTranscriptWithLanguage,
+// or GetTranscript when languageCode is empty. Returning an error from fn stops the stream
+// and that error is returned; a track without cues yields ErrEmptyTranscript.
+func (c *Client) StreamTranscript(videoID string, languageCode string, fn func(TranscriptEntry) error) error {
+	return c.StreamTranscriptContext(context.Background(), videoID, languageCode, fn)
+}
+
+// StreamTranscriptContext is StreamTranscript with the watch page fetch and the caption
+// download bound to ctx, so a server can stop streaming when its client goes away
+func (c *Client) StreamTranscriptContext(ctx context.Context, videoID string, languageCode string, fn func(TranscriptEntry) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var sel LanguageSelection
+	if languageCode != "" {
+		sel.Languages = []string{languageCode}
+	}
+	t, err := c.findTranscriptMatchingContext(ctx, videoID, sel)
+	if err != nil {
+		return err
+	}
+
+	if c.backend != nil {
+		entries, err := c.fetchTranscriptContext(ctx, t)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.streamTrack(ctx, t, fn)
+}
+
+// Encode downloads the caption track t and writes it to w in the given format, passing each
+// cue to the formatter as soon as it is decoded, so the transcript is never held in memory
+// as a whole
+func (c *Client) Encode(w io.Writer, format Format, t Transcript) error {
+	enc, err := newEntryEncoder(w, format, nil)
+	if err != nil {
+		return err
+	}
+	defer enc.release()
+	if c.backend != nil {
+		entries, err := c.fetchTranscript(t)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := enc.encode(e); err != nil {
+				return err
+			}
+		}
+		return enc.close()
+	}
+	if err := c.streamTrack(context.Background(), t, enc.encode); err != nil {
+		return err
+	}
+	return enc.close()
+}
+
+// streamTrack downloads the timedtext XML of t with the request bound to ctx and calls fn
+// for each decoded cue
+func (c *Client) streamTrack(ctx context.Context, t Transcript, fn func(TranscriptEntry) error) error {
+	resp, err := c.getContext(ctx, t.BaseURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return ErrTooManyRequests{VideoID: videoIDFromURL(t.BaseURL)}
+	}
+
+	n := 0
+	err = DecodeTranscriptXML(resp.Body, func(e TranscriptEntry) error {
+		n++
+		return fn(e)
+	})
+	if err == nil && n == 0 {
+		return ErrEmptyTranscript{VideoID: videoIDFromURL(t.BaseURL), Language: t.LanguageCode}
+	}
+	return err
+}
+
+// DecodeTranscriptXML incrementally decodes timedtext XML, calling fn for every <text> cue
+func DecodeTranscriptXML(r io.Reader, fn func(TranscriptEntry) error) error {
+	dec := xml.NewDecoder(r)
+	seenRoot := false
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			if !seenRoot {
+				return fmt.Errorf("transcript XML has no <transcript> element")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "transcript":
+			seenRoot = true
+		case "text":
+			var text struct {
+				Start float64 `xml:"start,attr"`
+				Dur   float64 `xml:"dur,attr"`
+				Text  string  `xml:",chardata"`
+			}
+			if err := dec.DecodeElement(&text, &start); err != nil {
+				return err
+			}
+			entry := TranscriptEntry{
+				Text:     html.UnescapeString(text.Text), // Decode HTML entities
+				Start:    text.Start,
+				Duration: text.Dur,
+			}
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package transcript
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStreamTranscript(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+
+	var texts []string
+	err := client.StreamTranscript("abcdefghijk", "de", func(e TranscriptEntry) error {
+		texts = append(texts, e.Text)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamTranscript() error = %v", err)
+	}
+	if strings.Join(texts, "|") != "Hello & welcome|to the show" {
+		t.Errorf("streamed %q", texts)
+	}
+}
+
+func TestStreamTranscript_StopsOnCallbackError(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+	stop := errors.New("stop")
+
+	calls := 0
+	err := client.StreamTranscript("abcdefghijk", "", func(e TranscriptEntry) error {
+		calls++
+		return stop
+	})
+	if err != stop {
+		t.Errorf("StreamTranscript() error = %v; want %v", err, stop)
+	}
+	if calls != 1 {
+		t.Errorf("callback called %d times; want 1", calls)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+	track, err := client.FindTranscript("abcdefghijk", "de")
+	if err != nil {
+		t.Fatalf("FindTranscript() error = %v", err)
+	}
+
+	var sb strings.Builder
+	if err := client.Encode(&sb, FormatSRT, track); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	entries, _ := ParseTranscriptXML(strings.NewReader(sampleTranscriptXML))
+	want, _ := FormatEntries(FormatSRT, entries)
+	if sb.String() != want {
+		t.Errorf("Encode() wrote %q; want %q", sb.String(), want)
+	}
+}
+
+func TestEncode_WriteError(t *testing.T) {
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		cue := `<text start="0" dur="1">` + strings.Repeat("long cue ", 1000) + `</text>`
+		return textResponse(r, `<transcript>`+cue+`</transcript>`), nil
+	})))
+
+	track := Transcript{LanguageCode: "en", BaseURL: "https://www.youtube.com/api/timedtext?v=abcdefghijk&lang=en"}
+	if err := client.Encode(failingWriter{}, FormatText, track); err == nil {
+		t.Fatal("Encode() to a failing writer error = nil; want an error")
+	}
+	if got, err := FormatEntries(FormatText, []TranscriptEntry{{Text: "fine"}}); err != nil || got != "fine" {
+		t.Errorf("FormatEntries() after a failed Encode = %q, %v; want fine", got, err)
+	}
+}
+
+func TestDecodeTranscriptXML_Invalid(t *testing.T) {
+	err := DecodeTranscriptXML(strings.NewReader("<html>not a transcript</html>"), func(TranscriptEntry) error { return nil })
+	if err == nil {
+		t.Error("DecodeTranscriptXML() expected error for non-transcript XML")
+	}
+}
+
+func TestEmptyTranscript(t *testing.T) {
+	fake := fakeYouTube(t)
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/api/timedtext" {
+			return textResponse(r, `<?xml version="1.0" encoding="utf-8" ?><transcript></transcript>`), nil
+		}
+		return fake(r)
+	})))
+
+	want := ErrEmptyTranscript{VideoID: "abcdefghijk", Language: "de"}
+	if _, err := client.GetTranscriptWithLanguage("abcdefghijk", "de"); err != want {
+		t.Errorf("GetTranscriptWithLanguage() error = %v; want %v", err, want)
+	}
+	err := client.StreamTranscript("abcdefghijk", "de", func(TranscriptEntry) error { return nil })
+	if err != want {
+		t.Errorf("StreamTranscript() error = %v; want %v", err, want)
+	}
+}
@@ -0,0 +1,44 @@
+package transcript
+
+import (
+	"fmt"
+)
+
+// Translator translates text between languages, e.g. through a machine translation service.
+// Implementations must return exactly one translation per input, in the same order.
+type Translator interface {
+	Translate(texts []string, source, target string) ([]string, error)
+}
+
+// translateBatchSize bounds the number of texts sent to a Translator in one call
+const translateBatchSize = 50
+
+// TranslateEntries translates entries from source (empty to let the translator detect it) into
+// target, keeping each entry's start and duration
+func TranslateEntries(tr Translator, entries []TranscriptEntry, source, target string) ([]TranscriptEntry, error) {
+	translated := make([]TranscriptEntry, len(entries))
+	copy(translated, entries)
+
+	for start := 0; start < len(entries); start += translateBatchSize {
+		end := start + translateBatchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		texts := make([]string, 0, end-start)
+		for _, e := range entries[start:end] {
+			texts = append(texts, e.Text)
+		}
+		out, err := tr.Translate(texts, source, target)
+		if err != nil {
+			return nil, fmt.Errorf("error translating transcript: %v", err)
+		}
+		if len(out) != len(texts) {
+			return nil, fmt.Errorf("error translating transcript: got %d translations for %d entries", len(out), len(texts))
+		}
+		for i, text := range out {
+			translated[start+i].Text = text
+		}
+	}
+	return translated, nil
+}
@@ -0,0 +1,52 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// upperTranslator "translates" by upper-casing, recording the batch sizes it receives
+type upperTranslator struct {
+	batches []int
+	short   bool
+}
+
+func (u *upperTranslator) Translate(texts []string, source, target string) ([]string, error) {
+	u.batches = append(u.batches, len(texts))
+	out := make([]string, 0, len(texts))
+	for _, t := range texts {
+		out = append(out, strings.ToUpper(t)+"@"+target)
+	}
+	if u.short {
+		out = out[1:]
+	}
+	return out, nil
+}
+
+func TestTranslateEntries(t *testing.T) {
+	var entries []TranscriptEntry
+	for i := 0; i < translateBatchSize+5; i++ {
+		entries = append(entries, TranscriptEntry{Text: fmt.Sprintf("line %d", i), Start: float64(i), Duration: 1.5})
+	}
+
+	tr := &upperTranslator{}
+	got, err := TranslateEntries(tr, entries, "en", "de")
+	if err != nil {
+		t.Fatalf("TranslateEntries() error = %v", err)
+	}
+	if len(tr.batches) != 2 || tr.batches[0] != translateBatchSize || tr.batches[1] != 5 {
+		t.Errorf("batches = %v; want [%d 5]", tr.batches, translateBatchSize)
+	}
+	want := TranscriptEntry{Text: "LINE 52@de", Start: 52, Duration: 1.5}
+	if got[52] != want {
+		t.Errorf("entry 52 = %+v; want %+v", got[52], want)
+	}
+	if entries[52].Text != "line 52" {
+		t.Errorf("input entries were modified: %+v", entries[52])
+	}
+
+	if _, err := TranslateEntries(&upperTranslator{short: true}, entries[:3], "", "de"); err == nil {
+		t.Error("TranslateEntries() with missing translations expected error")
+	}
+}
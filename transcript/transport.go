@@ -0,0 +1,78 @@
+package transcript
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Logger is the minimal logging interface used by the client. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// nopLogger discards everything, so the package has no logging side effects by default
+type nopLogger struct{}
+
+func (nopLogger) Printf(string, ...interface{}) {}
+
+// WithLogger sets the logger used for diagnostic messages.
+// Options are applied in order, so pass it before options that may log.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		if logger == nil {
+			logger = nopLogger{}
+		}
+		c.logger = logger
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeaders adds headers to every request to YouTube, such as X-Forwarded-For or Cookie.
+// Other hosts, such as SponsorBlock and the Google OAuth and Data API endpoints, never see
+// them. Several calls add up. Headers the client or the request sets itself, like
+// Accept-Encoding, Authorization and the User-Agent of WithUserAgent, take precedence.
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		for name, value := range headers {
+			c.headers.Set(name, value)
+		}
+	}
+}
+
+// youTubeHosts are the domains WithHeaders headers are sent to, with their subdomains
+var youTubeHosts = []string{"youtube.com", "googlevideo.com"}
+
+// isYouTubeHost reports whether host is one of youTubeHosts or a subdomain of one
+func isYouTubeHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, domain := range youTubeHosts {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithInterfaceLanguage sets the YouTube interface language, sent as the hl parameter and
+// Accept-Language header, so the Language names of tracks come back in it. Use "en" for
+// names that stay the same wherever the client runs.
+func WithInterfaceLanguage(hl string) ClientOption {
+	return func(c *Client) {
+		c.hl = hl
+	}
+}
+
+// WithTransport replaces the RoundTripper used for all requests.
+// This allows environments without a native network stack, such as GOOS=js
+// or serverless workers, to route requests through their own fetch implementation.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
@@ -0,0 +1,241 @@
+package transcript
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// textResponse builds a 200 response carrying body
+func textResponse(r *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}
+}
+
+const sampleWatchPage = `<html><script>var ytInitialPlayerResponse = {"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?v=abcdefghijk&lang=de","name":{"simpleText":"German"},"languageCode":"de"},` +
+	`{"baseUrl":"https://www.youtube.com/api/timedtext?v=abcdefghijk&lang=en","name":{"simpleText":"English (auto-generated)"},"languageCode":"en","kind":"asr","isTranslatable":true}` +
+	`]}}};</script></html>`
+
+// fakeYouTube serves sampleWatchPage for watch requests and sampleTranscriptXML for caption requests
+func fakeYouTube(t *testing.T) roundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/watch":
+			return textResponse(r, sampleWatchPage), nil
+		case "/api/timedtext":
+			return textResponse(r, sampleTranscriptXML), nil
+		}
+		t.Errorf("unexpected request to %s", r.URL)
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	var requested []string
+	fake := fakeYouTube(t)
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.String())
+		return fake(r)
+	})))
+
+	entries, err := client.GetTranscript("abcdefghijk")
+	if err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries; want 2", len(entries))
+	}
+
+	want := []string{
+		"https://www.youtube.com/watch?v=abcdefghijk",
+		"https://www.youtube.com/api/timedtext?v=abcdefghijk&lang=en",
+	}
+	if strings.Join(requested, " ") != strings.Join(want, " ") {
+		t.Errorf("requested %v; want %v", requested, want)
+	}
+}
+
+func TestParseCaptionTracks(t *testing.T) {
+	tracks, err := ParseCaptionTracks(sampleWatchPage)
+	if err != nil {
+		t.Fatalf("ParseCaptionTracks() error = %v", err)
+	}
+	if len(tracks) != 2 {
+		t.Fatalf("got %d tracks; want 2", len(tracks))
+	}
+	if tracks[0].LanguageCode != "de" || tracks[0].IsGenerated {
+		t.Errorf("tracks[0] = %+v; want manual German track", tracks[0])
+	}
+	if tracks[1].LanguageCode != "en" || !tracks[1].IsGenerated || !tracks[1].IsTranslatable {
+		t.Errorf("tracks[1] = %+v; want generated, translatable English track", tracks[1])
+	}
+}
+
+// recordingLogger keeps every line logged through it
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	NewClient(WithLogger(logger), WithProxy("://bad proxy"))
+	if len(logger.lines) != 1 {
+		t.Errorf("got %d log lines; want 1", len(logger.lines))
+	}
+}
+
+func TestWithLogger_Requests(t *testing.T) {
+	logger := &recordingLogger{}
+	client := NewClient(WithLogger(logger), WithTransport(fakeYouTube(t)))
+	if _, err := client.GetTranscript("abcdefghijk"); err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+	// One line for the watch page and one for the caption track
+	if len(logger.lines) != 2 {
+		t.Errorf("got %d log lines; want 2", len(logger.lines))
+	}
+}
+
+func TestErrTooManyRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		limited string
+	}{
+		{name: "Watch page", limited: "/watch"},
+		{name: "Caption track", limited: "/api/timedtext"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakeYouTube(t)
+			client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				if r.URL.Path == tt.limited {
+					return &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+				}
+				return fake(r)
+			})))
+
+			_, err := client.GetTranscript("abcdefghijk")
+			var limited ErrTooManyRequests
+			if !errors.As(err, &limited) || limited.VideoID != "abcdefghijk" {
+				t.Errorf("GetTranscript() error = %v; want ErrTooManyRequests for abcdefghijk", err)
+			}
+		})
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	fake := fakeYouTube(t)
+	var requests []*http.Request
+	client := NewClient(
+		WithHeaders(map[string]string{"X-Forwarded-For": "203.0.113.7", "User-Agent": "ignored"}),
+		WithHeaders(map[string]string{"Cookie": "CONSENT=YES+1"}),
+		WithUserAgent("yt-words-test/1.0"),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			requests = append(requests, r)
+			return fake(r)
+		})),
+	)
+
+	if _, err := client.GetTranscript("abcdefghijk"); err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+	for _, r := range requests {
+		if got := r.Header.Get("X-Forwarded-For"); got != "203.0.113.7" {
+			t.Errorf("X-Forwarded-For = %q; want 203.0.113.7", got)
+		}
+		if got := r.Header.Get("Cookie"); got != "CONSENT=YES+1" {
+			t.Errorf("Cookie = %q; want CONSENT=YES+1", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "yt-words-test/1.0" {
+			t.Errorf("User-Agent = %q; want yt-words-test/1.0", got)
+		}
+	}
+}
+
+func TestWithHeaders_OnlyYouTube(t *testing.T) {
+	var requests []*http.Request
+	client := NewClient(
+		WithHeaders(map[string]string{"Cookie": "SID=secret", "Authorization": "Basic ignored"}),
+		WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			requests = append(requests, r)
+			return textResponse(r, "[]"), nil
+		})),
+	)
+
+	if _, err := client.GetSponsorSegments("abcdefghijk"); err != nil {
+		t.Fatalf("GetSponsorSegments() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://www.youtube.com/youtubei/v1/browse", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := client.send(req)
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if got := requests[0].Header.Get("Cookie"); got != "" {
+		t.Errorf("SponsorBlock request Cookie = %q; want none", got)
+	}
+	if got := requests[1].Header.Get("Cookie"); got != "SID=secret" {
+		t.Errorf("YouTube request Cookie = %q; want SID=secret", got)
+	}
+	if got := requests[1].Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization = %q; want the request's own", got)
+	}
+}
+
+func TestWithInterfaceLanguage(t *testing.T) {
+	fake := fakeYouTube(t)
+	var hl, acceptLanguage string
+	client := NewClient(WithInterfaceLanguage("en"), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/watch" {
+			hl, acceptLanguage = r.URL.Query().Get("hl"), r.Header.Get("Accept-Language")
+		}
+		return fake(r)
+	})))
+
+	if _, err := client.ListAvailableTranscripts("abcdefghijk"); err != nil {
+		t.Fatalf("ListAvailableTranscripts() error = %v", err)
+	}
+	if hl != "en" || acceptLanguage != "en" {
+		t.Errorf("hl = %q, Accept-Language = %q; want en for both", hl, acceptLanguage)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var agents []string
+	fake := fakeYouTube(t)
+	client := NewClient(WithUserAgent("yt-words-test/1.0"), WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		agents = append(agents, r.Header.Get("User-Agent"))
+		return fake(r)
+	})))
+
+	if _, err := client.GetTranscript("abcdefghijk"); err != nil {
+		t.Fatalf("GetTranscript() error = %v", err)
+	}
+	for _, ua := range agents {
+		if ua != "yt-words-test/1.0" {
+			t.Errorf("User-Agent = %q; want yt-words-test/1.0", ua)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package transcript
+
+import "strings"
+
+// Defaults of NewSpeakerTurns
+const (
+	DefaultTurnPause    = 2.0
+	DefaultTurnMaxChars = 1200
+)
+
+// asrSpeakerMarker is what auto-generated captions insert where the speaker changes
+const asrSpeakerMarker = ">>"
+
+// SpeakerTurns groups entries into paragraphs, one entry each, for interviews and podcasts.
+// A paragraph ends where the captions mark a speaker change with ">>", after a sentence
+// followed by a pause of at least Pause seconds, and at the first sentence end past
+// MaxChars, or anywhere past twice MaxChars for captions without punctuation. It is a
+// Processor.
+type SpeakerTurns struct {
+	Pause    float64
+	MaxChars int
+	// SpeakerPrefix starts every paragraph opened by a speaker change, in place of ">>"
+	SpeakerPrefix string
+}
+
+// NewSpeakerTurns returns SpeakerTurns with the default pause and length, marking speaker
+// changes with a leading "- "
+func NewSpeakerTurns() SpeakerTurns {
+	return SpeakerTurns{Pause: DefaultTurnPause, MaxChars: DefaultTurnMaxChars, SpeakerPrefix: "- "}
+}
+
+// Process merges entries into paragraphs
+func (s SpeakerTurns) Process(entries []TranscriptEntry) ([]TranscriptEntry, error) {
+	var (
+		out     []TranscriptEntry
+		current TranscriptEntry
+		end     float64
+		open    bool
+		// pending carries a speaker change whose words start in a later entry
+		pending bool
+	)
+	flush := func() {
+		if open {
+			current.Duration = end - current.Start
+			out = append(out, current)
+			open = false
+		}
+	}
+	add := func(text string, start, stop float64, newSpeaker bool) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			pending = pending || newSpeaker
+			return
+		}
+		newSpeaker, pending = newSpeaker || pending, false
+		if newSpeaker || (open && s.Pause > 0 && start-end >= s.Pause && endsSentence(current.Text)) {
+			flush()
+		}
+		if !open {
+			if newSpeaker {
+				text = s.SpeakerPrefix + text
+			}
+			current = TranscriptEntry{Text: text, Start: start}
+			open = true
+		} else {
+			current.Text += " " + text
+		}
+		end = stop
+		if n := len(current.Text); s.MaxChars > 0 && ((n >= s.MaxChars && endsSentence(text)) || n >= 2*s.MaxChars) {
+			flush()
+		}
+	}
+
+	for _, e := range entries {
+		parts := strings.Split(e.Text, asrSpeakerMarker)
+		// Parts share the entry's time in proportion to their length
+		total := max(len(e.Text)-len(asrSpeakerMarker)*(len(parts)-1), 1)
+		start := e.Start
+		for i, part := range parts {
+			stop := start + e.Duration*float64(len(part))/float64(total)
+			if i == len(parts)-1 {
+				stop = e.Start + e.Duration
+			}
+			add(part, start, stop, i > 0)
+			start = stop
+		}
+	}
+	flush()
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package transcript
+
+import "testing"
+
+func TestSpeakerTurns(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "So tell me how it started.", Start: 0, Duration: 2},
+		{Text: ">> Well, it was", Start: 2, Duration: 1},
+		{Text: "a long time ago.", Start: 3, Duration: 1},
+		{Text: "We had no money.", Start: 7, Duration: 1},
+		{Text: "none at all >>", Start: 8, Duration: 1},
+		{Text: "Wow.", Start: 9, Duration: 1},
+	}
+
+	got, err := NewSpeakerTurns().Process(entries)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	want := []TranscriptEntry{
+		{Text: "So tell me how it started.", Start: 0, Duration: 2},
+		{Text: "- Well, it was a long time ago.", Start: 2, Duration: 2},
+		{Text: "We had no money. none at all", Start: 7, Duration: 2},
+		{Text: "- Wow.", Start: 9, Duration: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Process() = %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Text != want[i].Text || got[i].Start != want[i].Start || got[i].Duration != want[i].Duration {
+			t.Errorf("paragraph %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSpeakerTurns_SplitsEntryAtMarker(t *testing.T) {
+	got, _ := SpeakerTurns{}.Process([]TranscriptEntry{{Text: "yes >> no", Start: 10, Duration: 4}})
+	if len(got) != 2 || got[0].Text != "yes" || got[1].Text != "no" {
+		t.Fatalf("Process() = %+v; want two turns", got)
+	}
+	if got[1].Start <= 10 || got[1].Start+got[1].Duration != 14 {
+		t.Errorf("second turn at %v for %v; want it to start within the entry and end with it", got[1].Start, got[1].Duration)
+	}
+}
+
+func TestSpeakerTurns_Unpunctuated(t *testing.T) {
+	var entries []TranscriptEntry
+	for i := 0; i < 10; i++ {
+		entries = append(entries, TranscriptEntry{Text: "so we kept going and", Start: float64(i), Duration: 1})
+	}
+	got, _ := SpeakerTurns{MaxChars: 40}.Process(entries)
+	if len(got) < 2 {
+		t.Errorf("Process() of unpunctuated captions = %d paragraphs; want them split past twice MaxChars", len(got))
+	}
+}
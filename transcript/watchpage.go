@@ -0,0 +1,61 @@
+package transcript
+
+import "context"
+
+// WatchPage is the fetched watch page of a video. Its metadata, chapters and caption tracks
+// are read from the one page, so callers needing several of them make a single request.
+type WatchPage struct {
+	VideoID string
+	HTML    string
+
+	c *Client
+}
+
+// GetWatchPage fetches the watch page of a video
+func (c *Client) GetWatchPage(videoID string) (*WatchPage, error) {
+	return c.GetWatchPageContext(context.Background(), videoID)
+}
+
+// GetWatchPageContext is GetWatchPage with the request bound to ctx
+func (c *Client) GetWatchPageContext(ctx context.Context, videoID string) (*WatchPage, error) {
+	videoInfo, err := c.fetchVideoInfoContext(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return &WatchPage{VideoID: videoID, HTML: videoInfo, c: c}, nil
+}
+
+// Metadata parses the title, channel and other details of the video
+func (p *WatchPage) Metadata() (VideoMetadata, error) {
+	return ParseVideoMetadata(p.HTML)
+}
+
+// Chapters parses the chapters listed in the video description
+func (p *WatchPage) Chapters() ([]Chapter, error) {
+	md, err := p.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	return ParseChapters(md.Description), nil
+}
+
+// Transcripts lists the caption tracks of the video like ListAvailableTranscripts, through
+// the client's backend if it has one
+func (p *WatchPage) Transcripts() ([]Transcript, error) {
+	if p.c != nil && p.c.backend != nil {
+		return p.c.backend.ListTranscripts(context.Background(), p.VideoID)
+	}
+	if err := checkPlayability(p.VideoID, p.HTML); err != nil {
+		return nil, err
+	}
+	return extractTranscriptData(p.VideoID, p.HTML)
+}
+
+// FindTranscript selects a caption track of the video according to sel like FindTranscriptMatching
+func (p *WatchPage) FindTranscript(sel LanguageSelection) (Transcript, error) {
+	transcripts, err := p.Transcripts()
+	if err != nil {
+		return Transcript{}, err
+	}
+	return chooseTranscript(p.VideoID, transcripts, sel)
+}
@@ -0,0 +1,48 @@
+package transcript
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetWatchPage(t *testing.T) {
+	page := strings.Replace(sampleWatchPage, `{"captions"`, `{"videoDetails":{"videoId":"abcdefghijk","title":"Talk",`+
+		`"shortDescription":"0:00 Intro\n1:00 Middle\n2:00 End"},"captions"`, 1)
+	requests := 0
+	client := NewClient(WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return textResponse(r, page), nil
+	})))
+
+	p, err := client.GetWatchPage("abcdefghijk")
+	if err != nil {
+		t.Fatalf("GetWatchPage() error = %v", err)
+	}
+	md, err := p.Metadata()
+	if err != nil || md.Title != "Talk" {
+		t.Errorf("Metadata() = %+v, %v; want the title Talk", md, err)
+	}
+	chapters, err := p.Chapters()
+	if err != nil || len(chapters) != 3 {
+		t.Errorf("Chapters() = %+v, %v; want 3 chapters", chapters, err)
+	}
+	transcripts, err := p.Transcripts()
+	if err != nil || len(transcripts) != 2 {
+		t.Errorf("Transcripts() = %+v, %v; want 2 tracks", transcripts, err)
+	}
+	track, err := p.FindTranscript(LanguageSelection{Languages: []string{"de"}})
+	if err != nil || track.LanguageCode != "de" {
+		t.Errorf("FindTranscript(de) = %+v, %v; want the de track", track, err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests; want 1", requests)
+	}
+}
+
+func TestGetWatchPage_Unavailable(t *testing.T) {
+	client := NewClient(WithTransport(fakeYouTube(t)))
+	if _, err := client.GetWatchPage(" "); err == nil {
+		t.Error("GetWatchPage() of an empty video ID error = nil; want an error")
+	}
+}
@@ -0,0 +1,71 @@
+package transcript
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"unicode"
+)
+
+// WordFrequencies counts the words of a transcript for word clouds, most frequent first.
+// Annotations like [Music] are ignored, words are lowercased, and stop words, single
+// letters and numbers are left out.
+func WordFrequencies(entries []TranscriptEntry) []WordCount {
+	counts := make(map[string]int)
+	for _, e := range StripAnnotations(entries) {
+		for _, w := range tokenize(e.Text) {
+			if len([]rune(w)) < 2 || stopWords[w] || isNumber(w) {
+				continue
+			}
+			counts[w]++
+		}
+	}
+
+	freqs := make([]WordCount, 0, len(counts))
+	for w, n := range counts {
+		freqs = append(freqs, WordCount{Word: w, Count: n})
+	}
+	sort.Slice(freqs, func(i, j int) bool {
+		if freqs[i].Count != freqs[j].Count {
+			return freqs[i].Count > freqs[j].Count
+		}
+		return freqs[i].Word < freqs[j].Word
+	})
+	return freqs
+}
+
+// WriteWordFrequencies writes counts as term,count CSV with a header row, or as a JSON array
+// of {"word","count"} objects
+func WriteWordFrequencies(w io.Writer, format Format, counts []WordCount) error {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"term", "count"})
+		for _, c := range counts {
+			cw.Write([]string{c.Word, strconv.Itoa(c.Count)})
+		}
+		cw.Flush()
+		return cw.Error()
+	case FormatJSON:
+		if counts == nil {
+			counts = []WordCount{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return enc.Encode(counts)
+	}
+	return fmt.Errorf("word frequencies can be written as csv or json, not %s", format)
+}
+
+// isNumber reports whether w consists of digits only
+func isNumber(w string) bool {
+	for _, r := range w {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package transcript
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordFrequencies(t *testing.T) {
+	entries := []TranscriptEntry{
+		{Text: "[Music] Go is fun, and Go is fast."},
+		{Text: "I wrote 20 Go programs; fun!"},
+	}
+	got := WordFrequencies(entries)
+	want := []WordCount{{"go", 3}, {"fun", 2}, {"is", 2}, {"fast", 1}, {"programs", 1}, {"wrote", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WordFrequencies() = %v; want %v", got, want)
+	}
+}
+
+func TestWriteWordFrequencies(t *testing.T) {
+	counts := []WordCount{{"go", 3}, {"fun", 2}}
+
+	var csvOut strings.Builder
+	if err := WriteWordFrequencies(&csvOut, FormatCSV, counts); err != nil {
+		t.Fatalf("WriteWordFrequencies(csv) error = %v", err)
+	}
+	if want := "term,count\ngo,3\nfun,2\n"; csvOut.String() != want {
+		t.Errorf("WriteWordFrequencies(csv) = %q; want %q", csvOut.String(), want)
+	}
+
+	var jsonOut strings.Builder
+	if err := WriteWordFrequencies(&jsonOut, FormatJSON, counts); err != nil {
+		t.Fatalf("WriteWordFrequencies(json) error = %v", err)
+	}
+	if want := `[{"word":"go","count":3},{"word":"fun","count":2}]` + "\n"; jsonOut.String() != want {
+		t.Errorf("WriteWordFrequencies(json) = %q; want %q", jsonOut.String(), want)
+	}
+
+	if err := WriteWordFrequencies(&jsonOut, FormatSRT, counts); err == nil {
+		t.Error("WriteWordFrequencies(srt) error = nil")
+	}
+}
@@ -1,21 +1,46 @@
 package transcript
 
 import (
+	"context"
 	"encoding/json"
-	"encoding/xml"
+	"errors"
 	"fmt"
-	"html"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Error types
+
+// Playability holds the playabilityStatus of a watch page, for errors decided by it. Status is
+// YouTube's code, like UNPLAYABLE or LOGIN_REQUIRED, and Reason its message to viewers; both
+// are empty when the error did not come from a watch page.
+type Playability struct {
+	Status string
+	Reason string
+}
+
+// PlayabilityStatus returns YouTube's playability code
+func (p Playability) PlayabilityStatus() string { return p.Status }
+
+// PlayabilityReason returns the reason YouTube gives viewers
+func (p Playability) PlayabilityReason() string { return p.Reason }
+
+// PlayabilityError is implemented by the errors carrying a Playability, so failures can be
+// aggregated by YouTube's own reason with errors.As
+type PlayabilityError interface {
+	error
+	PlayabilityStatus() string
+	PlayabilityReason() string
+}
+
 type ErrVideoUnavailable struct {
 	VideoID string
+	Playability
 }
 
 func (e ErrVideoUnavailable) Error() string {
@@ -23,24 +48,142 @@ func (e ErrVideoUnavailable) Error() string {
 }
 
 type ErrNoTranscriptFound struct {
-	VideoID string
+	VideoID  string
+	Language string // Requested language code, if any
 }
 
 func (e ErrNoTranscriptFound) Error() string {
+	if e.Language != "" {
+		return fmt.Sprintf("No transcript found for video %s in language %s", e.VideoID, e.Language)
+	}
 	return fmt.Sprintf("No transcript found for video %s", e.VideoID)
 }
 
+// ErrEmptyTranscript is returned when a caption track exists but holds no cues
+type ErrEmptyTranscript struct {
+	VideoID  string
+	Language string // Language code of the track
+}
+
+func (e ErrEmptyTranscript) Error() string {
+	return fmt.Sprintf("Transcript of video %s in language %s is empty", e.VideoID, e.Language)
+}
+
 type ErrTranscriptsDisabled struct {
 	VideoID string
+	Playability
 }
 
 func (e ErrTranscriptsDisabled) Error() string {
 	return fmt.Sprintf("Transcripts are disabled for video %s", e.VideoID)
 }
 
+// ErrMembersOnly is returned for videos reserved to channel members, when the client has no
+// cookies of a member account (see WithCookies)
+type ErrMembersOnly struct {
+	VideoID string
+	Playability
+}
+
+func (e ErrMembersOnly) Error() string {
+	return fmt.Sprintf("Video %s is available to channel members only", e.VideoID)
+}
+
+// ErrRegionBlocked is returned for videos not available in the country requests come from.
+// Retrying through a proxy in one of AvailableCountries may succeed.
+type ErrRegionBlocked struct {
+	VideoID string
+	// Region is the ISO 3166 code of the country YouTube located the client in, if known
+	Region string
+	// AvailableCountries lists the countries the video is available in, if the page lists them
+	AvailableCountries []string
+	Playability
+}
+
+func (e ErrRegionBlocked) Error() string {
+	if e.Region == "" {
+		return fmt.Sprintf("Video %s is not available in your country", e.VideoID)
+	}
+	return fmt.Sprintf("Video %s is not available in %s", e.VideoID, e.Region)
+}
+
+// ErrNotYetAvailable is returned for upcoming live streams and premieres, which have no
+// captions before they start
+type ErrNotYetAvailable struct {
+	VideoID string
+	// ScheduledStart is the announced start time, or zero if YouTube gives none
+	ScheduledStart time.Time
+	Playability
+}
+
+func (e ErrNotYetAvailable) Error() string {
+	if e.ScheduledStart.IsZero() {
+		return fmt.Sprintf("Video %s has not started yet", e.VideoID)
+	}
+	return fmt.Sprintf("Video %s has not started yet; it is scheduled for %s", e.VideoID, e.ScheduledStart.UTC().Format(time.RFC3339))
+}
+
+// ErrCircuitOpen is returned without contacting YouTube while the circuit breaker of
+// WithCircuitBreaker is open
+type ErrCircuitOpen struct {
+	Until time.Time
+}
+
+func (e ErrCircuitOpen) Error() string {
+	return fmt.Sprintf("Too many consecutive failed requests to YouTube; not sending more until %s", e.Until.Format(time.TimeOnly))
+}
+
+// ErrBudgetExceeded is returned without contacting YouTube once the watch-page fetches of
+// WithRequestBudget are used up
+type ErrBudgetExceeded struct {
+	Max int
+	Per time.Duration
+	// ResetAt is when the next fetch fits in the budget again
+	ResetAt time.Time
+}
+
+func (e ErrBudgetExceeded) Error() string {
+	return fmt.Sprintf("Request budget of %d watch pages per %s used up until %s", e.Max, e.Per, e.ResetAt.Format(time.TimeOnly))
+}
+
+// ErrTooManyRequests is returned when YouTube answers with HTTP 429
+type ErrTooManyRequests struct {
+	VideoID string
+}
+
+func (e ErrTooManyRequests) Error() string {
+	return fmt.Sprintf("Too many requests to YouTube while fetching video %s", e.VideoID)
+}
+
+// ErrInvalidVideoID is returned when input contains no recognizable video ID
+type ErrInvalidVideoID struct {
+	Input string
+}
+
+func (e ErrInvalidVideoID) Error() string {
+	return fmt.Sprintf("Invalid YouTube URL or video ID: %s", e.Input)
+}
+
 // Client represents the YouTube Transcript API client
 type Client struct {
 	httpClient *http.Client
+	decoders   map[string]DecoderFunc
+	logger     Logger
+	userAgent  string
+	hl         string
+	headers    http.Header
+	cookies    []*http.Cookie
+	backend    Backend
+	retries    int
+	retryDelay time.Duration
+	// maxRetryAfter bounds the waits of Retry-After headers
+	maxRetryAfter time.Duration
+	limiter       *requestLimiter
+	breaker       *circuitBreaker
+	budgets       []*requestBudget
+	observer      func(FetchEvent)
+	// configErr is the first error of an option, returned by NewClientE and every request
+	configErr error
 }
 
 // Transcript represents a single transcript
@@ -49,19 +192,28 @@ type Transcript struct {
 	LanguageCode string
 	Language     string
 	IsGenerated  bool
+	// IsTranslatable reports whether YouTube can machine-translate the track into other languages
+	IsTranslatable bool
 }
 
 // TranscriptEntry represents a single entry in the transcript
 type TranscriptEntry struct {
-	Text     string
-	Start    float64
-	Duration float64
+	Text     string  `json:"text"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
 }
 
-// NewClient creates a new YouTube Transcript API client
+// NewClient creates a new YouTube Transcript API client. If an option is invalid, such as an
+// unparsable proxy URL, the error is logged and every request of the client returns it; use
+// NewClientE to check the configuration up front.
 func NewClient(options ...ClientOption) *Client {
 	c := &Client{
 		httpClient: &http.Client{},
+		decoders:   defaultDecoders(),
+		logger:     nopLogger{},
+		headers:    make(http.Header),
+
+		maxRetryAfter: DefaultMaxRetryAfter,
 	}
 	for _, opt := range options {
 		opt(c)
@@ -69,15 +221,32 @@ func NewClient(options ...ClientOption) *Client {
 	return c
 }
 
+// NewClientE creates a client like NewClient but returns the first error of its options
+func NewClientE(options ...ClientOption) (*Client, error) {
+	c := NewClient(options...)
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	return c, nil
+}
+
+// optionError records an invalid option; only the first error is kept
+func (c *Client) optionError(err error) {
+	c.logger.Printf("%v", err)
+	if c.configErr == nil {
+		c.configErr = err
+	}
+}
+
 // ClientOption defines a function to configure the Client
 type ClientOption func(*Client)
 
-// WithProxy sets a proxy for the HTTP client
+// WithProxy sets a proxy for the HTTP client. The URL needs an http, https or socks5 scheme and a host.
 func WithProxy(proxyURLStr string) ClientOption {
 	return func(c *Client) {
-		parsedURL, err := url.Parse(proxyURLStr)
+		parsedURL, err := parseProxyURL(proxyURLStr)
 		if err != nil {
-			log.Printf("Error parsing proxy URL: %v", err)
+			c.optionError(err)
 			return
 		}
 		c.httpClient.Transport = &http.Transport{
@@ -88,35 +257,44 @@ func WithProxy(proxyURLStr string) ClientOption {
 
 // GetTranscript fetches the transcript for a given video ID, preferring English if available
 func (c *Client) GetTranscript(videoID string) ([]TranscriptEntry, error) {
-	videoInfo, err := c.fetchVideoInfo(videoID)
-	if err != nil {
-		return nil, err
+	return c.GetTranscriptWithLanguage(videoID, "")
+}
+
+// FindTranscript fetches the track list for a video and selects a track by language code prefix.
+// An empty languageCode prefers English and falls back to the first available track.
+func (c *Client) FindTranscript(videoID string, languageCode string) (Transcript, error) {
+	var sel LanguageSelection
+	if languageCode != "" {
+		sel.Languages = []string{languageCode}
 	}
+	return c.FindTranscriptMatching(videoID, sel)
+}
 
-	transcripts, err := extractTranscriptData(videoInfo)
+// FindTranscriptMatching fetches the track list for a video and selects a track according to sel
+func (c *Client) FindTranscriptMatching(videoID string, sel LanguageSelection) (Transcript, error) {
+	return c.findTranscriptMatchingContext(context.Background(), videoID, sel)
+}
+
+// findTranscriptMatchingContext is FindTranscriptMatching with the watch page fetch bound to ctx
+func (c *Client) findTranscriptMatchingContext(ctx context.Context, videoID string, sel LanguageSelection) (Transcript, error) {
+	transcripts, err := c.ListAvailableTranscriptsContext(ctx, videoID)
 	if err != nil {
-		return nil, err
+		return Transcript{}, err
 	}
+	return chooseTranscript(videoID, transcripts, sel)
+}
 
+// chooseTranscript selects a track of videoID from transcripts according to sel
+func chooseTranscript(videoID string, transcripts []Transcript, sel LanguageSelection) (Transcript, error) {
 	if len(transcripts) == 0 {
-		return nil, ErrNoTranscriptFound{VideoID: videoID}
-	}
-
-	// Try to find English transcript first
-	var selectedTranscript Transcript
-	for _, t := range transcripts {
-		if strings.HasPrefix(t.LanguageCode, "en") { // Matches 'en', 'en-US', 'en-GB', etc.
-			selectedTranscript = t
-			break
-		}
+		return Transcript{}, ErrNoTranscriptFound{VideoID: videoID}
 	}
 
-	// If no English transcript found, fall back to the first available one
-	if selectedTranscript.BaseURL == "" {
-		selectedTranscript = transcripts[0]
+	t, ok := sel.Choose(transcripts)
+	if !ok {
+		return Transcript{}, ErrNoTranscriptFound{VideoID: videoID, Language: strings.Join(sel.Languages, ",")}
 	}
-
-	return c.fetchTranscript(selectedTranscript)
+	return t, nil
 }
 
 // GetTranscriptString fetches the transcript and returns it as a single string
@@ -141,84 +319,95 @@ func ConcatenateTranscript(entries []TranscriptEntry) string {
 }
 
 func (c *Client) fetchVideoInfo(videoID string) (string, error) {
+	return c.fetchVideoInfoContext(context.Background(), videoID)
+}
+
+// fetchVideoInfoContext fetches the watch page of videoID with the request bound to ctx
+func (c *Client) fetchVideoInfoContext(ctx context.Context, videoID string) (string, error) {
+	// Network errors below read as an unavailable video, which would hide a bad configuration
+	if c.configErr != nil {
+		return "", c.configErr
+	}
 	if strings.TrimSpace(videoID) == "" {
 		return "", &ErrVideoUnavailable{VideoID: videoID}
 	}
+	if err := c.spendBudget(); err != nil {
+		return "", err
+	}
 
 	videoURL := fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID)
-	resp, err := c.httpClient.Get(videoURL)
+	if c.hl != "" {
+		videoURL += "&hl=" + url.QueryEscape(c.hl)
+	}
+	resp, err := c.getContext(ctx, videoURL)
+	if errors.As(err, new(ErrCircuitOpen)) {
+		return "", err
+	}
+	// A cancelled fetch says nothing about the video
+	if err != nil && ctx.Err() != nil {
+		return "", ctx.Err()
+	}
 	if err != nil {
 		return "", &ErrVideoUnavailable{VideoID: videoID}
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", ErrTooManyRequests{VideoID: videoID}
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", &ErrVideoUnavailable{VideoID: videoID}
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
+	return readPage(resp.Body)
+}
 
-	return string(body), nil
+// ParseCaptionTracks extracts the available caption tracks from the HTML of a watch page.
+// It performs no network access, so callers that fetch pages themselves can reuse it.
+func ParseCaptionTracks(watchPage string) ([]Transcript, error) {
+	return extractTranscriptData("", watchPage)
 }
 
-func extractTranscriptData(videoInfo string) ([]Transcript, error) {
+// extractTranscriptData parses the caption tracks of videoID's watch page. A playable video
+// without captions data had them disabled by its uploader, while a video whose captions
+// data lists no tracks has none in any language.
+func extractTranscriptData(videoID, videoInfo string) ([]Transcript, error) {
 	startMarker := "\"captions\":"
 	startIndex := strings.Index(videoInfo, startMarker)
 	if startIndex == -1 {
-		// If we can't find captions data, the video is likely unavailable
-		return nil, &ErrVideoUnavailable{VideoID: ""}
-	}
-
-	// Find the opening brace of the JSON object
-	jsonStart := strings.Index(videoInfo[startIndex:], "{")
-	if jsonStart == -1 {
-		return nil, fmt.Errorf("could not find the start of JSON object")
-	}
-	jsonStart += startIndex
-
-	// Find the closing brace of the JSON object
-	braceCount := 1
-	jsonEnd := -1
-	for i := jsonStart + 1; i < len(videoInfo); i++ {
-		if videoInfo[i] == '{' {
-			braceCount++
-		} else if videoInfo[i] == '}' {
-			braceCount--
-			if braceCount == 0 {
-				jsonEnd = i + 1
-				break
-			}
+		p, ok := parsePlayability(videoInfo)
+		if ok && p.Status == "OK" {
+			return nil, ErrTranscriptsDisabled{VideoID: videoID, Playability: p.details()}
 		}
+		// Without playability or captions data, the video is likely unavailable
+		return nil, &ErrVideoUnavailable{VideoID: videoID, Playability: p.details()}
 	}
 
-	if jsonEnd == -1 {
-		return nil, fmt.Errorf("could not find the end of JSON object")
+	captionsJSON, err := extractJSONObject(videoInfo, startIndex)
+	if err != nil {
+		return nil, err
 	}
 
-	captionsJSON := videoInfo[jsonStart:jsonEnd]
-
 	// Check if the extracted JSON is empty or too short
 	if len(captionsJSON) < 10 {
 		return nil, fmt.Errorf("extracted JSON is too short or empty: %s", captionsJSON)
 	}
 
 	var transcriptData map[string]interface{}
-	err := json.Unmarshal([]byte(captionsJSON), &transcriptData)
+	err = json.Unmarshal([]byte(captionsJSON), &transcriptData)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing captions JSON: %v\nJSON: %s", err, captionsJSON)
 	}
 
 	playerCaptionsTracklistRenderer, ok := transcriptData["playerCaptionsTracklistRenderer"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("playerCaptionsTracklistRenderer not found in JSON")
+		p, _ := parsePlayability(videoInfo)
+		return nil, ErrTranscriptsDisabled{VideoID: videoID, Playability: p.details()}
 	}
 
 	captionTracks, ok := playerCaptionsTracklistRenderer["captionTracks"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("captionTracks not found in playerCaptionsTracklistRenderer")
+	if !ok || len(captionTracks) == 0 {
+		return nil, ErrNoTranscriptFound{VideoID: videoID}
 	}
 
 	var transcripts []Transcript
@@ -233,45 +422,126 @@ func extractTranscriptData(videoInfo string) ([]Transcript, error) {
 		name, _ := trackMap["name"].(map[string]interface{})
 		simpleText, _ := name["simpleText"].(string)
 		kind, _ := trackMap["kind"].(string)
+		isTranslatable, _ := trackMap["isTranslatable"].(bool)
 
 		transcripts = append(transcripts, Transcript{
-			BaseURL:      baseURL,
-			LanguageCode: languageCode,
-			Language:     simpleText,
-			IsGenerated:  kind == "asr",
+			BaseURL:        baseURL,
+			LanguageCode:   languageCode,
+			Language:       simpleText,
+			IsGenerated:    kind == "asr",
+			IsTranslatable: isTranslatable,
 		})
 	}
 
 	return transcripts, nil
 }
 
+// extractJSONObject returns the JSON object starting at the first '{' at or after from.
+// Braces inside string literals are ignored.
+func extractJSONObject(s string, from int) (string, error) {
+	// Find the opening brace of the JSON object
+	jsonStart := strings.Index(s[from:], "{")
+	if jsonStart == -1 {
+		return "", fmt.Errorf("could not find the start of JSON object")
+	}
+	jsonStart += from
+
+	// Find the closing brace of the JSON object
+	braceCount := 0
+	inString, escaped := false, false
+	for i := jsonStart; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && ch == '\\':
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '{':
+			braceCount++
+		case ch == '}':
+			braceCount--
+			if braceCount == 0 {
+				return s[jsonStart : i+1], nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not find the end of JSON object")
+}
+
+// FetchTranscript downloads the entries of a track returned by FindTranscript or ListAvailableTranscripts.
+// A track without cues yields ErrEmptyTranscript.
+func (c *Client) FetchTranscript(transcript Transcript) ([]TranscriptEntry, error) {
+	return c.fetchTranscript(transcript)
+}
+
 func (c *Client) fetchTranscript(transcript Transcript) ([]TranscriptEntry, error) {
-	resp, err := c.httpClient.Get(transcript.BaseURL)
+	return c.fetchTranscriptContext(context.Background(), transcript)
+}
+
+// FetchTranscriptContext is FetchTranscript with the caption download bound to ctx
+func (c *Client) FetchTranscriptContext(ctx context.Context, transcript Transcript) ([]TranscriptEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.fetchTranscriptContext(ctx, transcript)
+}
+
+// fetchTranscriptContext is fetchTranscript with the caption download bound to ctx
+func (c *Client) fetchTranscriptContext(ctx context.Context, transcript Transcript) ([]TranscriptEntry, error) {
+	entries, err := c.downloadTranscript(ctx, transcript)
+	if err == nil && len(entries) == 0 {
+		return nil, ErrEmptyTranscript{VideoID: videoIDFromURL(transcript.BaseURL), Language: transcript.LanguageCode}
+	}
+	return entries, err
+}
+
+func (c *Client) downloadTranscript(ctx context.Context, transcript Transcript) ([]TranscriptEntry, error) {
+	if c.backend != nil {
+		return c.backend.FetchTranscript(ctx, transcript)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, transcript.BaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var transcriptResp struct {
-		XMLName xml.Name `xml:"transcript"`
-		Texts   []struct {
-			Start float64 `xml:"start,attr"`
-			Dur   float64 `xml:"dur,attr"`
-			Text  string  `xml:",chardata"`
-		} `xml:"text"`
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, ErrTooManyRequests{VideoID: videoIDFromURL(transcript.BaseURL)}
 	}
 
-	if err := xml.NewDecoder(resp.Body).Decode(&transcriptResp); err != nil {
-		return nil, err
+	return ParseTranscriptXML(resp.Body)
+}
+
+// videoIDFromURL returns the v parameter of a caption track URL, or the fragment
+// Data API tracks keep it in
+func videoIDFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	if v := u.Query().Get("v"); v != "" {
+		return v
 	}
+	return u.Fragment
+}
 
+// ParseTranscriptXML decodes the timedtext XML served at a Transcript's BaseURL
+func ParseTranscriptXML(r io.Reader) ([]TranscriptEntry, error) {
 	var entries []TranscriptEntry
-	for _, text := range transcriptResp.Texts {
-		entries = append(entries, TranscriptEntry{
-			Text:     html.UnescapeString(text.Text), // Decode HTML entities
-			Start:    text.Start,
-			Duration: text.Dur,
-		})
+	err := DecodeTranscriptXML(r, func(e TranscriptEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return entries, nil
@@ -280,38 +550,40 @@ func (c *Client) fetchTranscript(transcript Transcript) ([]TranscriptEntry, erro
 // GetTranscriptWithLanguage fetches the transcript for a given video ID in the specified language code
 // If the specified language is not available, it returns an error
 func (c *Client) GetTranscriptWithLanguage(videoID string, languageCode string) ([]TranscriptEntry, error) {
-	videoInfo, err := c.fetchVideoInfo(videoID)
-	if err != nil {
-		return nil, err
+	var opts FetchOptions
+	if languageCode != "" {
+		opts.Languages = []string{languageCode}
 	}
-
-	transcripts, err := extractTranscriptData(videoInfo)
+	result, err := c.GetTranscriptWithOptions(context.Background(), videoID, opts)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(transcripts) == 0 {
-		return nil, ErrNoTranscriptFound{VideoID: videoID}
-	}
-
-	// Try to find transcript in specified language
-	for _, t := range transcripts {
-		if strings.HasPrefix(t.LanguageCode, languageCode) {
-			return c.fetchTranscript(t)
-		}
-	}
-
-	return nil, fmt.Errorf("no transcript found for language code: %s", languageCode)
+	return result.Entries, nil
 }
 
 // ListAvailableTranscripts returns a list of available transcript languages for a video
 func (c *Client) ListAvailableTranscripts(videoID string) ([]Transcript, error) {
-	videoInfo, err := c.fetchVideoInfo(videoID)
+	return c.ListAvailableTranscriptsContext(context.Background(), videoID)
+}
+
+// ListAvailableTranscriptsContext is ListAvailableTranscripts with the watch page fetch bound to ctx,
+// so servers can stop the request when their caller goes away
+func (c *Client) ListAvailableTranscriptsContext(ctx context.Context, videoID string) ([]Transcript, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if c.backend != nil {
+		return c.backend.ListTranscripts(ctx, videoID)
+	}
+	videoInfo, err := c.fetchVideoInfoContext(ctx, videoID)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkPlayability(videoID, videoInfo); err != nil {
+		return nil, err
+	}
 
-	return extractTranscriptData(videoInfo)
+	return extractTranscriptData(videoID, videoInfo)
 }
 
 // FetchMultipleTranscripts fetches transcripts for multiple video IDs concurrently
@@ -337,31 +609,111 @@ func (c *Client) FetchMultipleTranscripts(videoIDs []string) map[string][]Transc
 	return results
 }
 
-// ExtractVideoID extracts the video ID from various YouTube URL formats or returns the ID directly.
-// It supports full youtube.com URLs, short youtu.be URLs, and direct video IDs.
-func ExtractVideoID(input string) string {
+// ExtractVideoID extracts the video ID from a YouTube URL or returns a bare ID directly.
+// It understands watch, shorts, live, embed and /v/ URLs on youtube.com, m.youtube.com,
+// music.youtube.com and youtube-nocookie.com, youtu.be short links and attribution_link
+// redirects. Input without a video ID yields ErrInvalidVideoID.
+func ExtractVideoID(input string) (string, error) {
+	input = strings.TrimSpace(input)
+
 	// Check if the input is already a video ID
-	if len(input) == 11 && !strings.Contains(input, "/") && !strings.Contains(input, ".") {
-		return input
+	if ValidateVideoID(input) == nil {
+		return input, nil
 	}
 
-	// Handle youtube.com URLs
-	if strings.Contains(input, "youtube.com/watch") {
-		if u, err := url.Parse(input); err == nil {
-			if values, err := url.ParseQuery(u.RawQuery); err == nil {
-				if videoID := values.Get("v"); videoID != "" {
-					return videoID
-				}
-			}
+	if id := videoIDFromYouTubeURL(input); id != "" {
+		return id, nil
+	}
+	return "", ErrInvalidVideoID{Input: input}
+}
+
+// videoURLPattern finds YouTube links in free-form text, with or without a scheme
+var videoURLPattern = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z]+\.)?(?:youtube\.com|youtube-nocookie\.com|youtu\.be)/[^\s"'<>()\[\]{}]+`)
+
+// ExtractAllVideoIDs returns the ID of every video linked in text, such as a note, an HTML
+// page or a chat export, in order of first appearance and without duplicates. Bare video IDs
+// are only recognized on a line of their own, as ordinary 11-letter words would match too.
+func ExtractAllVideoIDs(text string) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
 		}
 	}
 
-	// Handle youtu.be URLs
-	if strings.Contains(input, "youtu.be/") {
-		if parts := strings.Split(input, "youtu.be/"); len(parts) == 2 {
-			return strings.Split(parts[1], "?")[0]
+	for _, line := range strings.Split(text, "\n") {
+		if id := strings.TrimSpace(line); ValidateVideoID(id) == nil {
+			add(id)
+			continue
+		}
+		for _, link := range videoURLPattern.FindAllString(line, -1) {
+			// Links copied from HTML keep their entity-encoded ampersands
+			link = strings.ReplaceAll(link, "&amp;", "&")
+			add(videoIDFromYouTubeURL(strings.TrimRight(link, ".,;:!?")))
 		}
 	}
+	return ids
+}
+
+// videoIDPathPrefixes are the youtube.com paths followed directly by a video ID
+var videoIDPathPrefixes = []string{"shorts", "live", "embed", "v", "e"}
 
+// videoIDFromYouTubeURL returns the video ID of a YouTube URL, or "" if it names no video
+func videoIDFromYouTubeURL(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch host {
+	case "youtu.be":
+		return validID(segments[0])
+	case "youtube.com", "m.youtube.com", "music.youtube.com", "youtube-nocookie.com":
+	default:
+		return ""
+	}
+
+	switch segments[0] {
+	case "watch":
+		return validID(u.Query().Get("v"))
+	case "attribution_link":
+		// The target is a relative URL such as /watch?v=ID&feature=share
+		if target := u.Query().Get("u"); strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "/attribution_link") {
+			return videoIDFromYouTubeURL("https://www.youtube.com" + target)
+		}
+		return ""
+	}
+	for _, prefix := range videoIDPathPrefixes {
+		if segments[0] == prefix && len(segments) > 1 {
+			return validID(segments[1])
+		}
+	}
 	return ""
 }
+
+// videoIDPattern is the shape of every YouTube video ID: 11 characters of the URL-safe base64 alphabet
+var videoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// ValidateVideoID returns ErrInvalidVideoID unless id is exactly 11 characters of A-Z, a-z, 0-9, _ and -.
+// It checks the form only; a valid ID may still name a video that does not exist.
+func ValidateVideoID(id string) error {
+	if !videoIDPattern.MatchString(id) {
+		return ErrInvalidVideoID{Input: id}
+	}
+	return nil
+}
+
+// validID returns id if it is a well-formed video ID, and "" otherwise
+func validID(id string) string {
+	if ValidateVideoID(id) != nil {
+		return ""
+	}
+	return id
+}
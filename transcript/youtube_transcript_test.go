@@ -0,0 +1,97 @@
+package transcript
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExtractVideoID(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Direct video ID", input: "VO6XEQIsCoM", expected: "VO6XEQIsCoM"},
+		{name: "YouTube full URL", input: "https://www.youtube.com/watch?v=VO6XEQIsCoM", expected: "VO6XEQIsCoM"},
+		{name: "YouTube short URL", input: "https://youtu.be/VO6XEQIsCoM?si=abc", expected: "VO6XEQIsCoM"},
+		{name: "Additional parameters", input: "https://www.youtube.com/watch?feature=share&v=VO6XEQIsCoM&t=123", expected: "VO6XEQIsCoM"},
+		{name: "Without scheme", input: "youtube.com/watch?v=VO6XEQIsCoM", expected: "VO6XEQIsCoM"},
+		{name: "Shorts", input: "https://www.youtube.com/shorts/VO6XEQIsCoM", expected: "VO6XEQIsCoM"},
+		{name: "Live", input: "https://www.youtube.com/live/VO6XEQIsCoM?feature=share", expected: "VO6XEQIsCoM"},
+		{name: "Embed", input: "https://www.youtube-nocookie.com/embed/VO6XEQIsCoM", expected: "VO6XEQIsCoM"},
+		{name: "Legacy /v/", input: "http://www.youtube.com/v/VO6XEQIsCoM", expected: "VO6XEQIsCoM"},
+		{name: "Music", input: "https://music.youtube.com/watch?v=VO6XEQIsCoM&list=RDAMVM", expected: "VO6XEQIsCoM"},
+		{name: "Mobile", input: "https://m.youtube.com/watch?v=VO6XEQIsCoM", expected: "VO6XEQIsCoM"},
+		{name: "Attribution link", input: "https://www.youtube.com/attribution_link?a=xyz&u=%2Fwatch%3Fv%3DVO6XEQIsCoM%26feature%3Dshare", expected: "VO6XEQIsCoM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExtractVideoID(tt.input)
+			if err != nil || result != tt.expected {
+				t.Errorf("ExtractVideoID(%s) = %s, %v; want %s", tt.input, result, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractVideoID_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"https://example.com/watch?v=VO6XEQIsCoM",
+		"https://www.youtube.com/channel/UCabcdefghijklmnopqrstuv",
+		"https://www.youtube.com/watch?v=short",
+		"https://www.youtube.com/shorts/",
+		"hello.world",
+		"https://youtu.be/VO6XEQ!sCoM",
+	} {
+		_, err := ExtractVideoID(input)
+		var invalid ErrInvalidVideoID
+		if !errors.As(err, &invalid) || invalid.Input != input {
+			t.Errorf("ExtractVideoID(%q) error = %v; want ErrInvalidVideoID", input, err)
+		}
+	}
+}
+
+func TestValidateVideoID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"VO6XEQIsCoM", true},
+		{"dQw4w9WgXcQ", true},
+		{"a-b_c-d_e-f", true},
+		{"hello.world", false},
+		{"hello world", false},
+		{"VO6XEQIsCo", false},
+		{"VO6XEQIsCoMx", false},
+		{"VO6XEQIs%oM", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateVideoID(tt.id)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateVideoID(%q) = %v; want valid %v", tt.id, err, tt.valid)
+		}
+	}
+}
+
+func TestExtractAllVideoIDs(t *testing.T) {
+	text := `Notes from the meeting, see https://www.youtube.com/watch?v=VO6XEQIsCoM.
+Also <a href="https://www.youtube.com/watch?feature=share&amp;v=dQw4w9WgXcQ">this one</a> and
+(youtu.be/a-b_c-d_e-f?t=10), plus the short https://youtube.com/shorts/VO6XEQIsCoM again.
+jNQXAC9IVRw
+Not IDs: information, https://example.com/watch?v=xxxxxxxxxxx, https://www.youtube.com/@handle`
+
+	got := ExtractAllVideoIDs(text)
+	want := []string{"VO6XEQIsCoM", "dQw4w9WgXcQ", "a-b_c-d_e-f", "jNQXAC9IVRw"}
+	if len(got) != len(want) {
+		t.Fatalf("ExtractAllVideoIDs() = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ExtractAllVideoIDs()[%d] = %s; want %s", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package translate
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DeepL translates with the DeepL API
+type DeepL struct {
+	service
+}
+
+// NewDeepL creates a DeepL adapter; keys ending in ":fx" use the free API endpoint
+func NewDeepL(apiKey string, options ...Option) *DeepL {
+	baseURL := "https://api.deepl.com"
+	if strings.HasSuffix(apiKey, ":fx") {
+		baseURL = "https://api-free.deepl.com"
+	}
+	return &DeepL{newService("deepl", baseURL, apiKey, options)}
+}
+
+// Translate implements transcript.Translator
+func (d *DeepL) Translate(texts []string, source, target string) ([]string, error) {
+	body := map[string]interface{}{
+		"text":        texts,
+		"target_lang": strings.ToUpper(target),
+	}
+	if source != "" {
+		// DeepL only accepts the base language as a source, e.g. EN rather than EN-GB
+		body["source_lang"] = strings.ToUpper(strings.SplitN(source, "-", 2)[0])
+	}
+
+	var resp struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	header := http.Header{"Authorization": {"DeepL-Auth-Key " + d.apiKey}}
+	if err := d.post(d.baseURL+"/v2/translate", header, body, &resp); err != nil {
+		return nil, err
+	}
+
+	out := make([]string, 0, len(resp.Translations))
+	for _, t := range resp.Translations {
+		out = append(out, t.Text)
+	}
+	return out, nil
+}
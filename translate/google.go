@@ -0,0 +1,47 @@
+package translate
+
+import (
+	"html"
+	"net/url"
+)
+
+// Google translates with the Google Cloud Translation API (v2, API key authentication)
+type Google struct {
+	service
+}
+
+// NewGoogle creates a Google Cloud Translation adapter
+func NewGoogle(apiKey string, options ...Option) *Google {
+	return &Google{newService("google", "https://translation.googleapis.com", apiKey, options)}
+}
+
+// Translate implements transcript.Translator
+func (g *Google) Translate(texts []string, source, target string) ([]string, error) {
+	body := map[string]interface{}{
+		"q":      texts,
+		"target": target,
+		"format": "text",
+	}
+	if source != "" {
+		body["source"] = source
+	}
+
+	var resp struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText string `json:"translatedText"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+	endpoint := g.baseURL + "/language/translate/v2?key=" + url.QueryEscape(g.apiKey)
+	if err := g.post(endpoint, nil, body, &resp); err != nil {
+		return nil, err
+	}
+
+	out := make([]string, 0, len(resp.Data.Translations))
+	for _, t := range resp.Data.Translations {
+		// Entities can appear even in text format, e.g. &#39; for apostrophes
+		out = append(out, html.UnescapeString(t.TranslatedText))
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package translate
+
+import "strings"
+
+// LibreTranslate translates with a LibreTranslate instance
+type LibreTranslate struct {
+	service
+}
+
+// NewLibreTranslate creates an adapter for the instance at baseURL; apiKey may be empty
+func NewLibreTranslate(baseURL, apiKey string, options ...Option) *LibreTranslate {
+	return &LibreTranslate{newService("libretranslate", strings.TrimSuffix(baseURL, "/"), apiKey, options)}
+}
+
+// Translate implements transcript.Translator
+func (l *LibreTranslate) Translate(texts []string, source, target string) ([]string, error) {
+	if source == "" {
+		source = "auto"
+	}
+	body := map[string]interface{}{
+		"q":      texts,
+		"source": source,
+		"target": target,
+		"format": "text",
+	}
+	if l.apiKey != "" {
+		body["api_key"] = l.apiKey
+	}
+
+	var resp struct {
+		TranslatedText []string `json:"translatedText"`
+	}
+	if err := l.post(l.baseURL+"/translate", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.TranslatedText, nil
+}
@@ -0,0 +1,118 @@
+// Package translate provides transcript.Translator adapters for machine translation services.
+//
+// The adapters translate whole batches of caption lines per request and leave timing to
+// transcript.TranslateEntries, so translated transcripts keep the original entry start times.
+package translate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// Environment variables read by FromEnv
+const (
+	EnvDeepLKey          = "DEEPL_API_KEY"
+	EnvGoogleKey         = "GOOGLE_TRANSLATE_API_KEY"
+	EnvLibreTranslateURL = "LIBRETRANSLATE_URL"
+	EnvLibreTranslateKey = "LIBRETRANSLATE_API_KEY"
+)
+
+// Providers lists the names accepted by FromEnv
+var Providers = []string{"deepl", "google", "libretranslate"}
+
+// Option configures an adapter
+type Option func(*service)
+
+// WithHTTPClient replaces the HTTP client used for requests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(s *service) {
+		s.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the service endpoint, e.g. for a self-hosted or proxied instance
+func WithBaseURL(url string) Option {
+	return func(s *service) {
+		s.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// service holds what every adapter needs to call its API
+type service struct {
+	name       string
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newService(name, baseURL, apiKey string, options []Option) service {
+	s := service{name: name, baseURL: baseURL, apiKey: apiKey, httpClient: &http.Client{}}
+	for _, opt := range options {
+		opt(&s)
+	}
+	return s
+}
+
+// FromEnv returns the named provider configured from its environment variables
+func FromEnv(provider string, options ...Option) (transcript.Translator, error) {
+	switch strings.ToLower(provider) {
+	case "deepl":
+		key := os.Getenv(EnvDeepLKey)
+		if key == "" {
+			return nil, fmt.Errorf("deepl needs an API key in %s", EnvDeepLKey)
+		}
+		return NewDeepL(key, options...), nil
+	case "google":
+		key := os.Getenv(EnvGoogleKey)
+		if key == "" {
+			return nil, fmt.Errorf("google needs an API key in %s", EnvGoogleKey)
+		}
+		return NewGoogle(key, options...), nil
+	case "libretranslate":
+		url := os.Getenv(EnvLibreTranslateURL)
+		if url == "" {
+			return nil, fmt.Errorf("libretranslate needs the instance URL in %s", EnvLibreTranslateURL)
+		}
+		return NewLibreTranslate(url, os.Getenv(EnvLibreTranslateKey), options...), nil
+	}
+	return nil, fmt.Errorf("unknown translation provider %q (want %s)", provider, strings.Join(Providers, ", "))
+}
+
+// post sends body as JSON to url and decodes the JSON response into out
+func (s service) post(url string, header http.Header, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %v", s.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s request failed: HTTP %d: %s", s.name, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding %s response: %v", s.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package translate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mjlefevre/yt-words-go/transcript"
+)
+
+// fakeService decodes each request body, checks it with check and answers with response
+func fakeService(t *testing.T, check func(r *http.Request, body map[string]interface{}), response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request: %v", err)
+		}
+		check(r, body)
+		w.Write([]byte(response))
+	}))
+}
+
+func TestAdapters(t *testing.T) {
+	tests := []struct {
+		name     string
+		new      func(url string) transcript.Translator
+		check    func(t *testing.T, r *http.Request, body map[string]interface{})
+		response string
+	}{
+		{
+			name: "deepl",
+			new:  func(url string) transcript.Translator { return NewDeepL("key:fx", WithBaseURL(url)) },
+			check: func(t *testing.T, r *http.Request, body map[string]interface{}) {
+				if r.URL.Path != "/v2/translate" || r.Header.Get("Authorization") != "DeepL-Auth-Key key:fx" {
+					t.Errorf("request %s with Authorization %q", r.URL.Path, r.Header.Get("Authorization"))
+				}
+				if body["target_lang"] != "DE" || body["source_lang"] != "EN" {
+					t.Errorf("body = %v; want target_lang DE, source_lang EN", body)
+				}
+			},
+			response: `{"translations":[{"text":"Hallo"},{"text":"Welt"}]}`,
+		},
+		{
+			name: "google",
+			new:  func(url string) transcript.Translator { return NewGoogle("key", WithBaseURL(url)) },
+			check: func(t *testing.T, r *http.Request, body map[string]interface{}) {
+				if r.URL.Path != "/language/translate/v2" || r.URL.Query().Get("key") != "key" {
+					t.Errorf("request %s", r.URL)
+				}
+				if body["target"] != "de" || body["source"] != "en-GB" {
+					t.Errorf("body = %v; want target de, source en-GB", body)
+				}
+			},
+			response: `{"data":{"translations":[{"translatedText":"Hallo"},{"translatedText":"Welt"}]}}`,
+		},
+		{
+			name: "libretranslate",
+			new:  func(url string) transcript.Translator { return NewLibreTranslate(url+"/", "") },
+			check: func(t *testing.T, r *http.Request, body map[string]interface{}) {
+				if r.URL.Path != "/translate" {
+					t.Errorf("request %s", r.URL)
+				}
+				if _, ok := body["api_key"]; ok || body["target"] != "de" {
+					t.Errorf("body = %v; want target de and no api_key", body)
+				}
+			},
+			response: `{"translatedText":["Hallo","Welt"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeService(t, func(r *http.Request, body map[string]interface{}) { tt.check(t, r, body) }, tt.response)
+			defer srv.Close()
+
+			got, err := tt.new(srv.URL).Translate([]string{"Hello", "world"}, "en-GB", "de")
+			if err != nil {
+				t.Fatalf("Translate() error = %v", err)
+			}
+			if want := []string{"Hallo", "Welt"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Translate() = %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTranslate_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", 456)
+	}))
+	defer srv.Close()
+
+	_, err := NewDeepL("key", WithBaseURL(srv.URL)).Translate([]string{"Hello"}, "", "de")
+	if err == nil || !strings.Contains(err.Error(), "HTTP 456") {
+		t.Errorf("Translate() error = %v; want HTTP 456", err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(EnvDeepLKey, "")
+	if _, err := FromEnv("deepl"); err == nil {
+		t.Error("FromEnv(deepl) without key expected error")
+	}
+	if _, err := FromEnv("bing"); err == nil {
+		t.Error("FromEnv(bing) expected error")
+	}
+
+	t.Setenv(EnvLibreTranslateURL, "http://localhost:5000")
+	if tr, err := FromEnv("LibreTranslate"); err != nil {
+		t.Errorf("FromEnv(LibreTranslate) error = %v", err)
+	} else if _, ok := tr.(*LibreTranslate); !ok {
+		t.Errorf("FromEnv(LibreTranslate) = %T; want *LibreTranslate", tr)
+	}
+}